@@ -0,0 +1,88 @@
+// Package eventsHandler streams the transaction lifecycle events of pkg/events to the frontend as
+// server-sent events, e.g. GET /v1/events?key=<userOpHash>&key=<signer>.
+//
+// The bus is in-process, so the stream only sees requests relayed by the same instance. It needs
+// the long running server of main.go, serverless functions can't hold the connection.
+package eventsHandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/events"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+	retryMillis       = 3000 // reconnect delay sent to the EventSource
+	maxKeys           = 16
+)
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(http.HandlerFunc(stream)).ServeHTTP(w, r)
+}
+
+// stream subscribes to every key param, comma separated or repeated. A reconnecting EventSource
+// sends Last-Event-ID and gets the recent events it missed, or every recent event when the id was
+// sent before a restart.
+func stream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, utils.Err(apierr.MethodNotAllowed, fmt.Sprintf("%s expects GET", r.URL.Path)))
+		return
+	}
+	var keys []string
+	for _, param := range r.URL.Query()["key"] {
+		for _, key := range strings.Split(param, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(keys) == 0 || len(keys) > maxKeys {
+		utils.WriteError(w, utils.ErrMalformedRequest(fmt.Sprintf("expected 1 to %d keys: userOpHash, message hash or signer address", maxKeys)))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.WriteError(w, utils.ErrInternal("streaming is not supported"))
+		return
+	}
+
+	ch, cancel := events.Subscribe(events.After(r.Header.Get("Last-Event-ID")), keys...)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				utils.LogError("failed to encode event", err.Error())
+				continue
+			}
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.Id(), event.Kind, data)
+		}
+		flusher.Flush()
+	}
+}
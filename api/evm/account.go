@@ -0,0 +1,82 @@
+package evmHandler
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// EscrowAssetState is the escrow balance, locked amount and lock deadline of an asset
+type EscrowAssetState struct {
+	Asset    common.Address
+	Balance  *big.Int
+	Locked   *big.Int
+	Deadline *big.Int
+}
+
+// UserChainState is the SimpleAccount and escrow of an owner on a chain
+type UserChainState struct {
+	Account         common.Address
+	AccountDeployed bool
+	Escrow          common.Address
+	EscrowDeployed  bool
+	Assets          []EscrowAssetState // empty until the escrow is deployed
+}
+
+// GetUserChainState reads the owner's SimpleAccount (salt 0) and escrow, and the escrow
+// asset info for every asset. Contracts that are not configured on the chain are skipped.
+func GetUserChainState(client *ethclient.Client, chain *chains.Chain, owner common.Address, escrowSalt []byte, assets []common.Address) (UserChainState, error) {
+	state := UserChainState{}
+
+	if chain.Contracts.SimpleAccountFactory != "" {
+		account, err := GetAccountAddress(client, common.HexToAddress(chain.Contracts.SimpleAccountFactory), owner, big.NewInt(0))
+		if err != nil {
+			return state, err
+		}
+		size, err := ExtCodeSize(client, account)
+		if err != nil {
+			return state, err
+		}
+		state.Account = account
+		state.AccountDeployed = size > 0
+	}
+
+	if chain.Contracts.EscrowFactory == "" || chain.Contracts.Escrow == "" {
+		return state, nil
+	}
+	escrowAddressBytes, _, err := GetEscrowAddress(
+		client,
+		owner,
+		common.HexToAddress(chain.Contracts.EscrowFactory),
+		common.HexToAddress(chain.Contracts.Escrow),
+		escrowSalt)
+	if err != nil {
+		return state, err
+	}
+	state.Escrow = common.BytesToAddress(escrowAddressBytes)
+	size, err := ExtCodeSize(client, state.Escrow)
+	if err != nil {
+		return state, err
+	}
+	state.EscrowDeployed = size > 0
+	if !state.EscrowDeployed {
+		return state, nil
+	}
+
+	for _, asset := range assets {
+		balance, locked, deadline, err := GetEscrowAssetInfo(client, state.Escrow, asset)
+		if err != nil {
+			return state, fmt.Errorf("asset %s: %v", asset.Hex(), err)
+		}
+		state.Assets = append(state.Assets, EscrowAssetState{
+			Asset:    asset,
+			Balance:  balance,
+			Locked:   locked,
+			Deadline: deadline,
+		})
+	}
+	return state, nil
+}
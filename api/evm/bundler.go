@@ -0,0 +1,326 @@
+package evmHandler
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// time a relayer tx waits for its receipt before the hash is returned as pending
+const receiptTimeout = 90 * time.Second
+
+// ValidationData is the unpacked account or paymaster validationData
+type ValidationData struct {
+	SigFailed  bool
+	Aggregator common.Address
+	ValidAfter uint64
+	ValidUntil uint64 // 0 means no expiry
+}
+
+// EscrowLock is the state of an escrow asset on the origin chain
+type EscrowLock struct {
+	EscrowAddress common.Address
+	Deployed      bool
+	Balance       *big.Int
+	Locked        *big.Int
+	Deadline      *big.Int
+}
+
+// HandleOpsResult is the outcome of a bundle with a single op
+type HandleOpsResult struct {
+	TxHash        common.Hash
+	Mined         bool
+	Status        uint64 // receipt status, only set once mined
+	BlockNumber   *big.Int
+	GasUsed       uint64
+	OpSuccess     bool // UserOperationEvent success flag
+	ActualGasCost *big.Int
+}
+
+// UserOperationEvent is the EntryPoint event emitted for every executed op
+type UserOperationEvent struct {
+	UserOpHash    common.Hash
+	Sender        common.Address
+	Paymaster     common.Address
+	Nonce         *big.Int
+	Success       bool
+	ActualGasCost *big.Int
+	ActualGasUsed *big.Int
+}
+
+// ParseValidationData splits validationData into aggregator, validUntil and validAfter
+func ParseValidationData(validationData *big.Int) ValidationData {
+	var word [32]byte
+	validationData.FillBytes(word[:])
+
+	aggregator := common.BytesToAddress(word[12:32])
+	validUntil := new(big.Int).SetBytes(word[6:12]).Uint64()
+	validAfter := new(big.Int).SetBytes(word[0:6]).Uint64()
+
+	return ValidationData{
+		SigFailed:  aggregator == common.BytesToAddress([]byte{1}),
+		Aggregator: aggregator,
+		ValidAfter: validAfter,
+		ValidUntil: validUntil,
+	}
+}
+
+// RecoverUserOpSigner returns the address that signed userOpHash, SimpleAccount
+// signs the eth signed message hash of the userOpHash
+func RecoverUserOpSigner(userOpHash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+
+	sig := make([]byte, 65)
+	copy(sig, signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(ToEthSignedMessageHash(userOpHash.Bytes()), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature: %v", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// GetEscrowLock reads the owner's escrow balance, locked amount and deadline for asset
+// with a single multicallView
+func GetEscrowLock(client *ethclient.Client, chain *chains.Chain, owner common.Address, asset common.Address, salt []byte) (EscrowLock, error) {
+	if chain.Contracts.EscrowFactory == "" || chain.Contracts.Escrow == "" {
+		return EscrowLock{}, fmt.Errorf("escrow not configured for chain id: %s", chain.ID)
+	}
+	multicallAddress, err := getMulticallAddress(chain.ID)
+	if err != nil {
+		return EscrowLock{}, err
+	}
+
+	escrowAddressBytes, _, err := GetEscrowAddress(
+		client,
+		owner,
+		common.HexToAddress(chain.Contracts.EscrowFactory),
+		common.HexToAddress(chain.Contracts.Escrow),
+		salt)
+	if err != nil {
+		return EscrowLock{}, err
+	}
+	escrowAddress := common.BytesToAddress(escrowAddressBytes)
+
+	multicallJSON, _ := abi.JSON(strings.NewReader(contractAbiMulticall))
+	escrowJSON, _ := abi.JSON(strings.NewReader(contractAbiEscrow))
+
+	results, err := MulticallView(client, multicallAddress, []Calls{
+		{contractAddress: multicallAddress, abi: multicallJSON, method: "getExtcodesize", params: escrowAddress},
+		{contractAddress: escrowAddress, abi: escrowJSON, method: "getAssetInfo", params: asset},
+	})
+	if err != nil {
+		return EscrowLock{}, err
+	}
+	if len(results) != 2 || !results[0].Success {
+		return EscrowLock{}, fmt.Errorf("getExtcodesize failed for escrow %s", escrowAddress.Hex())
+	}
+
+	lock := EscrowLock{
+		EscrowAddress: escrowAddress,
+		Balance:       big.NewInt(0),
+		Locked:        big.NewInt(0),
+		Deadline:      big.NewInt(0),
+	}
+	lock.Deployed = new(big.Int).SetBytes(results[0].ReturnData).Sign() > 0
+	if !lock.Deployed || !results[1].Success {
+		return lock, nil
+	}
+
+	assetInfo, err := escrowJSON.Unpack("getAssetInfo", results[1].ReturnData)
+	if err != nil || len(assetInfo) != 3 {
+		return EscrowLock{}, fmt.Errorf("failed getAssetInfo parse: %v", err)
+	}
+	lock.Balance = assetInfo[0].(*big.Int)
+	lock.Locked = assetInfo[1].(*big.Int)
+	lock.Deadline = assetInfo[2].(*big.Int)
+	return lock, nil
+}
+
+// SimulateUserOperation validates and executes op against the EntryPointSimulations
+// with the signed op, returns the account and paymaster validation data
+func SimulateUserOperation(ctx context.Context, client *ethclient.Client, chain *chains.Chain, op PackedUserOperation) (ValidationData, ValidationData, error) {
+	if chain.Contracts.EntrypointSimulations == "" {
+		return ValidationData{}, ValidationData{}, fmt.Errorf("entrypoint simulations not configured for chain id: %s", chain.ID)
+	}
+	entrypoint := common.HexToAddress(chain.Contracts.Entrypoint)
+
+	simulationsCode, err := client.CodeAt(ctx, common.HexToAddress(chain.Contracts.EntrypointSimulations), nil)
+	if err != nil {
+		return ValidationData{}, ValidationData{}, fmt.Errorf("failed to get entrypoint simulations code: %v", err)
+	}
+	code := hexutil.Bytes(simulationsCode)
+	overrides := stateOverride{entrypoint: {Code: &code}}
+
+	result, err := simulationCall(ctx, client, entrypoint, overrides, "simulateValidation", op)
+	if err != nil {
+		return ValidationData{}, ValidationData{}, err
+	}
+	accountValidationData, err := bigField(result, "ReturnInfo", "AccountValidationData")
+	if err != nil {
+		return ValidationData{}, ValidationData{}, err
+	}
+	paymasterValidationData, err := bigField(result, "ReturnInfo", "PaymasterValidationData")
+	if err != nil {
+		return ValidationData{}, ValidationData{}, err
+	}
+
+	if _, _, err := simulateHandleOp(ctx, client, entrypoint, overrides, op); err != nil {
+		return ValidationData{}, ValidationData{}, err
+	}
+
+	return ParseValidationData(accountValidationData), ParseValidationData(paymasterValidationData), nil
+}
+
+// SubmitHandleOps sends handleOps([op], beneficiary) from the relayer key and waits for the receipt,
+// a receipt that is not mined within the timeout is returned with Mined false
+func SubmitHandleOps(ctx context.Context, client *ethclient.Client, chain *chains.Chain, op PackedUserOperation, privateKey *ecdsa.PrivateKey, beneficiary common.Address) (HandleOpsResult, error) {
+	entrypointJSON, err := abi.JSON(strings.NewReader(contractAbiEntrypoint))
+	if err != nil {
+		return HandleOpsResult{}, err
+	}
+	entrypoint := common.HexToAddress(chain.Contracts.Entrypoint)
+
+	data, err := entrypointJSON.Pack("handleOps", []PackedUserOperation{op}, beneficiary)
+	if err != nil {
+		return HandleOpsResult{}, fmt.Errorf("failed to pack handleOps: %v", err)
+	}
+
+	signedTx, receipt, err := SendTransaction(ctx, client, privateKey, entrypoint, big.NewInt(0), data)
+	if err != nil {
+		return HandleOpsResult{}, err
+	}
+
+	result := HandleOpsResult{TxHash: signedTx.Hash()}
+	if receipt == nil {
+		// the tx is out, the caller can track it by hash
+		return result, nil
+	}
+
+	result.Mined = true
+	result.Status = receipt.Status
+	result.BlockNumber = receipt.BlockNumber
+	result.GasUsed = receipt.GasUsed
+
+	for _, event := range UserOperationEvents(receipt, entrypoint) {
+		result.OpSuccess = event.Success
+		result.ActualGasCost = event.ActualGasCost
+	}
+
+	return result, nil
+}
+
+// SendTransaction signs and sends a dynamic fee tx from privateKey and waits for the receipt,
+// the receipt is nil when the tx was sent but not mined within the timeout
+func SendTransaction(ctx context.Context, client *ethclient.Client, privateKey *ecdsa.PrivateKey, to common.Address, value *big.Int, data []byte) (*types.Transaction, *types.Receipt, error) {
+	return sendTransaction(ctx, client, privateKey, to, value, data, nil)
+}
+
+// sendTransaction is SendTransaction calling signed with the hash of the signed tx before it
+// is sent, nothing is sent when signed fails
+func sendTransaction(ctx context.Context, client *ethclient.Client, privateKey *ecdsa.PrivateKey, to common.Address, value *big.Int, data []byte, signed func(common.Hash) error) (*types.Transaction, *types.Receipt, error) {
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce, err := client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	priorityFee, maxFee, err := GetFeeCaps(ctx, client, DefaultGasOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	estimatedGas, err := client.EstimateGas(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("gas estimation failed: %v", err)
+	}
+	gasLimit := 120 * estimatedGas / 100
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainId,
+		Nonce:     nonce,
+		GasTipCap: priorityFee,
+		GasFeeCap: maxFee,
+		Gas:       gasLimit,
+		To:        &to,
+		Value:     value,
+		Data:      data,
+	})
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainId), privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if signed != nil {
+		if err := signed(signedTx.Hash()); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := client.SendTransaction(ctx, signedTx); err != nil {
+		return nil, nil, fmt.Errorf("failed to send transaction: %v", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, receiptTimeout)
+	defer cancel()
+	receipt, err := bind.WaitMined(waitCtx, client, signedTx)
+	if err != nil {
+		return signedTx, nil, nil
+	}
+	return signedTx, receipt, nil
+}
+
+// UserOperationEvents returns the UserOperationEvent logs of entrypoint in the receipt
+func UserOperationEvents(receipt *types.Receipt, entrypoint common.Address) []UserOperationEvent {
+	entrypointJSON, err := abi.JSON(strings.NewReader(contractAbiEntrypoint))
+	if err != nil {
+		return nil
+	}
+	event := entrypointJSON.Events["UserOperationEvent"]
+
+	var events []UserOperationEvent
+	for _, log := range receipt.Logs {
+		if log.Address != entrypoint || len(log.Topics) != 4 || log.Topics[0] != event.ID {
+			continue
+		}
+		values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil || len(values) != 4 {
+			continue
+		}
+		events = append(events, UserOperationEvent{
+			UserOpHash:    log.Topics[1],
+			Sender:        common.BytesToAddress(log.Topics[2].Bytes()),
+			Paymaster:     common.BytesToAddress(log.Topics[3].Bytes()),
+			Nonce:         values[0].(*big.Int),
+			Success:       values[1].(bool),
+			ActualGasCost: values[2].(*big.Int),
+			ActualGasUsed: values[3].(*big.Int),
+		})
+	}
+	return events
+}
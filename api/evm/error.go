@@ -1,7 +1,44 @@
 package evmHandler
 
-import "github.com/crosscall-labs/crosschain-api/pkg/utils"
+import (
+	"strings"
+	"sync"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
 
 func GetOrigin() string {
 	return utils.GetOrigin()
 }
+
+// revertAbis hold the custom errors a simulated op can revert with
+var revertAbis = sync.OnceValue(func() []*abi.ABI {
+	var abis []*abi.ABI
+	for _, contractAbi := range []string{
+		contractAbiEntrypoint,
+		contractAbiEntrypointSimulations,
+		contractAbiSimpleAccount,
+		contractAbiPaymaster,
+		contractAbiEscrow,
+	} {
+		parsed, err := abi.JSON(strings.NewReader(contractAbi))
+		if err != nil {
+			utils.LogError("failed to parse revert abi", err.Error())
+			continue
+		}
+		abis = append(abis, &parsed)
+	}
+	return abis
+})
+
+// SimulationError returns a simulation-revert with the decoded reason when err carries revert
+// data, other errors are internal
+func SimulationError(err error) error {
+	if revert, ok := apierr.FromRevert(err, revertAbis()...); ok {
+		revert.Origin = utils.GetOrigin()
+		return revert
+	}
+	return utils.ErrInternal(err.Error())
+}
@@ -0,0 +1,368 @@
+package evmHandler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// GasOptions controls how simulated gas is turned into op limits, margins are multipliers
+type GasOptions struct {
+	VerificationGasMargin    float64
+	CallGasMargin            float64
+	PaymasterGasMargin       float64
+	PreVerificationGasMargin float64
+	MinCallGasLimit          uint64
+	MinPostOpGasLimit        uint64
+	SimulationGasLimit       uint64 // limits used while simulating, high enough to never run out
+	FeeHistoryBlocks         uint64
+	FeeHistoryPercentile     float64 // priority fee percentile of every block
+	BaseFeeMultiplier        float64 // headroom for base fee growth before inclusion
+}
+
+var DefaultGasOptions = GasOptions{
+	VerificationGasMargin:    1.3,
+	CallGasMargin:            1.2,
+	PaymasterGasMargin:       1.3,
+	PreVerificationGasMargin: 1.1,
+	MinCallGasLimit:          10000,
+	MinPostOpGasLimit:        10000,
+	SimulationGasLimit:       10000000,
+	FeeHistoryBlocks:         10,
+	FeeHistoryPercentile:     50,
+	BaseFeeMultiplier:        2,
+}
+
+// preVerificationGas constants, same values as the reference bundler
+const (
+	pvgFixed        = 21000
+	pvgPerUserOp    = 18300
+	pvgPerWord      = 4
+	pvgZeroByte     = 4
+	pvgNonZeroByte  = 16
+	pvgIntrinsicGas = 21000 // subtracted from eth_estimateGas for the inner account call
+)
+
+// well formed signature that recovers to a random address, SimpleAccount returns
+// SIG_VALIDATION_FAILED for it instead of reverting
+var dummySignature = common.FromHex("0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c")
+
+type stateOverride map[common.Address]overrideAccount
+
+type overrideAccount struct {
+	Code    *hexutil.Bytes `json:"code,omitempty"`
+	Balance *hexutil.Big   `json:"balance,omitempty"`
+}
+
+type callArgs struct {
+	From *common.Address `json:"from,omitempty"`
+	To   *common.Address `json:"to"`
+	Gas  *hexutil.Uint64 `json:"gas,omitempty"`
+	Data hexutil.Bytes   `json:"data"`
+}
+
+// GetFeeCaps returns maxPriorityFeePerGas and maxFeePerGas from eth_feeHistory,
+// chains without fee history fall back to eth_gasPrice for both
+func GetFeeCaps(ctx context.Context, client *ethclient.Client, opts GasOptions) (*big.Int, *big.Int, error) {
+	history, err := client.FeeHistory(ctx, opts.FeeHistoryBlocks, nil, []float64{opts.FeeHistoryPercentile})
+	if err != nil || len(history.BaseFee) == 0 {
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get gas price: %v", err)
+		}
+		return gasPrice, gasPrice, nil
+	}
+
+	priorityFee, maxFeePerGas := feeCaps(history, opts)
+	return priorityFee, maxFeePerGas, nil
+}
+
+// feeCaps averages the priority fee percentile of the blocks, the fee cap leaves headroom for
+// the base fee of the next block
+func feeCaps(history *ethereum.FeeHistory, opts GasOptions) (*big.Int, *big.Int) {
+	priorityFee := big.NewInt(0)
+	samples := 0
+	for _, reward := range history.Reward {
+		if len(reward) > 0 && reward[0] != nil {
+			priorityFee.Add(priorityFee, reward[0])
+			samples++
+		}
+	}
+	if samples > 0 {
+		priorityFee.Div(priorityFee, big.NewInt(int64(samples)))
+	}
+
+	// the last entry is the base fee of the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	maxFeePerGas := applyMargin(baseFee, opts.BaseFeeMultiplier)
+	maxFeePerGas.Add(maxFeePerGas, priorityFee)
+	return priorityFee, maxFeePerGas
+}
+
+// EstimateUserOperationGas simulates op against the chain's EntryPointSimulations and fills
+// its gas limits, the simulations code is swapped into the EntryPoint with a state override
+func EstimateUserOperationGas(ctx context.Context, client *ethclient.Client, chain *chains.Chain, op *PackedUserOperation, opts GasOptions) error {
+	if chain.Contracts.EntrypointSimulations == "" {
+		return fmt.Errorf("entrypoint simulations not configured for chain id: %s", chain.ID)
+	}
+	entrypoint := common.HexToAddress(chain.Contracts.Entrypoint)
+
+	simulationsCode, err := client.CodeAt(ctx, common.HexToAddress(chain.Contracts.EntrypointSimulations), nil)
+	if err != nil {
+		return fmt.Errorf("failed to get entrypoint simulations code: %v", err)
+	}
+	if len(simulationsCode) == 0 {
+		return fmt.Errorf("entrypoint simulations not deployed on chain id: %s", chain.ID)
+	}
+	code := hexutil.Bytes(simulationsCode)
+	overrides := stateOverride{entrypoint: {Code: &code}}
+
+	simulationLimit := new(big.Int).SetUint64(opts.SimulationGasLimit)
+	hasPaymaster := len(op.PaymasterAndData) >= 52
+
+	// zero fees so no prefund is needed while measuring
+	sim := copyUserOperation(*op)
+	sim.AccountGasLimits = PackGasPair(simulationLimit, simulationLimit)
+	sim.PreVerificationGas = big.NewInt(0)
+	sim.GasFees = [32]byte{}
+	if len(sim.Signature) == 0 {
+		sim.Signature = dummySignature
+	}
+
+	// account validation alone, the paymaster is measured as the difference
+	accountOnly := copyUserOperation(sim)
+	accountOnly.PaymasterAndData = []byte{}
+	accountGas, err := simulateValidation(ctx, client, entrypoint, overrides, accountOnly)
+	if err != nil {
+		return err
+	}
+
+	paymasterVerificationGas := big.NewInt(0)
+	if hasPaymaster {
+		setPaymasterGasLimits(&sim, simulationLimit, simulationLimit)
+		totalGas, err := simulateValidation(ctx, client, entrypoint, overrides, sim)
+		if err != nil {
+			return err
+		}
+		if totalGas.Cmp(accountGas) > 0 {
+			paymasterVerificationGas.Sub(totalGas, accountGas)
+		}
+	}
+
+	callGas, err := estimateCallGas(ctx, client, chain, *op, opts)
+	if err != nil {
+		return err
+	}
+
+	verificationGasLimit := applyMargin(accountGas, opts.VerificationGasMargin)
+	callGasLimit := applyMargin(callGas, opts.CallGasMargin)
+	op.AccountGasLimits = PackGasPair(verificationGasLimit, callGasLimit)
+
+	if hasPaymaster {
+		paymasterVerificationGasLimit := applyMargin(paymasterVerificationGas, opts.PaymasterGasMargin)
+
+		// run the whole op at 1 wei gas so paid is the gas used, then remove the
+		// v0.7 penalty of 10% of the unused execution gas to get the postOp usage
+		postOpLimit := new(big.Int).Set(simulationLimit)
+		sim.AccountGasLimits = op.AccountGasLimits
+		sim.GasFees = PackGasPair(big.NewInt(1), big.NewInt(1))
+		setPaymasterGasLimits(&sim, paymasterVerificationGasLimit, postOpLimit)
+		preOpGas, paid, err := simulateHandleOp(ctx, client, entrypoint, overrides, sim)
+		if err != nil {
+			return err
+		}
+
+		postOpGas := postOpGasUsed(preOpGas, paid, callGas, new(big.Int).Add(callGasLimit, postOpLimit), opts)
+		setPaymasterGasLimits(op, paymasterVerificationGasLimit, applyMargin(postOpGas, opts.PaymasterGasMargin))
+	}
+
+	op.PreVerificationGas, err = CalculatePreVerificationGas(*op, opts)
+	return err
+}
+
+// postOpGasUsed takes the postOp usage out of a handleOp simulated at 1 wei gas. paid is
+// preOpGas + execution used + the v0.7 penalty of 10% of the unused execution limit, so
+// used = (paid - preOpGas - limit/10) * 10/9, and postOp is what the call did not use.
+func postOpGasUsed(preOpGas, paid, callGas, executionLimit *big.Int, opts GasOptions) *big.Int {
+	executionUsed := new(big.Int).Sub(paid, preOpGas)
+	executionUsed.Sub(executionUsed, new(big.Int).Div(executionLimit, big.NewInt(10)))
+	executionUsed.Mul(executionUsed, big.NewInt(10))
+	executionUsed.Div(executionUsed, big.NewInt(9))
+
+	postOpGas := executionUsed.Sub(executionUsed, callGas)
+	if postOpGas.Cmp(new(big.Int).SetUint64(opts.MinPostOpGasLimit)) < 0 {
+		postOpGas.SetUint64(opts.MinPostOpGasLimit)
+	}
+	return postOpGas
+}
+
+// CalculatePreVerificationGas prices the op's share of the bundle calldata and overhead
+func CalculatePreVerificationGas(op PackedUserOperation, opts GasOptions) (*big.Int, error) {
+	if len(op.Signature) == 0 {
+		op.Signature = dummySignature
+	}
+	if op.PreVerificationGas == nil {
+		op.PreVerificationGas = big.NewInt(0)
+	}
+
+	parsedJSON, err := abi.JSON(strings.NewReader(contractAbiEntrypointSimulations))
+	if err != nil {
+		return nil, err
+	}
+	packed, err := parsedJSON.Methods["simulateValidation"].Inputs.Pack(op)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack user operation: %v", err)
+	}
+
+	gas := uint64(pvgFixed + pvgPerUserOp)
+	gas += uint64((len(packed)+31)/32) * pvgPerWord
+	for _, b := range packed {
+		if b == 0 {
+			gas += pvgZeroByte
+		} else {
+			gas += pvgNonZeroByte
+		}
+	}
+	return applyMargin(new(big.Int).SetUint64(gas), opts.PreVerificationGasMargin), nil
+}
+
+// estimateCallGas runs the account callData from the EntryPoint, an undeployed sender
+// gets the SimpleAccount implementation code so the call can run before deployment
+func estimateCallGas(ctx context.Context, client *ethclient.Client, chain *chains.Chain, op PackedUserOperation, opts GasOptions) (*big.Int, error) {
+	minimum := new(big.Int).SetUint64(opts.MinCallGasLimit)
+	if len(op.CallData) == 0 {
+		return minimum, nil
+	}
+
+	overrides := stateOverride{}
+	if len(op.InitCode) > 0 {
+		if chain.Contracts.SimpleAccount == "" {
+			return nil, fmt.Errorf("simple account not configured for chain id: %s", chain.ID)
+		}
+		accountCode, err := client.CodeAt(ctx, common.HexToAddress(chain.Contracts.SimpleAccount), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get simple account code: %v", err)
+		}
+		code := hexutil.Bytes(accountCode)
+		overrides[op.Sender] = overrideAccount{Code: &code}
+	}
+
+	entrypoint := common.HexToAddress(chain.Contracts.Entrypoint)
+	var estimate hexutil.Uint64
+	err := client.Client().CallContext(ctx, &estimate, "eth_estimateGas", callArgs{
+		From: &entrypoint,
+		To:   &op.Sender,
+		Data: op.CallData,
+	}, "latest", overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate call gas: %w", err)
+	}
+
+	callGas := new(big.Int).SetUint64(uint64(estimate))
+	callGas.Sub(callGas, big.NewInt(pvgIntrinsicGas))
+	if callGas.Cmp(minimum) < 0 {
+		return minimum, nil
+	}
+	return callGas, nil
+}
+
+// simulateValidation returns the preOpGas of the op
+func simulateValidation(ctx context.Context, client *ethclient.Client, entrypoint common.Address, overrides stateOverride, op PackedUserOperation) (*big.Int, error) {
+	result, err := simulationCall(ctx, client, entrypoint, overrides, "simulateValidation", op)
+	if err != nil {
+		return nil, err
+	}
+	return bigField(result, "ReturnInfo", "PreOpGas")
+}
+
+// simulateHandleOp returns the preOpGas and the amount paid by the op
+func simulateHandleOp(ctx context.Context, client *ethclient.Client, entrypoint common.Address, overrides stateOverride, op PackedUserOperation) (*big.Int, *big.Int, error) {
+	result, err := simulationCall(ctx, client, entrypoint, overrides, "simulateHandleOp", op, common.Address{}, []byte{})
+	if err != nil {
+		return nil, nil, err
+	}
+	preOpGas, err := bigField(result, "PreOpGas")
+	if err != nil {
+		return nil, nil, err
+	}
+	paid, err := bigField(result, "Paid")
+	if err != nil {
+		return nil, nil, err
+	}
+	return preOpGas, paid, nil
+}
+
+func simulationCall(ctx context.Context, client *ethclient.Client, entrypoint common.Address, overrides stateOverride, method string, args ...interface{}) (interface{}, error) {
+	parsedJSON, err := abi.JSON(strings.NewReader(contractAbiEntrypointSimulations))
+	if err != nil {
+		return nil, err
+	}
+	data, err := parsedJSON.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %v", method, err)
+	}
+
+	var result hexutil.Bytes
+	err = client.Client().CallContext(ctx, &result, "eth_call", callArgs{
+		To:   &entrypoint,
+		Data: data,
+	}, "latest", overrides)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", method, err)
+	}
+
+	unpacked, err := parsedJSON.Unpack(method, result)
+	if err != nil || len(unpacked) == 0 {
+		return nil, fmt.Errorf("failed to unpack %s result: %v", method, err)
+	}
+	return unpacked[0], nil
+}
+
+// bigField walks the nested anonymous structs returned by the abi decoder
+func bigField(value interface{}, path ...string) (*big.Int, error) {
+	v := reflect.ValueOf(value)
+	for _, name := range path {
+		if v.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("simulation result has no field %s", name)
+		}
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			return nil, fmt.Errorf("simulation result has no field %s", name)
+		}
+	}
+	result, ok := v.Interface().(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("simulation field %s is not a number", strings.Join(path, "."))
+	}
+	return result, nil
+}
+
+// setPaymasterGasLimits rewrites the two uint128 limits after the paymaster address
+func setPaymasterGasLimits(op *PackedUserOperation, verificationGasLimit, postOpGasLimit *big.Int) {
+	paymasterAndData := make([]byte, len(op.PaymasterAndData))
+	copy(paymasterAndData, op.PaymasterAndData)
+	verificationGasLimit.FillBytes(paymasterAndData[20:36])
+	postOpGasLimit.FillBytes(paymasterAndData[36:52])
+	op.PaymasterAndData = paymasterAndData
+}
+
+func copyUserOperation(op PackedUserOperation) PackedUserOperation {
+	op.Nonce = new(big.Int).Set(op.Nonce)
+	op.PreVerificationGas = new(big.Int).Set(op.PreVerificationGas)
+	op.PaymasterAndData = append([]byte{}, op.PaymasterAndData...)
+	return op
+}
+
+func applyMargin(value *big.Int, margin float64) *big.Int {
+	result, _ := new(big.Float).Mul(new(big.Float).SetInt(value), big.NewFloat(margin)).Int(nil)
+	return result
+}
@@ -0,0 +1,123 @@
+package evmHandler
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e9))
+}
+
+func TestFeeCaps(t *testing.T) {
+	tests := []struct {
+		name        string
+		history     ethereum.FeeHistory
+		priorityFee *big.Int
+		maxFee      *big.Int
+	}{
+		{
+			name: "average of the blocks with rewards",
+			history: ethereum.FeeHistory{
+				Reward:  [][]*big.Int{{gwei(1)}, {gwei(3)}, {nil}, {}},
+				BaseFee: []*big.Int{gwei(10), gwei(20), gwei(30)},
+			},
+			// 2 * next base fee + priority fee
+			priorityFee: gwei(2),
+			maxFee:      gwei(62),
+		},
+		{
+			name:        "no rewards",
+			history:     ethereum.FeeHistory{BaseFee: []*big.Int{big.NewInt(7)}},
+			priorityFee: big.NewInt(0),
+			maxFee:      big.NewInt(14),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			priorityFee, maxFee := feeCaps(&test.history, DefaultGasOptions)
+			if priorityFee.Cmp(test.priorityFee) != 0 || maxFee.Cmp(test.maxFee) != 0 {
+				t.Fatalf("caps %s %s, want %s %s", priorityFee, maxFee, test.priorityFee, test.maxFee)
+			}
+		})
+	}
+}
+
+func TestPostOpGasUsed(t *testing.T) {
+	const (
+		preOpGas       = 100_000
+		callGas        = 90_000
+		executionLimit = 120_000 + 10_000_000 // call limit + simulation postOp limit
+	)
+	// what the entrypoint charges: preOp + execution used + 10% of the unused execution limit
+	paid := func(postOp int64) *big.Int {
+		used := int64(callGas) + postOp
+		return big.NewInt(preOpGas + used + (executionLimit-used)/10)
+	}
+
+	tests := []struct {
+		name string
+		paid *big.Int
+		want int64
+	}{
+		{"postOp usage", paid(40_000), 40_000},
+		{"below the minimum", paid(2_000), int64(DefaultGasOptions.MinPostOpGasLimit)},
+		{"no postOp", paid(0), int64(DefaultGasOptions.MinPostOpGasLimit)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := postOpGasUsed(big.NewInt(preOpGas), test.paid, big.NewInt(callGas), big.NewInt(executionLimit), DefaultGasOptions)
+			if got.Int64() != test.want {
+				t.Fatalf("postOp %s, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCalculatePreVerificationGas(t *testing.T) {
+	exact := DefaultGasOptions
+	exact.PreVerificationGasMargin = 1
+	op := PackedUserOperation{
+		Sender:             common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Nonce:              big.NewInt(0),
+		PreVerificationGas: big.NewInt(0),
+	}
+	calculate := func(op PackedUserOperation, opts GasOptions) int64 {
+		gas, err := CalculatePreVerificationGas(op, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gas.Int64()
+	}
+
+	// fixed overhead, the 17 words of the abi encoded op with the dummy signature and their bytes
+	base := calculate(op, exact)
+	if base != 42_504 {
+		t.Fatalf("base %d", base)
+	}
+	withSignature := op
+	withSignature.Signature = dummySignature
+	if calculate(withSignature, exact) != base {
+		t.Fatal("an empty signature is not priced as the dummy signature")
+	}
+
+	// each appended word costs 4 plus 4 per zero byte or 16 per non zero byte, the length
+	// word of the callData turns one zero byte into 0x20 (+12)
+	zeros, nonZero := op, op
+	zeros.CallData = make([]byte, 32)
+	nonZero.CallData = bytes.Repeat([]byte{0xff}, 32)
+	if got := calculate(zeros, exact) - base; got != 4+32*4+12 {
+		t.Fatalf("32 zero bytes cost %d", got)
+	}
+	if got := calculate(nonZero, exact) - base; got != 4+32*16+12 {
+		t.Fatalf("32 non zero bytes cost %d", got)
+	}
+
+	if got := calculate(op, DefaultGasOptions); got != 46_754 {
+		t.Fatalf("with the 10%% margin %d", got)
+	}
+}
@@ -1,7 +1,6 @@
 package evmHandler
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -29,7 +28,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 				log.Printf("\nFailed to create Supabase client for panic logging: %v", err)
 			}
 
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			utils.WriteError(w, utils.ErrInternal("recovered from panic"))
 		}
 	}()
 
@@ -41,7 +40,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		supabaseKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
 		supabaseClient, err := supabase.NewClient(supabaseUrl, supabaseKey, nil)
 		if err != nil {
-			http.Error(w, "Failed to create Supabase client", http.StatusInternalServerError)
+			utils.WriteError(w, utils.ErrInternal("failed to create supabase client"))
 			return
 		}
 
@@ -68,8 +67,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 			HandleResponse(w, r, supabaseClient, response, err)
 			return
 		default:
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(utils.ErrMalformedRequest("Invalid query parameter"))
+			utils.WriteError(w, utils.ErrMalformedRequest("Invalid query parameter"))
 			return
 		}
 	}))
@@ -82,14 +80,6 @@ func HandleResponse(w http.ResponseWriter, r *http.Request, supabaseClient *supa
 		if logErr := db.LogError(supabaseClient, err, r.URL.Query().Get("query"), response); logErr != nil {
 			fmt.Printf("Failed to log error: %v\n", logErr.Error())
 		}
-
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(err)
-		return
-	}
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 	}
+	utils.WriteResponse(w, response, err)
 }
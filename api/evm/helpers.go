@@ -9,6 +9,9 @@ import (
 	"os"
 	"strings"
 
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/eip712"
+	"github.com/crosscall-labs/crosschain-api/pkg/rpcpool"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -17,6 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -99,7 +103,7 @@ func GetEscrowAssetInfo(
 func TestRequest(r *http.Request, parameters ...*UnsignedEscrowRequestParams) (interface{}, error) {
 	salt := common.Hex2Bytes("0x0000000000000000000000000000000000000000000000000000000000000037")
 	signer := common.HexToAddress("19E7E376E7C213B7E7e7e46cc70A5dD086DAff2A") // should be from params
-	client, err := ethclient.Dial("https://rpc2.sepolia.org")                 // should be from inputs but ignored
+	client, err := rpcpool.EvmClient("11155111")                              // should be from inputs but ignored
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +207,11 @@ func TestRequest(r *http.Request, parameters ...*UnsignedEscrowRequestParams) (i
 	chainId := big.NewInt(11155111)
 
 	lockHash := EncodeAndHash(extendTime, assetAddress, extendNonce, chainId)
+	chain, _ := chains.Get(chainId.String())
+	lockTypedData, lockTypedDataHash, err := extendLockTypedData(chain, common.BytesToAddress(escrowAddressBytes), extendTime, assetAddress, extendNonce, chainId)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
 
 	// current nonce
 	//
@@ -237,11 +246,13 @@ func TestRequest(r *http.Request, parameters ...*UnsignedEscrowRequestParams) (i
 	}
 
 	messageEscrowEvm.TimeLockHash = EscrowTimeLockHashRaw{
-		ExtendTime:   extendTime.String(),
-		AssetAddress: assetAddress.Hex(),
-		ExtendNonce:  extendNonce.String(),
-		ChainId:      chainId.String(),
-		Hash:         hex.EncodeToString(lockHash),
+		ExtendTime:    extendTime.String(),
+		AssetAddress:  assetAddress.Hex(),
+		ExtendNonce:   extendNonce.String(),
+		ChainId:       chainId.String(),
+		Hash:          hex.EncodeToString(lockHash),
+		TypedData:     lockTypedData,
+		TypedDataHash: lockTypedDataHash,
 	}
 
 	return messageEscrowEvm, nil
@@ -295,7 +306,7 @@ func ViewFunction(client *ethclient.Client, contractAddress common.Address, pars
 	}
 
 	callMsg := ethereum.CallMsg{To: &contractAddress, Data: data}
-	result, err := client.CallContract(context.Background(), callMsg, big.NewInt(305965178))
+	result, err := client.CallContract(context.Background(), callMsg, nil) // nil block number for the latest state
 	if err != nil {
 		return nil, err
 	}
@@ -463,6 +474,20 @@ func EncodeAndHash(extendTime *big.Int, assetAddress common.Address, extendNonce
 	return ToEthSignedMessageHash(crypto.Keccak256(bytes_))
 }
 
+// extendLockTypedData is the eip-712 form of an extendLock bound to the escrow, nil unless the escrow
+// of chain checks the eip-712 digest, other escrows recover the personal_sign digest of EncodeAndHash
+func extendLockTypedData(chain *chains.Chain, escrow common.Address, extendTime *big.Int, assetAddress common.Address, extendNonce *big.Int, chainID *big.Int) (*apitypes.TypedData, string, error) {
+	if chain == nil || !chain.TypedData {
+		return nil, "", nil
+	}
+	typedData := eip712.ExtendLock(eip712.Domain(chainID, escrow), extendTime, assetAddress, extendNonce)
+	hash, err := eip712.Hash(typedData)
+	if err != nil {
+		return nil, "", err
+	}
+	return &typedData, hex.EncodeToString(hash), nil
+}
+
 func ExecuteFunction(client ethclient.Client, contractAddress common.Address, parsedABI abi.ABI, methodName string, value *big.Int, args ...interface{}) (receiptJSON []byte, err error) {
 	chainId, err := client.ChainID(context.Background())
 	if err != nil {
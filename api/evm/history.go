@@ -0,0 +1,294 @@
+package evmHandler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// blocks searched back for account and escrow logs, public rpcs cap eth_getLogs ranges
+const historyBlockRange = 10000
+
+// maximum events returned per chain and contract, newest first
+const historyMaxEvents = 50
+
+// escrow event emitted with the new balance of an asset on every deposit, lock and withdrawal
+var newBalanceTopic = crypto.Keccak256Hash([]byte("newBalance(address,uint256)"))
+
+// HistoryRange is the block range a history call scanned, Before is the to-block of the next
+// older page and zero once the scan reached genesis
+type HistoryRange struct {
+	FromBlock uint64
+	ToBlock   uint64
+	Before    uint64
+}
+
+// HistoryEvent is an on-chain event of a user account or escrow
+type HistoryEvent struct {
+	Kind        string // userop, or the escrow method that emitted the event (deposit, lock, withdraw...)
+	Contract    common.Address
+	TxHash      common.Hash
+	BlockNumber uint64
+	Timestamp   uint64
+	UserOpHash  common.Hash // userop only
+	Success     bool
+	Asset       common.Address // escrow only
+	Amount      *big.Int       // escrow balance after the event, or the op gas cost
+}
+
+// AccountHistory returns the UserOperationEvents of the owner's SimpleAccount within historyBlockRange
+// blocks up to before, the latest block when before is zero
+func AccountHistory(ctx context.Context, client *ethclient.Client, chain *chains.Chain, owner common.Address, before uint64) ([]HistoryEvent, HistoryRange, error) {
+	if chain.Contracts.Entrypoint == "" || chain.Contracts.SimpleAccountFactory == "" {
+		return nil, HistoryRange{}, fmt.Errorf("entrypoint not configured for chain id: %s", chain.ID)
+	}
+	entrypoint := common.HexToAddress(chain.Contracts.Entrypoint)
+
+	account, err := GetAccountAddress(client, common.HexToAddress(chain.Contracts.SimpleAccountFactory), owner, big.NewInt(0))
+	if err != nil {
+		return nil, HistoryRange{}, err
+	}
+	code, err := client.CodeAt(ctx, account, nil)
+	if err != nil {
+		return nil, HistoryRange{}, err
+	}
+	if len(code) == 0 {
+		return nil, HistoryRange{}, nil
+	}
+
+	entrypointJSON, err := abi.JSON(strings.NewReader(contractAbiEntrypoint))
+	if err != nil {
+		return nil, HistoryRange{}, err
+	}
+	scanned, err := historyRange(ctx, client, before)
+	if err != nil {
+		return nil, HistoryRange{}, err
+	}
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(scanned.FromBlock),
+		ToBlock:   new(big.Int).SetUint64(scanned.ToBlock),
+		Addresses: []common.Address{entrypoint},
+		Topics:    [][]common.Hash{{entrypointJSON.Events["UserOperationEvent"].ID}, nil, {common.BytesToHash(account.Bytes())}},
+	})
+	if err != nil {
+		return nil, HistoryRange{}, fmt.Errorf("failed to get UserOperationEvent logs: %v", err)
+	}
+
+	event := entrypointJSON.Events["UserOperationEvent"]
+	var events []HistoryEvent
+	newest, scanned := newestLogs(logs, scanned)
+	for _, log := range newest {
+		values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil || len(values) != 4 {
+			continue
+		}
+		events = append(events, HistoryEvent{
+			Kind:        "userop",
+			Contract:    account,
+			TxHash:      log.TxHash,
+			BlockNumber: log.BlockNumber,
+			UserOpHash:  log.Topics[1],
+			Success:     values[1].(bool),
+			Amount:      values[2].(*big.Int),
+		})
+	}
+	fillTimestamps(ctx, client, events)
+	return events, scanned, nil
+}
+
+// EscrowHistory returns the balance changes of the owner's escrow within historyBlockRange blocks up to
+// before, the kind is the escrow method of the transaction that emitted them
+func EscrowHistory(ctx context.Context, client *ethclient.Client, chain *chains.Chain, owner common.Address, salt []byte, before uint64) ([]HistoryEvent, HistoryRange, error) {
+	if chain.Contracts.EscrowFactory == "" || chain.Contracts.Escrow == "" {
+		return nil, HistoryRange{}, fmt.Errorf("escrow not configured for chain id: %s", chain.ID)
+	}
+	escrowAddressBytes, _, err := GetEscrowAddress(
+		client,
+		owner,
+		common.HexToAddress(chain.Contracts.EscrowFactory),
+		common.HexToAddress(chain.Contracts.Escrow),
+		salt)
+	if err != nil {
+		return nil, HistoryRange{}, err
+	}
+	escrowAddress := common.BytesToAddress(escrowAddressBytes)
+
+	scanned, err := historyRange(ctx, client, before)
+	if err != nil {
+		return nil, HistoryRange{}, err
+	}
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(scanned.FromBlock),
+		ToBlock:   new(big.Int).SetUint64(scanned.ToBlock),
+		Addresses: []common.Address{escrowAddress},
+		Topics:    [][]common.Hash{{newBalanceTopic}},
+	})
+	if err != nil {
+		return nil, HistoryRange{}, fmt.Errorf("failed to get escrow logs: %v", err)
+	}
+
+	escrowJSON, err := abi.JSON(strings.NewReader(contractAbiEscrow))
+	if err != nil {
+		return nil, HistoryRange{}, err
+	}
+	newest, scanned := newestLogs(logs, scanned)
+	inputs := transactionInputs(ctx, client, newest)
+	var events []HistoryEvent
+	for _, log := range newest {
+		if len(log.Data) != 64 {
+			continue
+		}
+		kind := "escrow"
+		if input := inputs[log.TxHash]; len(input) >= 4 {
+			if method, err := escrowJSON.MethodById(input[:4]); err == nil {
+				kind = method.Name
+			}
+		}
+		events = append(events, HistoryEvent{
+			Kind:        kind,
+			Contract:    escrowAddress,
+			TxHash:      log.TxHash,
+			BlockNumber: log.BlockNumber,
+			Success:     true,
+			Asset:       common.BytesToAddress(log.Data[12:32]),
+			Amount:      new(big.Int).SetBytes(log.Data[32:64]),
+		})
+	}
+	fillTimestamps(ctx, client, events)
+	return events, scanned, nil
+}
+
+func historyRange(ctx context.Context, client *ethclient.Client, before uint64) (HistoryRange, error) {
+	latest, err := client.BlockNumber(ctx)
+	if err != nil {
+		return HistoryRange{}, err
+	}
+	to := latest
+	if before != 0 && before < latest {
+		to = before
+	}
+	scanned := HistoryRange{ToBlock: to}
+	if to > historyBlockRange {
+		scanned.FromBlock = to - historyBlockRange
+		scanned.Before = scanned.FromBlock - 1
+	}
+	return scanned, nil
+}
+
+// newestLogs orders logs newest first and keeps at most historyMaxEvents. A page cut by the limit
+// ends before the block it was cut in, the next page starts at that block so none of its logs
+// are lost or repeated.
+func newestLogs(logs []types.Log, scanned HistoryRange) ([]types.Log, HistoryRange) {
+	newest := make([]types.Log, 0, len(logs))
+	for i := len(logs) - 1; i >= 0; i-- {
+		if logs[i].Removed {
+			continue
+		}
+		if len(newest) == historyMaxEvents {
+			cut := logs[i].BlockNumber
+			kept := newest[:0]
+			for _, log := range newest {
+				if log.BlockNumber != cut {
+					kept = append(kept, log)
+				}
+			}
+			// a single block holding more logs than a page is returned whole
+			if len(kept) > 0 {
+				newest = kept
+				scanned.FromBlock = cut + 1
+				scanned.Before = cut
+			} else {
+				for ; i >= 0 && logs[i].BlockNumber == cut; i-- {
+					if !logs[i].Removed {
+						newest = append(newest, logs[i])
+					}
+				}
+				scanned.FromBlock = cut
+				scanned.Before = 0
+				if cut > 0 {
+					scanned.Before = cut - 1
+				}
+			}
+			break
+		}
+		newest = append(newest, logs[i])
+	}
+	return newest, scanned
+}
+
+// transactionInputs reads the calldata of the transactions of logs in one batch, transactions
+// that could not be read are missing from the map
+func transactionInputs(ctx context.Context, client *ethclient.Client, logs []types.Log) map[common.Hash][]byte {
+	type transaction struct {
+		Input hexutil.Bytes `json:"input"`
+	}
+	var hashes []common.Hash
+	seen := make(map[common.Hash]bool)
+	for _, log := range logs {
+		if !seen[log.TxHash] {
+			seen[log.TxHash] = true
+			hashes = append(hashes, log.TxHash)
+		}
+	}
+	results := make([]transaction, len(hashes))
+	batch := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		batch[i] = rpc.BatchElem{Method: "eth_getTransactionByHash", Args: []interface{}{hash}, Result: &results[i]}
+	}
+
+	inputs := make(map[common.Hash][]byte)
+	if len(batch) == 0 || client.Client().BatchCallContext(ctx, batch) != nil {
+		return inputs
+	}
+	for i, elem := range batch {
+		if elem.Error == nil {
+			inputs[hashes[i]] = results[i].Input
+		}
+	}
+	return inputs
+}
+
+// fillTimestamps sets the block time of every event from one batch of header requests, events
+// of blocks that could not be read keep a zero timestamp
+func fillTimestamps(ctx context.Context, client *ethclient.Client, events []HistoryEvent) {
+	type header struct {
+		Time hexutil.Uint64 `json:"timestamp"`
+	}
+	var blocks []uint64
+	seen := make(map[uint64]bool)
+	for _, event := range events {
+		if !seen[event.BlockNumber] {
+			seen[event.BlockNumber] = true
+			blocks = append(blocks, event.BlockNumber)
+		}
+	}
+	results := make([]*header, len(blocks))
+	batch := make([]rpc.BatchElem, len(blocks))
+	for i, block := range blocks {
+		batch[i] = rpc.BatchElem{Method: "eth_getBlockByNumber", Args: []interface{}{hexutil.EncodeUint64(block), false}, Result: &results[i]}
+	}
+	if len(batch) == 0 || client.Client().BatchCallContext(ctx, batch) != nil {
+		return
+	}
+
+	times := make(map[uint64]uint64)
+	for i, elem := range batch {
+		if elem.Error == nil && results[i] != nil {
+			times[blocks[i]] = uint64(results[i].Time)
+		}
+	}
+	for i := range events {
+		events[i].Timestamp = times[events[i].BlockNumber]
+	}
+}
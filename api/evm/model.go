@@ -6,6 +6,7 @@ import (
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 type UnsignedEntryPointRequestResponse struct {
@@ -111,11 +112,13 @@ type EscrowInit struct {
 }
 
 type EscrowTimeLockHashRaw struct {
-	ExtendTime   string `json:"extend-time"`
-	AssetAddress string `json:"asset-address"`
-	ExtendNonce  string `json:"extend-nonce"`
-	ChainId      string `json:"chain-id"`
-	Hash         string `json:"hash"`
+	ExtendTime    string              `json:"extend-time"`
+	AssetAddress  string              `json:"asset-address"`
+	ExtendNonce   string              `json:"extend-nonce"`
+	ChainId       string              `json:"chain-id"`
+	Hash          string              `json:"hash"`
+	TypedData     *apitypes.TypedData `json:"typed-data,omitempty"` // eip-712 form of the lock, once the escrow verifies it
+	TypedDataHash string              `json:"typed-data-hash,omitempty"`
 }
 
 type EscrowTimeLockHash struct {
@@ -1,6 +1,11 @@
 package evmHandler
 
-import "github.com/crosscall-labs/crosschain-api/pkg/utils"
+import (
+	"math/big"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type UnsignedEscrowRequestParams struct {
 	Header utils.PartialHeader `query:"header"`
@@ -9,5 +14,10 @@ type UnsignedEscrowRequestParams struct {
 
 type UnsignedEntryPointRequestParams struct {
 	Header  utils.MessageHeader `query:"header"`
-	Payload string              `query:"payload" optional:"true"`
+	Target  string              `query:"target" optional:"true"`                              // comma separated for executeBatch
+	Value   string              `query:"value" optional:"true"`                               // wei, comma separated
+	Payload string              `query:"payload" optional:"true"`                             // hex calldata, comma separated
+	Salt    *big.Int            `query:"salt" optional:"true" validate:"uint=256"`            // SimpleAccount salt, default 0
+	Asset   *common.Address     `query:"asset" optional:"true"`                               // escrow asset paying the paymaster
+	Amount  *big.Int            `query:"amount" optional:"true" validate:"positive,uint=256"` // escrow amount paying the paymaster
 }
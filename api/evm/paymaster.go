@@ -0,0 +1,130 @@
+package evmHandler
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// paymasterAndData layout:
+// paymaster (20) | verification gas uint128 (16) | postOp gas uint128 (16) |
+// signer (20) | domain uint32 (4) | message type (1) | asset (20) | amount uint256 (32)
+const paymasterAndDataLength = 20 + 16 + 16 + 20 + 4 + 1 + 20 + 32
+
+// EncodePaymasterAndData packs the crosschain paymaster fields in the layout the paymaster reads,
+// the amount must fit the uint256 slot
+func EncodePaymasterAndData(pad PaymasterAndData) ([]byte, error) {
+	if pad.AssetAmount != nil && !IsUint256(pad.AssetAmount) {
+		return nil, fmt.Errorf("asset amount %s is not a uint256", pad.AssetAmount)
+	}
+
+	data := make([]byte, 0, paymasterAndDataLength)
+	data = append(data, pad.Paymaster.Bytes()...)
+	data = append(data, pad.PaymasterVerificationGasLimit[16:]...)
+	data = append(data, pad.PaymasterPostOpGasLimit[16:]...)
+	data = append(data, pad.Signer.Bytes()...)
+	data = append(data, pad.DestinationDomain[:]...)
+	data = append(data, pad.MessageType)
+	data = append(data, pad.AssetAddress.Bytes()...)
+
+	var amount [32]byte
+	if pad.AssetAmount != nil {
+		pad.AssetAmount.FillBytes(amount[:])
+	}
+	return append(data, amount[:]...), nil
+}
+
+// DecodePaymasterAndData is the inverse of EncodePaymasterAndData
+func DecodePaymasterAndData(data []byte) (PaymasterAndData, error) {
+	if len(data) != paymasterAndDataLength {
+		return PaymasterAndData{}, fmt.Errorf("invalid paymasterAndData length: %d", len(data))
+	}
+
+	pad := PaymasterAndData{}
+	pad.Paymaster = common.BytesToAddress(data[0:20])
+	copy(pad.PaymasterVerificationGasLimit[16:], data[20:36])
+	copy(pad.PaymasterPostOpGasLimit[16:], data[36:52])
+	pad.Signer = common.BytesToAddress(data[52:72])
+	copy(pad.DestinationDomain[:], data[72:76])
+	pad.MessageType = data[76]
+	pad.AssetAddress = common.BytesToAddress(data[77:97])
+	pad.AssetAmount = new(big.Int).SetBytes(data[97:129])
+	return pad, nil
+}
+
+// IsUint256 reports whether value fits a uint256, FillBytes panics on anything larger
+func IsUint256(value *big.Int) bool {
+	return value.Sign() >= 0 && value.BitLen() <= 256
+}
+
+// DomainBytes converts a hyperlane domain to the big endian form stored in PaymasterAndData
+func DomainBytes(domain uint32) [4]byte {
+	return [4]byte{byte(domain >> 24), byte(domain >> 16), byte(domain >> 8), byte(domain)}
+}
+
+func parseBytes32(name, value string) ([32]byte, error) {
+	var result [32]byte
+	if value == "" || value == "0x" {
+		return result, nil
+	}
+	decoded, err := hexutil.Decode(ensureHexPrefix(value))
+	if err != nil {
+		return result, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	if len(decoded) > 32 {
+		return result, fmt.Errorf("invalid %s: longer than 32 bytes", name)
+	}
+	copy(result[32-len(decoded):], decoded)
+	return result, nil
+}
+
+func parseAddress(name, value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("invalid %s: %s", name, value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+func parsePaymasterAndDataResponse(pad PaymasterAndDataResponse) (PaymasterAndData, error) {
+	var result PaymasterAndData
+	var err error
+
+	if result.Paymaster, err = parseAddress("paymaster", pad.Paymaster); err != nil {
+		return PaymasterAndData{}, err
+	}
+	if result.PaymasterVerificationGasLimit, err = parseBytes32("paymaster verification gas limit", pad.PaymasterVerificationGasLimit); err != nil {
+		return PaymasterAndData{}, err
+	}
+	if result.PaymasterPostOpGasLimit, err = parseBytes32("paymaster postOp gas limit", pad.PaymasterPostOpGasLimit); err != nil {
+		return PaymasterAndData{}, err
+	}
+	if result.Signer, err = parseAddress("signer", pad.Signer); err != nil {
+		return PaymasterAndData{}, err
+	}
+	if result.AssetAddress, err = parseAddress("asset address", pad.AssetAddress); err != nil {
+		return PaymasterAndData{}, err
+	}
+
+	domain, err := strconv.ParseUint(pad.DestinationDomain, 10, 32)
+	if err != nil {
+		return PaymasterAndData{}, fmt.Errorf("invalid destination domain: %s", pad.DestinationDomain)
+	}
+	result.DestinationDomain = DomainBytes(uint32(domain))
+
+	messageType, err := strconv.ParseUint(pad.MessageType, 10, 8)
+	if err != nil {
+		return PaymasterAndData{}, fmt.Errorf("invalid message type: %s", pad.MessageType)
+	}
+	result.MessageType = byte(messageType)
+
+	amount, ok := new(big.Int).SetString(pad.AssetAmount, 10)
+	if !ok || !IsUint256(amount) {
+		return PaymasterAndData{}, fmt.Errorf("invalid asset amount: %s", pad.AssetAmount)
+	}
+	result.AssetAmount = amount
+
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package evmHandler
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPaymasterAndDataAmount(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	pad := PaymasterAndData{
+		Paymaster:                     common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		PaymasterVerificationGasLimit: PackGasPair(big.NewInt(0), big.NewInt(60_000)),
+		PaymasterPostOpGasLimit:       PackGasPair(big.NewInt(0), big.NewInt(40_000)),
+		Signer:                        common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		DestinationDomain:             DomainBytes(11155111),
+		MessageType:                   1,
+		AssetAddress:                  common.HexToAddress("0x3333333333333333333333333333333333333333"),
+	}
+
+	tests := []struct {
+		name   string
+		amount *big.Int
+		valid  bool
+	}{
+		{"nil", nil, true},
+		{"one", big.NewInt(1), true},
+		{"max uint256", maxUint256, true},
+		{"2^256", new(big.Int).Add(maxUint256, big.NewInt(1)), false},
+		{"negative", big.NewInt(-1), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pad := pad
+			pad.AssetAmount = test.amount
+			data, err := EncodePaymasterAndData(pad)
+			if !test.valid {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(data) != paymasterAndDataLength {
+				t.Fatalf("length %d", len(data))
+			}
+
+			decoded, err := DecodePaymasterAndData(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := test.amount
+			if want == nil {
+				want = big.NewInt(0)
+			}
+			if decoded.AssetAmount.Cmp(want) != 0 || decoded.Signer != pad.Signer || decoded.PaymasterPostOpGasLimit != pad.PaymasterPostOpGasLimit {
+				t.Fatalf("decoded %+v", decoded)
+			}
+			if encoded, _ := EncodePaymasterAndData(decoded); !bytes.Equal(encoded, data) {
+				t.Fatal("re-encoded paymasterAndData differs")
+			}
+		})
+	}
+
+	response, err := ToPaymasterAndDataResponse(PaymasterAndData{AssetAmount: new(big.Int).Lsh(big.NewInt(1), 256)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FromPaymasterAndDataResponse(response); err == nil {
+		t.Fatal("parsed a 2^256 asset amount")
+	}
+}
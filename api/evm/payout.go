@@ -0,0 +1,308 @@
+package evmHandler
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// gas paid to the IGP for the escrow handle call on the origin chain
+const payoutHandleGas = 200000
+
+// hyperlane mailbox event carrying the id of every dispatched message
+var dispatchIdTopic = crypto.Keccak256Hash([]byte("DispatchId(bytes32)"))
+
+// PayoutRequest is the decoded paymasterAndDataType1 payload, a 32 byte word per field:
+// paymaster | verification gas uint128 ++ postOp gas uint128 | message type | signer | asset | origin chain id | amount
+type PayoutRequest struct {
+	Paymaster                     common.Address
+	PaymasterVerificationGasLimit *big.Int
+	PaymasterPostOpGasLimit       *big.Int
+	MessageType                   uint8
+	Signer                        common.Address
+	AssetAddress                  common.Address
+	OriginChainId                 *big.Int
+	AssetAmount                   *big.Int
+}
+
+// PayoutMessage is the hyperlane message body delivered to the signer's escrow
+type PayoutMessage struct {
+	Request    PayoutRequest
+	UserOpHash common.Hash
+	Recipient  common.Address // relayer that fronted the destination execution
+	Body       []byte
+	Hash       common.Hash // signed by the relayer
+	Signature  []byte
+}
+
+// PayoutDispatch is a payout sent through the mailbox, the message id is zero until the
+// dispatch is mined
+type PayoutDispatch struct {
+	DispatchTx common.Hash
+	MessageId  common.Hash
+}
+
+// ParsePaymasterAndDataType1 decodes the abi encoded type 1 payload, trailing words are ignored
+func ParsePaymasterAndDataType1(data []byte) (PayoutRequest, error) {
+	if len(data) < 7*32 {
+		return PayoutRequest{}, fmt.Errorf("invalid paymasterAndDataType1 length: %d", len(data))
+	}
+	word := func(i int) []byte { return data[i*32 : (i+1)*32] }
+
+	messageType := new(big.Int).SetBytes(word(2))
+	if !messageType.IsUint64() || messageType.Uint64() != 1 {
+		return PayoutRequest{}, fmt.Errorf("unsupported message type: %s", messageType)
+	}
+
+	return PayoutRequest{
+		Paymaster:                     common.BytesToAddress(word(0)[12:]),
+		PaymasterVerificationGasLimit: new(big.Int).SetBytes(word(1)[:16]),
+		PaymasterPostOpGasLimit:       new(big.Int).SetBytes(word(1)[16:]),
+		MessageType:                   uint8(messageType.Uint64()),
+		Signer:                        common.BytesToAddress(word(3)[12:]),
+		AssetAddress:                  common.BytesToAddress(word(4)[12:]),
+		OriginChainId:                 new(big.Int).SetBytes(word(5)),
+		AssetAmount:                   new(big.Int).SetBytes(word(6)),
+	}, nil
+}
+
+// VerifyPayoutTrace checks that traceId is a successful handleOps tx on the destination chain
+// containing an executed op sponsored for this payout, returns the op hash
+func VerifyPayoutTrace(ctx context.Context, client *ethclient.Client, chain *chains.Chain, traceId common.Hash, request PayoutRequest, originDomain uint32) (common.Hash, error) {
+	receipt, err := client.TransactionReceipt(ctx, traceId)
+	if err != nil {
+		if err == ethereum.NotFound {
+			return common.Hash{}, fmt.Errorf("trace id %s has no receipt on chain id %s", traceId.Hex(), chain.ID)
+		}
+		return common.Hash{}, err
+	}
+	if receipt.Status != 1 {
+		return common.Hash{}, fmt.Errorf("trace id %s reverted", traceId.Hex())
+	}
+
+	tx, _, err := client.TransactionByHash(ctx, traceId)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	entrypoint := common.HexToAddress(chain.Contracts.Entrypoint)
+	if tx.To() == nil || *tx.To() != entrypoint {
+		return common.Hash{}, fmt.Errorf("trace id %s is not an entrypoint transaction", traceId.Hex())
+	}
+
+	entrypointJSON, err := abi.JSON(strings.NewReader(contractAbiEntrypoint))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(tx.Data()) < 4 {
+		return common.Hash{}, fmt.Errorf("trace id %s is not a handleOps call", traceId.Hex())
+	}
+	method, err := entrypointJSON.MethodById(tx.Data()[:4])
+	if err != nil || method.Name != "handleOps" {
+		return common.Hash{}, fmt.Errorf("trace id %s is not a handleOps call", traceId.Hex())
+	}
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil || len(args) != 2 {
+		return common.Hash{}, fmt.Errorf("failed to decode handleOps: %v", err)
+	}
+	ops := *abi.ConvertType(args[0], new([]PackedUserOperation)).(*[]PackedUserOperation)
+
+	// only the configured paymaster checks the escrow of the signer, any other contract could name
+	// a victim as the signer in its paymasterAndData
+	if chain.Contracts.Paymaster == "" {
+		return common.Hash{}, fmt.Errorf("paymaster not configured for chain id: %s", chain.ID)
+	}
+	paymaster := common.HexToAddress(chain.Contracts.Paymaster)
+	if request.Paymaster != paymaster {
+		return common.Hash{}, fmt.Errorf("paymaster %s is not the paymaster of chain id %s", request.Paymaster.Hex(), chain.ID)
+	}
+
+	executed := make(map[common.Hash]bool)
+	for _, event := range UserOperationEvents(receipt, entrypoint) {
+		executed[event.UserOpHash] = event.Success
+	}
+
+	chainId, _ := new(big.Int).SetString(chain.ID, 10)
+	for _, op := range ops {
+		pad, err := DecodePaymasterAndData(op.PaymasterAndData)
+		if err != nil {
+			continue
+		}
+		if pad.Paymaster != paymaster ||
+			pad.Signer != request.Signer ||
+			pad.AssetAddress != request.AssetAddress ||
+			pad.AssetAmount.Cmp(request.AssetAmount) != 0 ||
+			pad.DestinationDomain != DomainBytes(originDomain) {
+			continue
+		}
+
+		userOpHash, err := GetUserOpHash(op, entrypoint, chainId)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if !executed[userOpHash] {
+			return common.Hash{}, fmt.Errorf("user operation %s did not execute successfully", userOpHash.Hex())
+		}
+		return userOpHash, nil
+	}
+
+	return common.Hash{}, fmt.Errorf("trace id %s has no user operation for this payout", traceId.Hex())
+}
+
+// BuildPayoutMessage encodes the escrow payout body and signs its hash with the relayer key:
+// abi.encode(messageType, signer, asset, amount, userOpHash, recipient, signature)
+func BuildPayoutMessage(request PayoutRequest, userOpHash common.Hash, recipient common.Address, privateKey *ecdsa.PrivateKey) (PayoutMessage, error) {
+	uint8Type, _ := abi.NewType("uint8", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+
+	fields := abi.Arguments{
+		{Type: uint8Type},
+		{Type: addressType},
+		{Type: addressType},
+		{Type: uint256Type},
+		{Type: bytes32Type},
+		{Type: addressType},
+	}
+
+	// the chain id binds the signature to the origin escrow
+	signedFields := append(fields, abi.Argument{Type: uint256Type})
+	unsigned, err := signedFields.Pack(request.MessageType, request.Signer, request.AssetAddress, request.AssetAmount, userOpHash, recipient, request.OriginChainId)
+	if err != nil {
+		return PayoutMessage{}, fmt.Errorf("failed to pack payout message: %v", err)
+	}
+	hash := crypto.Keccak256Hash(unsigned)
+
+	signature, err := crypto.Sign(ToEthSignedMessageHash(hash.Bytes()), privateKey)
+	if err != nil {
+		return PayoutMessage{}, err
+	}
+	signature[64] += 27
+
+	body, err := append(fields, abi.Argument{Type: bytesType}).Pack(request.MessageType, request.Signer, request.AssetAddress, request.AssetAmount, userOpHash, recipient, signature)
+	if err != nil {
+		return PayoutMessage{}, fmt.Errorf("failed to pack payout message: %v", err)
+	}
+
+	return PayoutMessage{
+		Request:    request,
+		UserOpHash: userOpHash,
+		Recipient:  recipient,
+		Body:       body,
+		Hash:       hash,
+		Signature:  signature,
+	}, nil
+}
+
+// DispatchPayout sends the payout from chain's mailbox to the escrow on the origin domain,
+// signed is called with the dispatch tx hash before it is sent. The dispatch tx is zero when
+// nothing reached the chain, the IGP is paid separately by PayPayoutGas
+func DispatchPayout(ctx context.Context, client *ethclient.Client, chain *chains.Chain, originDomain uint32, escrowAddress common.Address, message PayoutMessage, privateKey *ecdsa.PrivateKey, signed func(common.Hash) error) (PayoutDispatch, error) {
+	if chain.Contracts.HyperlaneMailbox == "" || chain.Contracts.HyperlaneIgp == "" {
+		return PayoutDispatch{}, fmt.Errorf("hyperlane not configured for chain id: %s", chain.ID)
+	}
+	mailbox := common.HexToAddress(chain.Contracts.HyperlaneMailbox)
+
+	mailboxJSON, err := abi.JSON(strings.NewReader(contractAbiHyperlaneMailbox))
+	if err != nil {
+		return PayoutDispatch{}, err
+	}
+
+	recipient := common.BytesToHash(escrowAddress.Bytes())
+	dispatchData, err := mailboxJSON.Pack("dispatch", originDomain, recipient, message.Body)
+	if err != nil {
+		return PayoutDispatch{}, fmt.Errorf("failed to pack dispatch: %v", err)
+	}
+
+	dispatchTx, receipt, err := sendTransaction(ctx, client, privateKey, mailbox, big.NewInt(0), dispatchData, signed)
+	if err != nil {
+		return PayoutDispatch{}, err
+	}
+	if receipt == nil {
+		return PayoutDispatch{DispatchTx: dispatchTx.Hash()}, fmt.Errorf("dispatch %s not mined, gas not paid", dispatchTx.Hash().Hex())
+	}
+	if receipt.Status != 1 {
+		// a reverted dispatch sent nothing
+		return PayoutDispatch{}, fmt.Errorf("dispatch %s reverted", dispatchTx.Hash().Hex())
+	}
+	messageId, err := dispatchedMessageId(receipt, mailbox)
+	if err != nil {
+		return PayoutDispatch{DispatchTx: dispatchTx.Hash()}, err
+	}
+	return PayoutDispatch{DispatchTx: dispatchTx.Hash(), MessageId: messageId}, nil
+}
+
+// PayoutDispatchMessageId reads the message id of a stored dispatch tx, mined is false while
+// the tx has no receipt. A reverted dispatch returns mined with an error
+func PayoutDispatchMessageId(ctx context.Context, client *ethclient.Client, chain *chains.Chain, dispatchTx common.Hash) (common.Hash, bool, error) {
+	receipt, err := client.TransactionReceipt(ctx, dispatchTx)
+	if err == ethereum.NotFound {
+		return common.Hash{}, false, nil
+	}
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	if receipt.Status != 1 {
+		return common.Hash{}, true, fmt.Errorf("dispatch %s reverted", dispatchTx.Hex())
+	}
+	messageId, err := dispatchedMessageId(receipt, common.HexToAddress(chain.Contracts.HyperlaneMailbox))
+	return messageId, true, err
+}
+
+// dispatchedMessageId returns the id of the DispatchId event of mailbox in receipt
+func dispatchedMessageId(receipt *types.Receipt, mailbox common.Address) (common.Hash, error) {
+	for _, log := range receipt.Logs {
+		if log.Address == mailbox && len(log.Topics) == 2 && log.Topics[0] == dispatchIdTopic {
+			return log.Topics[1], nil
+		}
+	}
+	return common.Hash{}, fmt.Errorf("dispatch %s has no DispatchId event", receipt.TxHash.Hex())
+}
+
+// PayPayoutGas pays the IGP of chain for the delivery of a dispatched payout, the gas tx is
+// only returned once it is mined. Paying again after an unmined payment overpays the IGP but
+// never delivers the message twice
+func PayPayoutGas(ctx context.Context, client *ethclient.Client, chain *chains.Chain, originDomain uint32, messageId common.Hash, privateKey *ecdsa.PrivateKey) (common.Hash, error) {
+	if chain.Contracts.HyperlaneIgp == "" {
+		return common.Hash{}, fmt.Errorf("hyperlane not configured for chain id: %s", chain.ID)
+	}
+	igp := common.HexToAddress(chain.Contracts.HyperlaneIgp)
+	relayAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	igpJSON, err := abi.JSON(strings.NewReader(contractAbiHyperlaneIgp))
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	quote, err := ViewFunction(client, igp, igpJSON, "quoteGasPayment", originDomain, big.NewInt(payoutHandleGas))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed quoteGasPayment request: %v", err)
+	}
+
+	gasData, err := igpJSON.Pack("payForGas", messageId, originDomain, big.NewInt(payoutHandleGas), relayAddress)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack payForGas: %v", err)
+	}
+	gasTx, receipt, err := SendTransaction(ctx, client, privateKey, igp, new(big.Int).SetBytes(quote), gasData)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if receipt == nil {
+		return common.Hash{}, fmt.Errorf("gas payment %s not mined", gasTx.Hash().Hex())
+	}
+	if receipt.Status != 1 {
+		return common.Hash{}, fmt.Errorf("gas payment %s reverted", gasTx.Hash().Hex())
+	}
+	return gasTx.Hash(), nil
+}
@@ -0,0 +1,29 @@
+package evmHandler
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestDispatchedMessageId(t *testing.T) {
+	mailbox := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	messageId := common.HexToHash("0x01")
+	otherTopic := common.HexToHash("0x02")
+
+	receipt := &types.Receipt{Logs: []*types.Log{
+		{Address: mailbox, Topics: []common.Hash{otherTopic, common.HexToHash("0x03")}},
+		{Address: common.HexToAddress("0xbb"), Topics: []common.Hash{dispatchIdTopic, common.HexToHash("0x04")}},
+		{Address: mailbox, Topics: []common.Hash{dispatchIdTopic, messageId}},
+	}}
+	if got, err := dispatchedMessageId(receipt, mailbox); err != nil || got != messageId {
+		t.Fatalf("message id %s, err %v", got.Hex(), err)
+	}
+
+	// the event of another contract is not the dispatch of the mailbox
+	receipt.Logs = receipt.Logs[:2]
+	if _, err := dispatchedMessageId(receipt, mailbox); err == nil {
+		t.Fatal("expected an error without a DispatchId event")
+	}
+}
@@ -1,6 +1,7 @@
 package evmHandler
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -8,10 +9,13 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/rpcpool"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 func AssetMintRequest(r *http.Request, parameters ...*utils.AssetMintRequestParams) (interface{}, error) {
@@ -48,8 +52,7 @@ func AssetMintRequest(r *http.Request, parameters ...*utils.AssetMintRequestPara
 		return nil, fmt.Errorf("ailed to parse the string into *big.Int")
 	}
 
-	jsonrpc, _ := getChainRpc(params.ChainId)
-	client, err := ethclient.Dial(jsonrpc)
+	client, err := rpcpool.EvmClient(params.ChainId)
 	if err != nil {
 		fmt.Printf("\nclient connection failed: %v\n", err)
 		return nil, fmt.Errorf("client connection failed: %v", err)
@@ -105,10 +108,9 @@ func AssetInfoRequest(r *http.Request, parameters ...*utils.AssetInfoRequestPara
 
 	userAddress := common.HexToAddress(params.UserAddress)
 
-	jsonrpc, _ := getChainRpc(params.ChainId)
-	client, err := ethclient.Dial(jsonrpc)
+	client, err := rpcpool.EvmClient(params.ChainId)
 	if err != nil {
-		return nil, utils.ErrInternal(fmt.Errorf("client connection failed: %v", err).Error())
+		return nil, utils.Err(apierr.RpcFailure, fmt.Sprintf("client connection failed: %v", err))
 	}
 
 	var multicallAddress common.Address
@@ -330,11 +332,16 @@ func UnsignedEscrowRequest(r *http.Request, parameters ...*UnsignedEscrowRequest
 		return nil, utils.ErrMalformedRequest(errorStr)
 	}
 
+	chain, err := chains.Get(params.Header.ChainId)
+	if err != nil {
+		return nil, utils.Err(apierr.UnsupportedChain, err.Error())
+	}
+
 	salt := common.Hex2Bytes("0x0000000000000000000000000000000000000000000000000000000000000037")
 	signer := common.HexToAddress("19E7E376E7C213B7E7e7e46cc70A5dD086DAff2A") // should be from params
-	client, err := ethclient.Dial("https://rpc2.sepolia.org")                 // should be from inputs but ignored
+	client, err := rpcpool.EvmClient(chain.ID)
 	if err != nil {
-		return nil, utils.ErrInternal(err.Error())
+		return nil, utils.Err(apierr.RpcFailure, err.Error())
 	}
 
 	a := common.HexToAddress("f39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
@@ -354,9 +361,8 @@ func UnsignedEscrowRequest(r *http.Request, parameters ...*UnsignedEscrowRequest
 
 	assetAddress := common.HexToAddress("0000000000000000000000000000000000000000")
 	value := common.Big0
-	escrowSingletonAddress := common.HexToAddress("ea8D264dF67c9476cA80A24067c2F3CF7726aC4d")
-	escrowFactoryAddress := common.HexToAddress("d9842E241B7015ea1E1B5A90Ae20b6453ADF2723")
-	//multicallAddress := common.HexToAddress("6958206f218D8f889ECBb76B89eE9bF1CAe37715")
+	escrowSingletonAddress := common.HexToAddress(chain.Contracts.Escrow)
+	escrowFactoryAddress := common.HexToAddress(chain.Contracts.EscrowFactory)
 
 	escrowAddressBytes, initalizerBytes, err := GetEscrowAddress(client, signer, escrowFactoryAddress, escrowSingletonAddress, salt)
 	if err != nil {
@@ -433,9 +439,13 @@ func UnsignedEscrowRequest(r *http.Request, parameters ...*UnsignedEscrowRequest
 	}
 
 	extendTime := big.NewInt(3600)
-	chainId := big.NewInt(11155111)
+	chainId, _ := new(big.Int).SetString(chain.ID, 10)
 
 	lockHash := EncodeAndHash(extendTime, assetAddress, extendNonce, chainId)
+	lockTypedData, lockTypedDataHash, err := extendLockTypedData(chain, common.BytesToAddress(escrowAddressBytes), extendTime, assetAddress, extendNonce, chainId)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
 
 	messageEscrowEvm.Init = EscrowInitRaw{
 		SingletonAddress: escrowSingletonAddress.Hex(),
@@ -458,11 +468,13 @@ func UnsignedEscrowRequest(r *http.Request, parameters ...*UnsignedEscrowRequest
 	}
 
 	messageEscrowEvm.TimeLockHash = EscrowTimeLockHashRaw{
-		ExtendTime:   extendTime.String(),
-		AssetAddress: assetAddress.Hex(),
-		ExtendNonce:  extendNonce.String(),
-		ChainId:      chainId.String(),
-		Hash:         hex.EncodeToString(lockHash),
+		ExtendTime:    extendTime.String(),
+		AssetAddress:  assetAddress.Hex(),
+		ExtendNonce:   extendNonce.String(),
+		ChainId:       chainId.String(),
+		Hash:          hex.EncodeToString(lockHash),
+		TypedData:     lockTypedData,
+		TypedDataHash: lockTypedDataHash,
 	}
 
 	return messageEscrowEvm, nil
@@ -493,40 +505,99 @@ func UnsignedEntryPointRequest(r *http.Request, parameters ...*UnsignedEntryPoin
 		return nil, utils.ErrMalformedRequest(errorStr)
 	}
 
-	// payload, err := utils.Str2Bytes(params.Payload)
-	// if err != nil {
-	// 	utils.ErrMalformedRequest(w, err.Error())
-	// }
-	// fmt.Print(payload)
+	if !common.IsHexAddress(params.Header.ToChainSigner) {
+		return nil, utils.ErrMalformedRequest("invalid destination signer address")
+	}
+	owner := common.HexToAddress(params.Header.ToChainSigner)
 
-	// return data; priceGwei is redundant but left up to the user if the user wants to input a different escrow payout in the paymaster and data
-	// type MessageOpEvm struct {
-	// 	UserOp           PackedUserOperationResponse `json:"op-packed-data"` // parsed data, recommended to validate data
-	// 	PaymasterAndData PaymasterAndData            `json:"op-paymaster"`
-	// 	UserOpHash       string                      `json:"op-hash"`
-	// 	PriceGwei        string                      `json:"op-price"`
-	// }
+	salt := big.NewInt(0)
+	if params.Salt != nil {
+		salt = params.Salt
+	}
 
-	// need to create the userop, but to make it real we need to use proper tools to value the gas estimate and over estimate
-	//		for now use fixed values from forge tests
+	// without a target the account calls nothing, which still deploys it through the initCode
+	var calls []UserOpCall
+	if params.Target != "" {
+		var err error
+		calls, err = ParseUserOpCalls(params.Target, params.Value, params.Payload)
+		if err != nil {
+			return nil, utils.ErrMalformedRequest(err.Error())
+		}
+	}
+
+	chain, err := chains.Get(params.Header.ToChainId)
+	if err != nil {
+		return nil, utils.Err(apierr.UnsupportedChain, err.Error())
+	}
+	client, err := rpcpool.EvmClient(chain.ID)
+	if err != nil {
+		return nil, utils.Err(apierr.RpcFailure, err.Error())
+	}
+
+	packedUserOperation, err := BuildPackedUserOperation(client, chain, owner, salt, calls)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+
+	// with an escrow asset the op is sponsored by the paymaster and paid from the origin escrow
+	paymasterAndData := PaymasterAndData{AssetAmount: big.NewInt(0)}
+	if params.Asset != nil {
+		if chain.Contracts.Paymaster == "" {
+			return nil, utils.ErrMalformedRequest(fmt.Sprintf("paymaster not configured for chain id: %s", chain.ID))
+		}
+		originChain, err := chains.Get(params.Header.FromChainId)
+		if err != nil {
+			return nil, utils.ErrMalformedRequest(err.Error())
+		}
+		if !common.IsHexAddress(params.Header.FromChainSigner) {
+			return nil, utils.ErrMalformedRequest("invalid origin signer address")
+		}
+		if params.Amount == nil {
+			return nil, utils.ErrMalformedRequest("amount is required with asset")
+		}
+
+		// the paymaster verification and postOp limits are set by EstimateUserOperationGas
+		paymasterAndData = PaymasterAndData{
+			Paymaster:         common.HexToAddress(chain.Contracts.Paymaster),
+			Signer:            common.HexToAddress(params.Header.FromChainSigner),
+			DestinationDomain: DomainBytes(originChain.Domain),
+			MessageType:       1,
+			AssetAddress:      *params.Asset,
+			AssetAmount:       params.Amount,
+		}
+		packedUserOperation.PaymasterAndData, err = EncodePaymasterAndData(paymasterAndData)
+		if err != nil {
+			return nil, utils.ErrMalformedRequest(err.Error())
+		}
+	}
+	if err := EstimateUserOperationGas(context.Background(), client, chain, &packedUserOperation, DefaultGasOptions); err != nil {
+		return nil, SimulationError(fmt.Errorf("gas estimation failed: %w", err))
+	}
+
+	chainId, ok := new(big.Int).SetString(chain.ID, 10)
+	if !ok {
+		return nil, utils.ErrInternal(fmt.Sprintf("chain id is not numeric: %s", chain.ID))
+	}
+	userOpHash, err := GetUserOpHash(packedUserOperation, common.HexToAddress(chain.Contracts.Entrypoint), chainId)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
 
 	// todo
-	//	create default values for calldata (this should be done by the protocol api since we don't want to delegate using a specifc wallet architecture)
-	//		test data will be using an empty value sent as if it were thorugh signer -> simpleAccount proxy
 	//	combine the transaction gas and cost for execution then multiply by 0.1%, this should be our crosschain fee + bid fee
 	// 		add this value to the paymaster and data AND PriceGwei
+	if len(packedUserOperation.PaymasterAndData) > 0 {
+		// estimation rewrites the paymaster gas limits
+		paymasterAndData, _ = DecodePaymasterAndData(packedUserOperation.PaymasterAndData)
+	}
 
-	packedUserOperation := GenerateTestPackedUserOperation()
-	paymasterAndData := PaymasterAndData{}
-
-	// empty data for basic testing
 	packedUserOperationResponse, _ := ToPackedUserOperationResponse(packedUserOperation)
 	paymasterAndDataResponse, _ := ToPaymasterAndDataResponse(paymasterAndData)
 	return MessageOpEvm{
 		UserOp:           packedUserOperationResponse,
 		PaymasterAndData: paymasterAndDataResponse,
-		UserOpHash:       "0x0000000000000000000000000000000000000000000000000000000000000000",
-		PriceGwei:        "0",
+		UserOpHash:       userOpHash.Hex(),
+		PriceGwei:        new(big.Int).Div(RequiredPrefund(packedUserOperation), big.NewInt(1e9)).String(),
 	}, nil
 }
 
@@ -580,17 +651,60 @@ func ToPaymasterAndDataResponse(pad PaymasterAndData) (PaymasterAndDataResponse,
 }
 
 // FromPackedUserOperationResponse converts a PackedUserOperationResponse to PackedUserOperation.
-//
-// Needs to be written.
 func FromPackedUserOperationResponse(packedUserOperationResponse PackedUserOperationResponse) (PackedUserOperation, error) {
-	return PackedUserOperation{}, nil
+	if !common.IsHexAddress(packedUserOperationResponse.Sender) {
+		return PackedUserOperation{}, fmt.Errorf("invalid sender: %s", packedUserOperationResponse.Sender)
+	}
+
+	nonce, ok := new(big.Int).SetString(packedUserOperationResponse.Nonce, 10)
+	if !ok {
+		return PackedUserOperation{}, fmt.Errorf("invalid nonce: %s", packedUserOperationResponse.Nonce)
+	}
+	preVerificationGas, ok := new(big.Int).SetString(packedUserOperationResponse.PreVerificationGas, 10)
+	if !ok {
+		return PackedUserOperation{}, fmt.Errorf("invalid preVerificationGas: %s", packedUserOperationResponse.PreVerificationGas)
+	}
+
+	fields := map[string]string{
+		"initCode":         packedUserOperationResponse.InitCode,
+		"callData":         packedUserOperationResponse.CallData,
+		"accountGasLimits": packedUserOperationResponse.AccountGasLimits,
+		"gasFees":          packedUserOperationResponse.GasFees,
+		"paymasterAndData": packedUserOperationResponse.PaymasterAndData,
+		"signature":        packedUserOperationResponse.Signature,
+	}
+	decoded := make(map[string][]byte, len(fields))
+	for name, value := range fields {
+		if value == "" {
+			decoded[name] = []byte{}
+			continue
+		}
+		bytes, err := hexutil.Decode(ensureHexPrefix(value))
+		if err != nil {
+			return PackedUserOperation{}, fmt.Errorf("invalid %s: %v", name, err)
+		}
+		decoded[name] = bytes
+	}
+	if len(decoded["accountGasLimits"]) != 32 || len(decoded["gasFees"]) != 32 {
+		return PackedUserOperation{}, fmt.Errorf("accountGasLimits and gasFees must be 32 bytes")
+	}
+
+	return PackedUserOperation{
+		Sender:             common.HexToAddress(packedUserOperationResponse.Sender),
+		Nonce:              nonce,
+		InitCode:           decoded["initCode"],
+		CallData:           decoded["callData"],
+		AccountGasLimits:   [32]byte(decoded["accountGasLimits"]),
+		PreVerificationGas: preVerificationGas,
+		GasFees:            [32]byte(decoded["gasFees"]),
+		PaymasterAndData:   decoded["paymasterAndData"],
+		Signature:          decoded["signature"],
+	}, nil
 }
 
 // FromPaymasterAndDataResponse converts a PaymasterAndDataResponse to PaymasterAndData.
-//
-// Needs to be written.
 func FromPaymasterAndDataResponse(pad PaymasterAndDataResponse) (PaymasterAndData, error) {
-	return PaymasterAndData{}, nil
+	return parsePaymasterAndDataResponse(pad)
 }
 
 // don't need to gen PaymasterAndData{} suffices
@@ -0,0 +1,287 @@
+package evmHandler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// placeholder limits of a built op, the values of the forge tests. EstimateUserOperationGas
+// replaces every limit before an op is returned, including the paymaster limits.
+var (
+	defaultVerificationGasLimit = big.NewInt(20000000)
+	defaultCallGasLimit         = big.NewInt(10000000)
+	defaultPreVerificationGas   = big.NewInt(20000000)
+)
+
+// UserOpCall is a single call made by the account, batched with executeBatch when more than one
+type UserOpCall struct {
+	Target common.Address
+	Value  *big.Int
+	Data   []byte
+}
+
+// PackGasPair packs two 128 bit values into a bytes32 the way accountGasLimits and gasFees are stored
+func PackGasPair(high, low *big.Int) [32]byte {
+	var packed [32]byte
+	high.FillBytes(packed[:16])
+	low.FillBytes(packed[16:])
+	return packed
+}
+
+// UnpackGasPair splits a packed bytes32 into its high and low 128 bit values
+func UnpackGasPair(packed [32]byte) (*big.Int, *big.Int) {
+	return new(big.Int).SetBytes(packed[:16]), new(big.Int).SetBytes(packed[16:])
+}
+
+// ParseUserOpCalls reads comma separated targets, values and calldata, values and calldata
+// may be left empty or be shorter than the targets
+func ParseUserOpCalls(targets, values, payloads string) ([]UserOpCall, error) {
+	if targets == "" {
+		return nil, fmt.Errorf("no call target provided")
+	}
+
+	targetList := strings.Split(targets, ",")
+	valueList := splitOptional(values)
+	payloadList := splitOptional(payloads)
+	if len(valueList) > len(targetList) || len(payloadList) > len(targetList) {
+		return nil, fmt.Errorf("more values or payloads than targets")
+	}
+
+	calls := make([]UserOpCall, 0, len(targetList))
+	for i, target := range targetList {
+		target = strings.TrimSpace(target)
+		if !common.IsHexAddress(target) {
+			return nil, fmt.Errorf("invalid call target: %s", target)
+		}
+
+		value := big.NewInt(0)
+		if i < len(valueList) && valueList[i] != "" {
+			if _, ok := value.SetString(valueList[i], 10); !ok || value.Sign() < 0 {
+				return nil, fmt.Errorf("invalid call value: %s", valueList[i])
+			}
+		}
+
+		data := []byte{}
+		if i < len(payloadList) && payloadList[i] != "" {
+			decoded, err := hexutil.Decode(ensureHexPrefix(payloadList[i]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid call payload: %v", err)
+			}
+			data = decoded
+		}
+
+		calls = append(calls, UserOpCall{
+			Target: common.HexToAddress(target),
+			Value:  value,
+			Data:   data,
+		})
+	}
+	return calls, nil
+}
+
+// EncodeAccountCallData builds the SimpleAccount execute or executeBatch calldata
+func EncodeAccountCallData(calls []UserOpCall) ([]byte, error) {
+	parsedJSON, err := abi.JSON(strings.NewReader(contractAbiSimpleAccount))
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(calls) {
+	case 0:
+		return []byte{}, nil
+	case 1:
+		return parsedJSON.Pack("execute", calls[0].Target, calls[0].Value, calls[0].Data)
+	default:
+		targets := make([]common.Address, len(calls))
+		values := make([]*big.Int, len(calls))
+		datas := make([][]byte, len(calls))
+		for i, call := range calls {
+			targets[i], values[i], datas[i] = call.Target, call.Value, call.Data
+		}
+		return parsedJSON.Pack("executeBatch", targets, values, datas)
+	}
+}
+
+// GetAccountAddress resolves the counterfactual SimpleAccount address for owner and salt
+func GetAccountAddress(client *ethclient.Client, factory common.Address, owner common.Address, salt *big.Int) (common.Address, error) {
+	parsedJSON, err := abi.JSON(strings.NewReader(contractAbiSimpleAccountFactory))
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	result, err := ViewFunction(client, factory, parsedJSON, "getAddress", owner, salt)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed getAddress request: %v", err)
+	}
+	if len(result) < 32 {
+		return common.Address{}, fmt.Errorf("getAddress returned %d bytes", len(result))
+	}
+	return common.BytesToAddress(result[12:32]), nil
+}
+
+// GetAccountInitCode returns factory ++ createAccount(owner, salt)
+func GetAccountInitCode(factory common.Address, owner common.Address, salt *big.Int) ([]byte, error) {
+	parsedJSON, err := abi.JSON(strings.NewReader(contractAbiSimpleAccountFactory))
+	if err != nil {
+		return nil, err
+	}
+
+	callData, err := parsedJSON.Pack("createAccount", owner, salt)
+	if err != nil {
+		return nil, err
+	}
+	return append(factory.Bytes(), callData...), nil
+}
+
+// GetEntrypointNonce reads the next nonce of sender for the 192 bit key
+func GetEntrypointNonce(client *ethclient.Client, entrypoint common.Address, sender common.Address, key *big.Int) (*big.Int, error) {
+	parsedJSON, err := abi.JSON(strings.NewReader(contractAbiEntrypoint))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ViewFunction(client, entrypoint, parsedJSON, "getNonce", sender, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed getNonce request: %v", err)
+	}
+	return new(big.Int).SetBytes(result), nil
+}
+
+// BuildPackedUserOperation creates an unsigned op for the owner's SimpleAccount on chain,
+// initCode is only set while the account is not deployed
+func BuildPackedUserOperation(client *ethclient.Client, chain *chains.Chain, owner common.Address, salt *big.Int, calls []UserOpCall) (PackedUserOperation, error) {
+	if chain.Contracts.SimpleAccountFactory == "" || chain.Contracts.Entrypoint == "" {
+		return PackedUserOperation{}, fmt.Errorf("entrypoint or account factory not configured for chain id: %s", chain.ID)
+	}
+	factory := common.HexToAddress(chain.Contracts.SimpleAccountFactory)
+	entrypoint := common.HexToAddress(chain.Contracts.Entrypoint)
+
+	sender, err := GetAccountAddress(client, factory, owner, salt)
+	if err != nil {
+		return PackedUserOperation{}, err
+	}
+
+	codeSize, err := ExtCodeSize(client, sender)
+	if err != nil {
+		return PackedUserOperation{}, fmt.Errorf("failed to get account code: %v", err)
+	}
+
+	initCode := []byte{}
+	if codeSize == 0 {
+		initCode, err = GetAccountInitCode(factory, owner, salt)
+		if err != nil {
+			return PackedUserOperation{}, fmt.Errorf("initCode generation failed: %v", err)
+		}
+	}
+
+	nonce, err := GetEntrypointNonce(client, entrypoint, sender, big.NewInt(0))
+	if err != nil {
+		return PackedUserOperation{}, err
+	}
+
+	callData, err := EncodeAccountCallData(calls)
+	if err != nil {
+		return PackedUserOperation{}, fmt.Errorf("callData generation failed: %v", err)
+	}
+
+	maxPriorityFeePerGas, maxFeePerGas, err := GetFeeCaps(context.Background(), client, DefaultGasOptions)
+	if err != nil {
+		return PackedUserOperation{}, err
+	}
+
+	return PackedUserOperation{
+		Sender:             sender,
+		Nonce:              nonce,
+		InitCode:           initCode,
+		CallData:           callData,
+		AccountGasLimits:   PackGasPair(defaultVerificationGasLimit, defaultCallGasLimit),
+		PreVerificationGas: new(big.Int).Set(defaultPreVerificationGas),
+		GasFees:            PackGasPair(maxPriorityFeePerGas, maxFeePerGas),
+		PaymasterAndData:   []byte{},
+		Signature:          []byte{},
+	}, nil
+}
+
+// GetUserOpHash computes the v0.7 EntryPoint hash:
+// keccak256(abi.encode(keccak256(pack(op)), entrypoint, chainId))
+func GetUserOpHash(op PackedUserOperation, entrypoint common.Address, chainId *big.Int) (common.Hash, error) {
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+
+	packed, err := abi.Arguments{
+		{Type: addressType},
+		{Type: uint256Type},
+		{Type: bytes32Type},
+		{Type: bytes32Type},
+		{Type: bytes32Type},
+		{Type: uint256Type},
+		{Type: bytes32Type},
+		{Type: bytes32Type},
+	}.Pack(
+		op.Sender,
+		op.Nonce,
+		crypto.Keccak256Hash(op.InitCode),
+		crypto.Keccak256Hash(op.CallData),
+		op.AccountGasLimits,
+		op.PreVerificationGas,
+		op.GasFees,
+		crypto.Keccak256Hash(op.PaymasterAndData),
+	)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack user operation: %v", err)
+	}
+
+	encoded, err := abi.Arguments{
+		{Type: bytes32Type},
+		{Type: addressType},
+		{Type: uint256Type},
+	}.Pack(crypto.Keccak256Hash(packed), entrypoint, chainId)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack user operation hash: %v", err)
+	}
+
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// RequiredPrefund is the max wei the op can be charged: all gas limits times maxFeePerGas
+func RequiredPrefund(op PackedUserOperation) *big.Int {
+	verificationGasLimit, callGasLimit := UnpackGasPair(op.AccountGasLimits)
+	_, maxFeePerGas := UnpackGasPair(op.GasFees)
+
+	gas := new(big.Int).Add(verificationGasLimit, callGasLimit)
+	gas.Add(gas, op.PreVerificationGas)
+	if len(op.PaymasterAndData) >= 52 {
+		// paymaster ++ uint128 verification gas ++ uint128 postOp gas
+		gas.Add(gas, new(big.Int).SetBytes(op.PaymasterAndData[20:36]))
+		gas.Add(gas, new(big.Int).SetBytes(op.PaymasterAndData[36:52]))
+	}
+	return gas.Mul(gas, maxFeePerGas)
+}
+
+func splitOptional(list string) []string {
+	if list == "" {
+		return nil
+	}
+	parts := strings.Split(list, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+func ensureHexPrefix(s string) string {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return s
+	}
+	return "0x" + s
+}
@@ -0,0 +1,138 @@
+// Package grpcHandler serves the crosscall grpc api, every rpc binds its request into the param
+// structs of the http api and calls the same service function.
+package grpcHandler
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/crosscall-labs/crosschain-api --go-grpc_out=../.. --go-grpc_opt=module=github.com/crosscall-labs/crosschain-api crosscall/v1/crosscall.proto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/pb/crosscallv1"
+	"github.com/supabase-community/supabase-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const DefaultAddr = ":9090"
+
+type Server struct {
+	crosscallv1.UnimplementedCrosscallServiceServer
+}
+
+// NewServer registers the crosscall service and reflection for grpcurl
+func NewServer() *grpc.Server {
+	s := grpc.NewServer()
+	crosscallv1.RegisterCrosscallServiceServer(s, &Server{})
+	reflection.Register(s)
+	return s
+}
+
+// Serve listens on addr, GRPC_ADDR or :9090 when empty, until the server stops
+func Serve(s *grpc.Server, addr string) error {
+	if addr == "" {
+		if addr = os.Getenv("GRPC_ADDR"); addr == "" {
+			addr = DefaultAddr
+		}
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", addr, err)
+	}
+	return s.Serve(lis)
+}
+
+type serviceFunc func(r *http.Request) (interface{}, error)
+
+// service adapts the variadic service functions, the params always come from the request
+func service[P any](fn func(r *http.Request, parameters ...P) (interface{}, error)) serviceFunc {
+	return func(r *http.Request) (interface{}, error) {
+		return fn(r)
+	}
+}
+
+// call sends the request as the json body the /v1 routes take, the json names of the proto fields
+// are the query tags of the param structs
+func call(ctx context.Context, request proto.Message, fn serviceFunc) (*crosscallv1.Response, error) {
+	body, err := protojson.Marshal(request)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to encode request: %v", err)
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "/grpc", bytes.NewReader(body))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	r.Header.Set("Content-Type", "application/json")
+
+	response, err := fn(r)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toResponse(response)
+}
+
+// toResponse converts the json of the http response, responses that are not objects are set under value
+func toResponse(response interface{}) (*crosscallv1.Response, error) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode response: %v", err)
+	}
+	object, ok := decoded.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{"value": decoded}
+	}
+	result, err := structpb.NewStruct(object)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+	return &crosscallv1.Response{Result: result}, nil
+}
+
+func toStatus(err error) error {
+	apiErr := apierr.From(err)
+	message := apiErr.Message
+	if apiErr.Details != "" {
+		message = fmt.Sprintf("%s: %s", apiErr.Message, apiErr.Details)
+	}
+	if apiErr.Revert != "" {
+		message = fmt.Sprintf("%s (%s)", message, apiErr.Revert)
+	}
+	return status.Error(grpcCode(apiErr.Kind), message)
+}
+
+func grpcCode(kind apierr.Kind) codes.Code {
+	switch kind {
+	case apierr.InvalidParam, apierr.UnsupportedChain:
+		return codes.InvalidArgument
+	case apierr.SignatureInvalid, apierr.Unauthorized:
+		return codes.Unauthenticated
+	case apierr.InsufficientEscrow, apierr.EscrowNotFound, apierr.EscrowLockExpiring, apierr.SimulationRevert, apierr.QuoteExpired:
+		return codes.FailedPrecondition
+	case apierr.RpcFailure:
+		return codes.Unavailable
+	case apierr.NotFound:
+		return codes.NotFound
+	case apierr.MethodNotAllowed:
+		return codes.Unimplemented
+	}
+	return codes.Internal
+}
+
+func newSupabaseClient() (*supabase.Client, error) {
+	return supabase.NewClient(os.Getenv("SUPABASE_URL"), os.Getenv("SUPABASE_SERVICE_ROLE_KEY"), nil)
+}
@@ -0,0 +1,146 @@
+package grpcHandler
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToResponse(t *testing.T) {
+	type nested struct {
+		Hash string `json:"hash"`
+		Skip string `json:"skip,omitempty"`
+	}
+	type response struct {
+		Value  string   `json:"value"`
+		Count  int      `json:"count"`
+		Ok     bool     `json:"ok"`
+		Items  []string `json:"items"`
+		Nested nested   `json:"nested"`
+		hidden string
+	}
+
+	t.Run("object", func(t *testing.T) {
+		out, err := toResponse(response{Value: "1", Count: 2, Ok: true, Items: []string{"a"}, Nested: nested{Hash: "0x01"}, hidden: "x"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields := out.Result.AsMap()
+		if fields["value"] != "1" || fields["count"] != float64(2) || fields["ok"] != true {
+			t.Fatalf("fields %v", fields)
+		}
+		if items := fields["items"].([]interface{}); len(items) != 1 || items[0] != "a" {
+			t.Fatalf("items %v", fields["items"])
+		}
+		object := fields["nested"].(map[string]interface{})
+		if object["hash"] != "0x01" || len(object) != 1 {
+			t.Fatalf("nested %v", object)
+		}
+		if _, ok := fields["hidden"]; ok {
+			t.Fatal("unexported field in the response")
+		}
+	})
+
+	tests := []struct {
+		name     string
+		response interface{}
+		want     interface{}
+	}{
+		{"string", "done", "done"},
+		{"number", 3, float64(3)},
+		{"list", []int{1}, []interface{}{float64(1)}},
+		{"nil", nil, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := toResponse(test.response)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fields := out.Result.AsMap()
+			if len(fields) != 1 {
+				t.Fatalf("fields %v", fields)
+			}
+			if got := fields["value"]; !equalJSON(got, test.want) {
+				t.Fatalf("value %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	t.Run("unencodable", func(t *testing.T) {
+		_, err := toResponse(math.Inf(1))
+		if status.Code(err) != codes.Internal {
+			t.Fatalf("err %v", err)
+		}
+	})
+}
+
+func equalJSON(a, b interface{}) bool {
+	listA, okA := a.([]interface{})
+	listB, okB := b.([]interface{})
+	if okA || okB {
+		if len(listA) != len(listB) {
+			return false
+		}
+		for i := range listA {
+			if !equalJSON(listA[i], listB[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+func TestGrpcCode(t *testing.T) {
+	tests := map[apierr.Kind]codes.Code{
+		apierr.Internal:           codes.Internal,
+		apierr.InvalidParam:       codes.InvalidArgument,
+		apierr.UnsupportedChain:   codes.InvalidArgument,
+		apierr.RpcFailure:         codes.Unavailable,
+		apierr.InsufficientEscrow: codes.FailedPrecondition,
+		apierr.EscrowNotFound:     codes.FailedPrecondition,
+		apierr.EscrowLockExpiring: codes.FailedPrecondition,
+		apierr.SignatureInvalid:   codes.Unauthenticated,
+		apierr.SimulationRevert:   codes.FailedPrecondition,
+		apierr.QuoteExpired:       codes.FailedPrecondition,
+		apierr.Unauthorized:       codes.Unauthenticated,
+		apierr.NotFound:           codes.NotFound,
+		apierr.MethodNotAllowed:   codes.Unimplemented,
+		apierr.Kind("unknown"):    codes.Internal,
+	}
+	for kind, want := range tests {
+		if got := grpcCode(kind); got != want {
+			t.Errorf("%s: code %s, want %s", kind, got, want)
+		}
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	revert := apierr.New(apierr.SimulationRevert, "handleOps reverted")
+	revert.Revert = "FailedOp(0, \"AA21\")"
+
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{"catalog error", apierr.New(apierr.InvalidParam, "bad chain"), codes.InvalidArgument, "bad chain"},
+		{"revert", revert, codes.FailedPrecondition, "(FailedOp(0, \"AA21\"))"},
+		{"plain error", errors.New("boom"), codes.Internal, "boom"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			st := status.Convert(toStatus(test.err))
+			if st.Code() != test.code || !strings.Contains(st.Message(), test.message) {
+				t.Fatalf("status %s %q", st.Code(), st.Message())
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package grpcHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	evmHandler "github.com/crosscall-labs/crosschain-api/api/evm"
+	infoHandler "github.com/crosscall-labs/crosschain-api/api/info"
+	handler "github.com/crosscall-labs/crosschain-api/api/main"
+	requestHandler "github.com/crosscall-labs/crosschain-api/api/request"
+	svmHandler "github.com/crosscall-labs/crosschain-api/api/svm"
+	tvmHandler "github.com/crosscall-labs/crosschain-api/api/tvm"
+	"github.com/crosscall-labs/crosschain-api/pkg/events"
+	"github.com/crosscall-labs/crosschain-api/pkg/intent"
+	"github.com/crosscall-labs/crosschain-api/pkg/pb/crosscallv1"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// polling fallback of WatchIntent, published transitions are sent right away
+const watchInterval = 10 * time.Second
+
+func (s *Server) Version(ctx context.Context, request *crosscallv1.VersionRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(infoHandler.VersionRequest))
+}
+
+func (s *Server) UserInfo(ctx context.Context, request *crosscallv1.UserInfoRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(infoHandler.UserInfoRequest))
+}
+
+func (s *Server) AssetInfo(ctx context.Context, request *crosscallv1.AssetInfoRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(infoHandler.AssetInfoRequest))
+}
+
+func (s *Server) AssetMint(ctx context.Context, request *crosscallv1.AssetMintRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(requestHandler.AssetMintRequest))
+}
+
+func (s *Server) UnsignedMessage(ctx context.Context, request *crosscallv1.UnsignedMessageRequest) (*crosscallv1.Response, error) {
+	supabaseClient, err := newSupabaseClient()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create supabase client: %v", err)
+	}
+	return call(ctx, request, func(r *http.Request) (interface{}, error) {
+		return handler.UnsignedRequest(r, supabaseClient)
+	})
+}
+
+func (s *Server) UnsignedEvmEntryPoint(ctx context.Context, request *crosscallv1.UnsignedEvmEntryPointRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(evmHandler.UnsignedEntryPointRequest))
+}
+
+func (s *Server) UnsignedSvmEntryPoint(ctx context.Context, request *crosscallv1.UnsignedSvmEntryPointRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(svmHandler.UnsignedEntryPointRequest))
+}
+
+func (s *Server) UnsignedTvmEntryPoint(ctx context.Context, request *crosscallv1.UnsignedTvmEntryPointRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(tvmHandler.UnsignedEntryPointRequest))
+}
+
+func (s *Server) SignedTvmEntryPoint(ctx context.Context, request *crosscallv1.SignedTvmEntryPointRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(tvmHandler.SignedEntryPointRequest))
+}
+
+func (s *Server) UnsignedEvmEscrow(ctx context.Context, request *crosscallv1.UnsignedEvmEscrowRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(evmHandler.UnsignedEscrowRequest))
+}
+
+func (s *Server) UnsignedSvmEscrow(ctx context.Context, request *crosscallv1.UnsignedSvmEscrowRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(svmHandler.UnsignedEscrowRequest))
+}
+
+func (s *Server) UnsignedTvmEscrow(ctx context.Context, request *crosscallv1.UnsignedTvmEscrowRequest) (*crosscallv1.Response, error) {
+	return call(ctx, request, service(tvmHandler.UnsignedEscrowRequest))
+}
+
+// WatchIntent sends the stored intent whenever a transition was recorded. Transitions published on
+// the event bus of this instance wake the watch, the intent is polled as the fallback for steps
+// that are not published or were recorded by another instance.
+func (s *Server) WatchIntent(request *crosscallv1.WatchIntentRequest, stream grpc.ServerStreamingServer[crosscallv1.Intent]) error {
+	if request.Id == "" {
+		return status.Error(codes.InvalidArgument, "id is required")
+	}
+	supabaseClient, err := newSupabaseClient()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create supabase client: %v", err)
+	}
+
+	published, cancel := events.Subscribe(0, request.Id)
+	defer cancel()
+	load := func() (intent.Intent, error) {
+		return intent.Get(supabaseClient, request.Id)
+	}
+	return watchIntent(stream.Context(), load, stream.Send, published, watchInterval)
+}
+
+func watchIntent(ctx context.Context, load func() (intent.Intent, error), send func(*crosscallv1.Intent) error, published <-chan events.Event, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	sent := -1 // transitions already sent
+	for {
+		current, err := load()
+		switch {
+		case errors.Is(err, intent.ErrNotFound) && sent < 0:
+			return status.Error(codes.NotFound, err.Error())
+		case err != nil:
+			utils.LogError("failed to get watched intent", err.Error())
+		case len(current.History) != sent:
+			if err := send(toIntent(current)); err != nil {
+				return err
+			}
+			sent = len(current.History)
+			if current.State.Terminal() {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		case _, ok := <-published:
+			if !ok {
+				published = nil
+			}
+		}
+	}
+}
+
+func toIntent(i intent.Intent) *crosscallv1.Intent {
+	out := &crosscallv1.Intent{
+		Id:                i.Id,
+		Vm:                i.VM,
+		State:             string(i.State),
+		OriginId:          i.OriginId,
+		DestinationId:     i.DestinationId,
+		OriginSigner:      i.OriginSigner,
+		DestinationSigner: i.DestinationSigner,
+		AssetAddress:      i.AssetAddress,
+		AssetAmount:       i.AssetAmount,
+		TxHash:            i.TxHash,
+		PayoutTx:          i.PayoutTx,
+		Error:             i.Error,
+	}
+	if i.UpdatedAt != nil {
+		out.UpdatedAt = i.UpdatedAt.Format(time.RFC3339)
+	}
+	for _, transition := range i.History {
+		out.History = append(out.History, &crosscallv1.IntentTransition{
+			From:   string(transition.From),
+			To:     string(transition.To),
+			At:     transition.At.Format(time.RFC3339),
+			Detail: transition.Detail,
+		})
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package grpcHandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/events"
+	"github.com/crosscall-labs/crosschain-api/pkg/intent"
+	"github.com/crosscall-labs/crosschain-api/pkg/pb/crosscallv1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// storedIntent fakes the intents table of a watch
+type storedIntent struct {
+	mu      sync.Mutex
+	current intent.Intent
+	err     error
+}
+
+func (s *storedIntent) load() (intent.Intent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current, s.err
+}
+
+func (s *storedIntent) advance(to intent.State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current.History = append(s.current.History, intent.Transition{From: s.current.State, To: to, At: time.Now()})
+	s.current.State = to
+}
+
+func TestWatchIntentWakesOnEvents(t *testing.T) {
+	bus := events.NewBus()
+	published, cancel := bus.Subscribe(0, "0xabcd")
+	defer cancel()
+	stored := &storedIntent{current: intent.Intent{Id: "0xabcd", State: intent.Signed, History: []intent.Transition{{To: intent.Signed}}}}
+
+	sent := make(chan *crosscallv1.Intent, 8)
+	done := make(chan error, 1)
+	go func() {
+		send := func(i *crosscallv1.Intent) error {
+			sent <- i
+			return nil
+		}
+		// the fallback poll never fires, only the bus moves the watch
+		done <- watchIntent(context.Background(), stored.load, send, published, time.Hour)
+	}()
+
+	for _, state := range []intent.State{"", intent.Executed, intent.PaidOut} {
+		if state != "" {
+			stored.advance(state)
+			bus.Publish(events.Event{Kind: events.DestinationExecuted, Keys: []string{"0xABCD"}})
+		}
+		select {
+		case i := <-sent:
+			if state != "" && i.State != string(state) {
+				t.Fatalf("sent state %s, want %s", i.State, state)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no intent sent for %q", state)
+		}
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("watch did not end on a terminal state")
+	}
+}
+
+func TestWatchIntentPollsWithoutEvents(t *testing.T) {
+	stored := &storedIntent{current: intent.Intent{Id: "0xabcd", State: intent.Signed, History: []intent.Transition{{To: intent.Signed}}}}
+	var sent []string
+	send := func(i *crosscallv1.Intent) error {
+		sent = append(sent, i.State)
+		if len(sent) == 1 {
+			stored.advance(intent.Failed)
+		}
+		return nil
+	}
+	// a closed bus leaves the polling fallback
+	bus := events.NewBus()
+	bus.Close()
+	published, _ := bus.Subscribe(0, "0xabcd")
+
+	if err := watchIntent(context.Background(), stored.load, send, published, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sent) != "[signed failed]" {
+		t.Fatalf("sent %v", sent)
+	}
+}
+
+func TestWatchIntentErrors(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		stored := &storedIntent{err: fmt.Errorf("%w: 0xabcd", intent.ErrNotFound)}
+		err := watchIntent(context.Background(), stored.load, nil, nil, time.Hour)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("err %v", err)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		stored := &storedIntent{current: intent.Intent{State: intent.Signed}}
+		ctx, cancel := context.WithCancel(context.Background())
+		send := func(*crosscallv1.Intent) error {
+			cancel()
+			return nil
+		}
+		err := watchIntent(ctx, stored.load, send, nil, time.Hour)
+		if status.Code(err) != codes.Canceled {
+			t.Fatalf("err %v", err)
+		}
+	})
+
+	t.Run("send fails", func(t *testing.T) {
+		stored := &storedIntent{current: intent.Intent{State: intent.Signed}}
+		failed := errors.New("stream closed")
+		err := watchIntent(context.Background(), stored.load, func(*crosscallv1.Intent) error { return failed }, nil, time.Hour)
+		if !errors.Is(err, failed) {
+			t.Fatalf("err %v", err)
+		}
+	})
+}
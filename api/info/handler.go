@@ -1,12 +1,12 @@
 package infoHandler
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
 	"github.com/crosscall-labs/crosschain-api/pkg/db"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 	"github.com/supabase-community/supabase-go"
@@ -29,7 +29,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 				log.Printf("\nFailed to create Supabase client for panic logging: %v", err)
 			}
 
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			utils.WriteError(w, utils.ErrInternal("recovered from panic"))
 		}
 	}()
 
@@ -41,7 +41,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		supabaseKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
 		supabaseClient, err := supabase.NewClient(supabaseUrl, supabaseKey, nil)
 		if err != nil {
-			http.Error(w, "Failed to create Supabase client", http.StatusInternalServerError)
+			utils.WriteError(w, utils.ErrInternal("failed to create supabase client"))
 			return
 		}
 
@@ -51,10 +51,18 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 			response, err = VersionRequest(r)
 			HandleResponse(w, r, supabaseClient, response, err)
 			return
-		// case "chain-info": // need to migrate
-		// 	response, err = UnsignedEntryPointRequest(r)
-		// 	HandleResponse(w, r, supabaseClient, response, err)
-		// 	return
+		case "chain-info":
+			response, err = ChainInfoRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
+		case "chain-reload": // admin only, reloads the chain registry from its configured source
+			if !utils.AdminAuthorized(r) {
+				utils.WriteError(w, utils.Err(apierr.Unauthorized, "missing or invalid X-Admin-Key"))
+				return
+			}
+			response, err = ChainReloadRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
 		case "asset-info":
 			response, err = AssetInfoRequest(r)
 			HandleResponse(w, r, supabaseClient, response, err)
@@ -64,12 +72,11 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 			HandleResponse(w, r, supabaseClient, response, err)
 			return
 		case "user-transactions": // pull users current transaction logs across all chains
-			response, err = UserTransactionsRequest(r)
+			response, err = UserTransactionsRequest(r, supabaseClient)
 			HandleResponse(w, r, supabaseClient, response, err)
 			return
 		default:
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(utils.ErrMalformedRequest("Invalid query parameter"))
+			utils.WriteError(w, utils.ErrMalformedRequest("Invalid query parameter"))
 			return
 		}
 	}))
@@ -82,14 +89,6 @@ func HandleResponse(w http.ResponseWriter, r *http.Request, supabaseClient *supa
 		if logErr := db.LogError(supabaseClient, err, r.URL.Query().Get("query"), response); logErr != nil {
 			fmt.Printf("Failed to log error: %v\n", logErr.Error())
 		}
-
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(err)
-		return
-	}
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 	}
+	utils.WriteResponse(w, response, err)
 }
@@ -1 +1,83 @@
 package infoHandler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/rpcpool"
+	"github.com/crosscall-labs/crosschain-api/pkg/tonx"
+)
+
+const healthTimeout = 3 * time.Second
+
+// checkChainsHealth runs the health checks concurrently, results keep the input order
+func checkChainsHealth(chainList []*chains.Chain) []*ChainHealth {
+	results := make([]*ChainHealth, len(chainList))
+
+	var wg sync.WaitGroup
+	for i, chain := range chainList {
+		wg.Add(1)
+		go func(i int, chain *chains.Chain) {
+			defer wg.Done()
+			results[i] = checkChainHealth(chain)
+		}(i, chain)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func checkChainHealth(chain *chains.Chain) *ChainHealth {
+	if !chain.Enabled {
+		return &ChainHealth{Status: "disabled"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var health *ChainHealth
+	switch chain.VM {
+	case "evm":
+		health = checkEvmHealth(ctx, chain)
+	case "tvm":
+		health = checkTvmHealth(ctx)
+	default:
+		return &ChainHealth{Status: "unknown"}
+	}
+	health.LatencyMs = time.Since(start).Milliseconds()
+	return health
+}
+
+func checkEvmHealth(ctx context.Context, chain *chains.Chain) *ChainHealth {
+	pool, err := rpcpool.EvmPool(chain.ID)
+	if err != nil {
+		return &ChainHealth{Status: "down", Error: err.Error()}
+	}
+	client, err := rpcpool.EvmClient(chain.ID)
+	if err != nil {
+		return &ChainHealth{Status: "down", Error: err.Error()}
+	}
+
+	block, err := client.BlockNumber(ctx)
+	if err != nil {
+		return &ChainHealth{Status: "down", Error: err.Error(), Endpoints: pool.Status()}
+	}
+	return &ChainHealth{Status: "ok", Block: block, Endpoints: pool.Status()}
+}
+
+// tvm health goes through tonx since the liteserver pool takes too long to warm up for a status check
+func checkTvmHealth(ctx context.Context) *ChainHealth {
+	client, err := tonx.NewTestnetClient(tonx.WithRetries(0, 0))
+	if err != nil {
+		return &ChainHealth{Status: "unknown", Error: "tonx not configured"}
+	}
+
+	info, err := client.GetMasterchainInfo(ctx)
+	if err != nil {
+		return &ChainHealth{Status: "down", Rpc: client.URL(), Error: err.Error()}
+	}
+	return &ChainHealth{Status: "ok", Rpc: client.URL(), Block: uint64(info.Last.Seqno)}
+}
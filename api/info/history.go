@@ -0,0 +1,315 @@
+package infoHandler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	evmHandler "github.com/crosscall-labs/crosschain-api/api/evm"
+	tvmHandler "github.com/crosscall-labs/crosschain-api/api/tvm"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/intent"
+	"github.com/crosscall-labs/crosschain-api/pkg/rpcpool"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/supabase-community/supabase-go"
+)
+
+const historyTimeout = 20 * time.Second
+
+// proxy wallet transactions read per request
+const proxyWalletHistoryLimit = 50
+
+// escrow salt used by the escrow requests, see SALT in the handler
+var escrowSalt = common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000037")
+
+// historyCursor is the block each evm source continues before, keyed by chain id/source
+type historyCursor map[string]uint64
+
+// parseHistoryCursor reads "chain/source:block,..." as returned in a response
+func parseHistoryCursor(cursor string) (historyCursor, error) {
+	parsed := historyCursor{}
+	for _, entry := range strings.Split(cursor, ",") {
+		key, block, found := strings.Cut(entry, ":")
+		before, err := strconv.ParseUint(block, 10, 64)
+		if !found || err != nil || before == 0 || !strings.Contains(key, "/") {
+			return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid cursor entry: %s", entry))
+		}
+		parsed[key] = before
+	}
+	return parsed, nil
+}
+
+func (c historyCursor) String() string {
+	entries := make([]string, 0, len(c))
+	for key, before := range c {
+		entries = append(entries, fmt.Sprintf("%s:%d", key, before))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// collectUserHistory merges the intents of the signers with their on-chain history, newest first.
+// Sources that fail are reported in the returned errors instead of failing the history. A cursor
+// only reads the older events of the evm sources it names, intents and proxy wallets are complete
+// on the first page.
+func collectUserHistory(supabaseClient *supabase.Client, signers []string, evmSigner common.Address, hasEvmSigner bool, tvmSigner string, cursor historyCursor) ([]UserTransaction, []HistoryScan, historyCursor, []string) {
+	var (
+		mu      sync.Mutex
+		history []UserTransaction
+		scans   []HistoryScan
+		errs    []string
+	)
+	next := historyCursor{}
+	intents := make(map[string]int) // intent id -> index in history
+
+	for _, signer := range signers {
+		if cursor != nil {
+			break
+		}
+		list, _, err := intent.ListBySigner(supabaseClient, signer, 0, userTransactionsIntentLimit)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("intents: %v", err))
+			break
+		}
+		for _, i := range list {
+			if _, found := intents[strings.ToLower(i.Id)]; found {
+				continue
+			}
+			intents[strings.ToLower(i.Id)] = len(history)
+			history = append(history, intentTransaction(i))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), historyTimeout)
+	defer cancel()
+
+	var userOps []UserTransaction
+	var wg sync.WaitGroup
+	for _, chain := range chains.Default().Chains() {
+		if !chain.Enabled || !hasEvmSigner {
+			continue
+		}
+		switch {
+		case chain.VM == "evm":
+			wg.Add(1)
+			go func(chain *chains.Chain) {
+				defer wg.Done()
+				ops, escrow, chainScans, chainErrs := evmHistory(ctx, chain, evmSigner, cursor)
+				mu.Lock()
+				defer mu.Unlock()
+				userOps = append(userOps, ops...)
+				history = append(history, escrow...)
+				errs = append(errs, chainErrs...)
+				for _, scan := range chainScans {
+					scans = append(scans, scan.HistoryScan)
+					if scan.before != 0 {
+						next[scan.ChainId+"/"+scan.Source] = scan.before
+					}
+				}
+			}(chain)
+		case chain.VM == "tvm" && tvmSigner != "" && cursor == nil:
+			wg.Add(1)
+			go func(chain *chains.Chain) {
+				defer wg.Done()
+				txs, err := tvmHistory(ctx, chain, evmSigner, tvmSigner)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("%s proxy wallet: %v", chain.ID, err))
+				}
+				history = append(history, txs...)
+			}(chain)
+		}
+	}
+	wg.Wait()
+
+	// executed ops of a recorded intent complete the intent instead of being listed twice
+	for _, op := range userOps {
+		index, found := intents[strings.ToLower(op.Id)]
+		if !found {
+			history = append(history, op)
+			continue
+		}
+		recorded := &history[index]
+		if recorded.TxHash == "" {
+			recorded.TxHash = op.TxHash
+			recorded.TxUrl = op.TxUrl
+		}
+		recorded.BlockNumber = op.BlockNumber
+		recorded.GasCost = op.GasCost
+	}
+
+	sort.SliceStable(history, func(i, j int) bool { return history[i].timestamp.After(history[j].timestamp) })
+	sort.Slice(scans, func(i, j int) bool {
+		if scans[i].ChainId != scans[j].ChainId {
+			return scans[i].ChainId < scans[j].ChainId
+		}
+		return scans[i].Source < scans[j].Source
+	})
+	sort.Strings(errs)
+	return history, scans, next, errs
+}
+
+func intentTransaction(i intent.Intent) UserTransaction {
+	transaction := UserTransaction{
+		Id:            i.Id,
+		Source:        "intent",
+		Kind:          i.VM,
+		Status:        string(i.State),
+		ChainId:       i.DestinationId,
+		OriginId:      i.OriginId,
+		DestinationId: i.DestinationId,
+		AssetAddress:  i.AssetAddress,
+		AssetAmount:   i.AssetAmount,
+		TxHash:        i.TxHash,
+		PayoutTx:      i.PayoutTx,
+		Error:         i.Error,
+		History:       i.History,
+	}
+	// stale quotes are expired on the write path, until then they are shown as expired
+	if i.IsExpired(time.Now().UTC()) {
+		transaction.Status = string(intent.Expired)
+	}
+	if i.CreatedAt != nil {
+		transaction.timestamp = i.CreatedAt.UTC()
+		transaction.Time = transaction.timestamp.Format(time.RFC3339)
+	}
+	// the op and its payout dispatch both run on the destination chain
+	if chain, found := chains.Lookup(i.DestinationId); found {
+		transaction.TxUrl = chain.TxUrl(i.TxHash)
+		transaction.PayoutUrl = chain.TxUrl(i.PayoutTx)
+	}
+	return transaction
+}
+
+// evmScan is a HistoryScan with the block the next page of the source ends at, zero when done
+type evmScan struct {
+	HistoryScan
+	before uint64
+}
+
+// evmHistory returns the user operations and escrow events of the signer on chain. With a cursor
+// only the sources it continues are read.
+func evmHistory(ctx context.Context, chain *chains.Chain, signer common.Address, cursor historyCursor) ([]UserTransaction, []UserTransaction, []evmScan, []string) {
+	client, err := rpcpool.EvmClient(chain.ID)
+	if err != nil {
+		return nil, nil, nil, []string{fmt.Sprintf("%s: %v", chain.ID, err)}
+	}
+
+	// read reports whether the source is part of this page and the block it ends at
+	read := func(source string) (bool, uint64) {
+		if cursor == nil {
+			return true, 0
+		}
+		before, found := cursor[chain.ID+"/"+source]
+		return found, before
+	}
+	scan := func(source string, scanned evmHandler.HistoryRange) evmScan {
+		return evmScan{
+			HistoryScan: HistoryScan{
+				ChainId:   chain.ID,
+				Source:    source,
+				FromBlock: strconv.FormatUint(scanned.FromBlock, 10),
+				ToBlock:   strconv.FormatUint(scanned.ToBlock, 10),
+			},
+			before: scanned.Before,
+		}
+	}
+
+	var errs []string
+	var scans []evmScan
+	var ops, escrow []UserTransaction
+	if ok, before := read("userop"); ok && chain.Contracts.Entrypoint != "" && chain.Contracts.SimpleAccountFactory != "" {
+		events, scanned, err := evmHandler.AccountHistory(ctx, client, chain, signer, before)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s user operations: %v", chain.ID, err))
+		} else if scanned.ToBlock != 0 {
+			scans = append(scans, scan("userop", scanned))
+		}
+		for _, event := range events {
+			transaction := evmTransaction(chain, event)
+			transaction.Id = event.UserOpHash.Hex()
+			transaction.Source = "userop"
+			transaction.GasCost = event.Amount.String()
+			ops = append(ops, transaction)
+		}
+	}
+	if ok, before := read("escrow"); ok && chain.Contracts.Escrow != "" && chain.Contracts.EscrowFactory != "" {
+		events, scanned, err := evmHandler.EscrowHistory(ctx, client, chain, signer, escrowSalt[:], before)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s escrow: %v", chain.ID, err))
+		} else {
+			scans = append(scans, scan("escrow", scanned))
+		}
+		for _, event := range events {
+			transaction := evmTransaction(chain, event)
+			transaction.Id = event.TxHash.Hex()
+			transaction.Source = "escrow"
+			transaction.AssetAddress = event.Asset.Hex()
+			transaction.EscrowBalance = event.Amount.String()
+			escrow = append(escrow, transaction)
+		}
+	}
+	return ops, escrow, scans, errs
+}
+
+func evmTransaction(chain *chains.Chain, event evmHandler.HistoryEvent) UserTransaction {
+	status := "success"
+	if !event.Success {
+		status = "failed"
+	}
+	transaction := UserTransaction{
+		Kind:        event.Kind,
+		Status:      status,
+		ChainId:     chain.ID,
+		Contract:    event.Contract.Hex(),
+		ContractUrl: chain.AddressUrl(event.Contract.Hex()),
+		TxHash:      event.TxHash.Hex(),
+		TxUrl:       chain.TxUrl(event.TxHash.Hex()),
+		BlockNumber: strconv.FormatUint(event.BlockNumber, 10),
+	}
+	// zero when the block header could not be read
+	if event.Timestamp != 0 {
+		transaction.timestamp = time.Unix(int64(event.Timestamp), 0).UTC()
+		transaction.Time = transaction.timestamp.Format(time.RFC3339)
+	}
+	return transaction
+}
+
+// tvmHistory returns the transactions of the proxy wallet owned by both signers
+func tvmHistory(ctx context.Context, chain *chains.Chain, evmSigner common.Address, tvmSigner string) ([]UserTransaction, error) {
+	proxyWalletAddress, txs, err := tvmHandler.ProxyWalletHistory(ctx, evmSigner, tvmSigner, proxyWalletHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]UserTransaction, 0, len(txs))
+	for _, tx := range txs {
+		status := "success"
+		if !tx.Success {
+			status = "failed"
+		}
+		timestamp := time.Unix(int64(tx.Now), 0).UTC()
+		history = append(history, UserTransaction{
+			Id:          tx.Hash,
+			Source:      "proxy-wallet",
+			Kind:        "proxy-wallet",
+			Status:      status,
+			ChainId:     chain.ID,
+			Contract:    proxyWalletAddress,
+			ContractUrl: chain.AddressUrl(proxyWalletAddress),
+			ValueNano:   tx.ValueNano,
+			TxHash:      tx.Hash,
+			TxUrl:       chain.TxUrl(tx.Hash),
+			BlockNumber: strconv.FormatUint(tx.LT, 10),
+			Time:        timestamp.Format(time.RFC3339),
+			timestamp:   timestamp,
+		})
+	}
+	return history, nil
+}
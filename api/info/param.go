@@ -1 +1,149 @@
 package infoHandler
+
+import (
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/intent"
+	"github.com/crosscall-labs/crosschain-api/pkg/rpcpool"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ChainInfoRequestParams struct {
+	ChainId         string `query:"chain-id" optional:"true"`
+	Health          string `query:"health" optional:"true" oneof:"true|false"`           // "false" skips the live rpc checks
+	IncludeDisabled string `query:"include-disabled" optional:"true" oneof:"true|false"` // "true" also lists disabled chains
+}
+
+type ChainInfoResponse struct {
+	Source   string           `json:"source"`
+	LoadedAt string           `json:"loaded-at"`
+	Chains   []ChainInfoEntry `json:"chains"`
+}
+
+type ChainInfoEntry struct {
+	ChainId         string           `json:"chain-id"`
+	VM              string           `json:"vm"`
+	Name            string           `json:"name"`
+	Enabled         bool             `json:"enabled"`
+	Domain          uint32           `json:"domain,omitempty"`
+	EscrowTypes     []int            `json:"escrow-types"`
+	EntrypointTypes []int            `json:"entrypoint-types"`
+	Contracts       chains.Contracts `json:"contracts"`
+	Health          *ChainHealth     `json:"health,omitempty"`
+}
+
+type ChainHealth struct {
+	Status    string                   `json:"status"` // ok, down, disabled or unknown
+	Rpc       string                   `json:"rpc,omitempty"`
+	LatencyMs int64                    `json:"latency-ms"`
+	Block     uint64                   `json:"block,omitempty"`
+	Error     string                   `json:"error,omitempty"`
+	Endpoints []rpcpool.EndpointStatus `json:"endpoints,omitempty"`
+}
+
+type UserTransactionsRequestParams struct {
+	EvmSigner *common.Address `query:"evm-signer" optional:"true"`
+	TvmSigner string          `query:"tvm-signer" optional:"true"`               // proxy wallet history needs both signers
+	Page      int             `query:"page" optional:"true" validate:"positive"` // starts at 1
+	PageSize  int             `query:"page-size" optional:"true" validate:"positive"`
+	Cursor    string          `query:"cursor" optional:"true"` // cursor of a previous response, reads older evm events only
+}
+
+type UserTransactionsResponse struct {
+	EvmSigner    string            `json:"evm-signer,omitempty"`
+	TvmSigner    string            `json:"tvm-signer,omitempty"`
+	Page         int               `json:"page"`
+	PageSize     int               `json:"page-size"`
+	Total        int               `json:"total"`
+	Transactions []UserTransaction `json:"transactions"`
+	Scanned      []HistoryScan     `json:"scanned,omitempty"` // evm block ranges read, older events need the cursor
+	Cursor       string            `json:"cursor,omitempty"`  // empty once every evm source was read back to genesis
+	Errors       []string          `json:"errors,omitempty"`  // sources that could not be read, the history may be incomplete
+}
+
+// HistoryScan is the block range of an evm history source read for the response
+type HistoryScan struct {
+	ChainId   string `json:"chain-id"`
+	Source    string `json:"source"` // userop or escrow
+	FromBlock string `json:"from-block"`
+	ToBlock   string `json:"to-block"`
+}
+
+// UserTransaction is a recorded intent or an on-chain event of the user
+type UserTransaction struct {
+	Id            string              `json:"id"`     // userOpHash, tvm message hash or tx hash
+	Source        string              `json:"source"` // intent, userop, escrow or proxy-wallet
+	Kind          string              `json:"kind"`   // intent vm, userop, the escrow method or proxy-wallet
+	Status        string              `json:"status"` // intent state, success or failed
+	ChainId       string              `json:"chain-id"`
+	OriginId      string              `json:"origin-id,omitempty"`
+	DestinationId string              `json:"destination-id,omitempty"`
+	Contract      string              `json:"contract,omitempty"`
+	ContractUrl   string              `json:"contract-url,omitempty"`
+	AssetAddress  string              `json:"asset-address,omitempty"`
+	AssetAmount   string              `json:"asset-amount,omitempty"`   // requested amount of an intent
+	EscrowBalance string              `json:"escrow-balance,omitempty"` // escrow balance of the asset after the event
+	GasCost       string              `json:"gas-cost,omitempty"`       // actual gas cost of an op in wei
+	ValueNano     string              `json:"value,omitempty"`          // value received by the proxy wallet
+	TxHash        string              `json:"tx-hash,omitempty"`
+	TxUrl         string              `json:"tx-url,omitempty"`
+	PayoutTx      string              `json:"payout-tx,omitempty"`
+	PayoutUrl     string              `json:"payout-url,omitempty"`
+	BlockNumber   string              `json:"block-number,omitempty"`
+	Error         string              `json:"error,omitempty"`
+	Time          string              `json:"time"`
+	History       []intent.Transition `json:"history,omitempty"`
+	timestamp     time.Time
+}
+
+type UserInfoRequestParams struct {
+	EvmSigner *common.Address `query:"evm-signer" optional:"true"`
+	TvmSigner string          `query:"tvm-signer" optional:"true"` // proxy wallets need both signers
+	Assets    string          `query:"assets" optional:"true"`     // comma separated evm escrow assets, native by default
+	Jettons   string          `query:"jettons" optional:"true"`    // comma separated jetton masters
+}
+
+type UserInfoResponse struct {
+	EvmSigner string          `json:"evm-signer,omitempty"`
+	TvmSigner string          `json:"tvm-signer,omitempty"`
+	Chains    []UserChainInfo `json:"chains"`
+}
+
+type UserChainInfo struct {
+	ChainId     string              `json:"chain-id"`
+	VM          string              `json:"vm"`
+	Name        string              `json:"name"`
+	Account     *UserAccountInfo    `json:"account,omitempty"`
+	Escrow      *UserEscrowInfo     `json:"escrow,omitempty"`
+	ProxyWallet *UserAccountInfo    `json:"proxy-wallet,omitempty"`
+	Jettons     []UserJettonBalance `json:"jettons,omitempty"`
+	Error       string              `json:"error,omitempty"`
+}
+
+type UserAccountInfo struct {
+	Address    string `json:"address"`
+	AddressUrl string `json:"address-url,omitempty"`
+	Init       bool   `json:"init"`
+}
+
+type UserEscrowInfo struct {
+	Address    string            `json:"address"`
+	AddressUrl string            `json:"address-url,omitempty"`
+	Init       bool              `json:"init"`
+	Assets     []UserEscrowAsset `json:"assets"`
+}
+
+type UserEscrowAsset struct {
+	Asset        string `json:"asset"`
+	Balance      string `json:"balance"`
+	LockBalance  string `json:"lock-balance"`
+	LockDeadline string `json:"lock-deadline"`
+}
+
+type UserJettonBalance struct {
+	Owner   string `json:"owner"`
+	Jetton  string `json:"jetton"`
+	Wallet  string `json:"wallet"`
+	Balance string `json:"balance"`
+}
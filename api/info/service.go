@@ -3,10 +3,18 @@ package infoHandler
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	evmHandler "github.com/crosscall-labs/crosschain-api/api/evm"
+	svmHandler "github.com/crosscall-labs/crosschain-api/api/svm"
 	tvmHandler "github.com/crosscall-labs/crosschain-api/api/tvm"
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/supabase-community/supabase-go"
+	"github.com/xssnick/tonutils-go/address"
 )
 
 func VersionRequest(r *http.Request, parameters ...interface{}) (interface{}, error) {
@@ -15,12 +23,164 @@ func VersionRequest(r *http.Request, parameters ...interface{}) (interface{}, er
 	}, nil
 }
 
-// func ChainInfoRequest(r *http.Request, parameters ...*UnsignedEscrowRequestParams) (interface{}, error) {
-// 	// migrate from sdk api
-// }
+func ChainInfoRequest(r *http.Request, parameters ...*ChainInfoRequestParams) (interface{}, error) {
+	var params *ChainInfoRequestParams
 
-func UserTransactionsRequest(r *http.Request, parameters ...interface{}) (interface{}, error) {
-	return nil, nil
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &ChainInfoRequestParams{}
+	}
+
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	registry := chains.Default()
+
+	var chainList []*chains.Chain
+	if params.ChainId != "" {
+		chain, found := registry.Lookup(params.ChainId)
+		if !found {
+			return nil, utils.Err(apierr.UnsupportedChain, fmt.Sprintf("unsupported chain ID: %s", params.ChainId))
+		}
+		chainList = append(chainList, chain)
+	} else {
+		for _, chain := range registry.Chains() {
+			if chain.Enabled || params.IncludeDisabled == "true" {
+				chainList = append(chainList, chain)
+			}
+		}
+	}
+
+	var health []*ChainHealth
+	if params.Health != "false" {
+		health = checkChainsHealth(chainList)
+	}
+
+	response := ChainInfoResponse{
+		Source:   registry.Source(),
+		LoadedAt: registry.LoadedAt().UTC().Format(time.RFC3339),
+		Chains:   make([]ChainInfoEntry, 0, len(chainList)),
+	}
+	for i, chain := range chainList {
+		entry := ChainInfoEntry{
+			ChainId:         chain.ID,
+			VM:              chain.VM,
+			Name:            chain.Name,
+			Enabled:         chain.Enabled,
+			Domain:          chain.Domain,
+			EscrowTypes:     chain.EscrowTypes,
+			EntrypointTypes: chain.EntrypointTypes,
+			Contracts:       chain.Contracts,
+		}
+		if health != nil {
+			entry.Health = health[i]
+		}
+		response.Chains = append(response.Chains, entry)
+	}
+
+	return response, nil
+}
+
+// ChainReloadRequest reloads the chain registry without a restart, callers must be authorized by the handler
+func ChainReloadRequest(r *http.Request, parameters ...interface{}) (interface{}, error) {
+	if _, err := chains.Reload(); err != nil {
+		return nil, utils.ErrInternal(fmt.Sprintf("chain config reload failed: %v", err))
+	}
+	return ChainInfoRequest(nil, &ChainInfoRequestParams{Health: "false", IncludeDisabled: "true"})
+}
+
+const (
+	defaultUserTransactionsPageSize = 20
+	maxUserTransactionsPageSize     = 100
+	// intents read per signer before the history is merged and paginated
+	userTransactionsIntentLimit = 500
+)
+
+// UserTransactionsRequest returns a paginated history of a signer across all enabled chains, newest first.
+// Recorded intents are merged with the UserOperationEvents of the signer's SimpleAccounts, the balance
+// changes of its escrows and the transactions of its TON proxy wallet.
+func UserTransactionsRequest(r *http.Request, supabaseClient *supabase.Client, parameters ...*UserTransactionsRequestParams) (interface{}, error) {
+	var params *UserTransactionsRequestParams
+
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &UserTransactionsRequestParams{}
+	}
+
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.EvmSigner == nil && params.TvmSigner == "" {
+		return nil, utils.ErrMalformedRequest("evm-signer or tvm-signer is required")
+	}
+	var evmSigner common.Address
+	if params.EvmSigner != nil {
+		evmSigner = *params.EvmSigner
+	}
+	if params.TvmSigner != "" {
+		if _, err := address.ParseAddr(params.TvmSigner); err != nil {
+			return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid tvm-signer: %s", params.TvmSigner))
+		}
+	}
+
+	page, pageSize := params.Page, params.PageSize
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = defaultUserTransactionsPageSize
+	}
+	if pageSize > maxUserTransactionsPageSize {
+		pageSize = maxUserTransactionsPageSize
+	}
+
+	response := UserTransactionsResponse{
+		TvmSigner: params.TvmSigner,
+		Page:      page,
+		PageSize:  pageSize,
+	}
+	signers := []string{}
+	if params.EvmSigner != nil {
+		response.EvmSigner = evmSigner.Hex()
+		signers = append(signers, evmSigner.Hex())
+	}
+	if params.TvmSigner != "" {
+		signers = append(signers, params.TvmSigner)
+	}
+
+	var err error
+	var cursor historyCursor
+	if params.Cursor != "" {
+		if cursor, err = parseHistoryCursor(params.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	history, scans, next, errs := collectUserHistory(supabaseClient, signers, evmSigner, params.EvmSigner != nil, params.TvmSigner, cursor)
+	response.Errors = errs
+	response.Scanned = scans
+	response.Cursor = next.String()
+	response.Total = len(history)
+
+	start := (page - 1) * pageSize
+	if start > len(history) {
+		start = len(history)
+	}
+	end := start + pageSize
+	if end > len(history) {
+		end = len(history)
+	}
+	response.Transactions = history[start:end]
+
+	return response, nil
 }
 
 func AssetInfoRequest(r *http.Request, parameters ...*utils.AssetInfoRequestParams) (interface{}, error) {
@@ -49,29 +209,81 @@ func AssetInfoRequest(r *http.Request, parameters ...*utils.AssetInfoRequestPara
 		return evmHandler.AssetInfoRequest(r, params)
 	case "tvm":
 		return tvmHandler.AssetInfoRequest(r, params)
-	// case "svm":
-	// 	// return svmHandler.AssetInfoRequestSvm(params)
+	case "svm":
+		return svmHandler.AssetInfoRequest(r, params)
 	default:
 		return nil, utils.ErrInternal(fmt.Errorf("Virtual machine %v is unsupported", params.VM).Error())
 	}
 }
 
-func UserInfoRequest(r *http.Request, parameters ...*interface{}) (interface{}, error) {
-	// var params *UnsignedEscrowRequestParams
+// UserInfoRequest returns the accounts, escrows, proxy wallets and balances of a user on every enabled chain,
+// chains are read concurrently and a failing chain only reports its error
+func UserInfoRequest(r *http.Request, parameters ...*UserInfoRequestParams) (interface{}, error) {
+	var params *UserInfoRequestParams
 
-	// if len(parameters) > 0 {
-	// 	params = parameters[0]
-	// } else {
-	// 	params = &UnsignedEscrowRequestParams{}
-	// }
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &UserInfoRequestParams{}
+	}
 
-	// if r != nil {
-	// 	if err := utils.ParseAndValidateParams(r, &params); err != nil {
-	// 		return nil, err
-	// 	}
-	// }
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
 
-	return nil, nil
+	if params.EvmSigner == nil && params.TvmSigner == "" {
+		return nil, utils.ErrMalformedRequest("evm-signer or tvm-signer is required")
+	}
+	input := userInfoInput{tvmSigner: params.TvmSigner}
+	if params.EvmSigner != nil {
+		input.evmSigner = *params.EvmSigner
+		input.hasEvmSigner = true
+	}
+	if params.TvmSigner != "" {
+		if _, err := address.ParseAddr(params.TvmSigner); err != nil {
+			return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid tvm-signer: %s", params.TvmSigner))
+		}
+	}
+
+	// native asset by default
+	input.assets = []common.Address{{}}
+	if params.Assets != "" {
+		input.assets = nil
+		for _, asset := range strings.Split(params.Assets, ",") {
+			asset = strings.TrimSpace(asset)
+			if !common.IsHexAddress(asset) {
+				return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid asset: %s", asset))
+			}
+			input.assets = append(input.assets, common.HexToAddress(asset))
+		}
+	}
+	if params.Jettons != "" {
+		for _, jetton := range strings.Split(params.Jettons, ",") {
+			jetton = strings.TrimSpace(jetton)
+			if _, err := address.ParseAddr(jetton); err != nil {
+				return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid jetton: %s", jetton))
+			}
+			input.jettons = append(input.jettons, jetton)
+		}
+	}
+
+	var chainList []*chains.Chain
+	for _, chain := range chains.Default().Chains() {
+		if chain.Enabled {
+			chainList = append(chainList, chain)
+		}
+	}
+
+	response := UserInfoResponse{
+		TvmSigner: params.TvmSigner,
+		Chains:    collectUserInfo(chainList, input),
+	}
+	if input.hasEvmSigner {
+		response.EvmSigner = input.evmSigner.Hex()
+	}
+	return response, nil
 }
 
 func UnsignedEscrowRequest(r *http.Request, parameters ...*interface{}) (interface{}, error) {
@@ -0,0 +1,135 @@
+package infoHandler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	evmHandler "github.com/crosscall-labs/crosschain-api/api/evm"
+	tvmHandler "github.com/crosscall-labs/crosschain-api/api/tvm"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/rpcpool"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const userInfoTimeout = 20 * time.Second
+
+// userInfoInput is the validated user-info request shared by every chain
+type userInfoInput struct {
+	evmSigner    common.Address
+	hasEvmSigner bool
+	tvmSigner    string
+	assets       []common.Address
+	jettons      []string
+}
+
+// collectUserInfo reads every chain concurrently, results keep the input order
+func collectUserInfo(chainList []*chains.Chain, input userInfoInput) []UserChainInfo {
+	results := make([]UserChainInfo, len(chainList))
+
+	ctx, cancel := context.WithTimeout(context.Background(), userInfoTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i, chain := range chainList {
+		wg.Add(1)
+		go func(i int, chain *chains.Chain) {
+			defer wg.Done()
+			info := UserChainInfo{ChainId: chain.ID, VM: chain.VM, Name: chain.Name}
+			var err error
+			switch chain.VM {
+			case "evm":
+				err = evmUserInfo(chain, input, &info)
+			case "tvm":
+				err = tvmUserInfo(ctx, chain, input, &info)
+			default:
+				err = fmt.Errorf("%s type chains are not yet supported", chain.VM)
+			}
+			if err != nil {
+				info.Error = err.Error()
+			}
+			results[i] = info
+		}(i, chain)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func evmUserInfo(chain *chains.Chain, input userInfoInput, info *UserChainInfo) error {
+	if !input.hasEvmSigner {
+		return nil
+	}
+	client, err := rpcpool.EvmClient(chain.ID)
+	if err != nil {
+		return err
+	}
+
+	state, err := evmHandler.GetUserChainState(client, chain, input.evmSigner, escrowSalt[:], input.assets)
+	if state.Account != (common.Address{}) {
+		info.Account = &UserAccountInfo{
+			Address:    state.Account.Hex(),
+			AddressUrl: chain.AddressUrl(state.Account.Hex()),
+			Init:       state.AccountDeployed,
+		}
+	}
+	if state.Escrow != (common.Address{}) {
+		info.Escrow = &UserEscrowInfo{
+			Address:    state.Escrow.Hex(),
+			AddressUrl: chain.AddressUrl(state.Escrow.Hex()),
+			Init:       state.EscrowDeployed,
+			Assets:     make([]UserEscrowAsset, 0, len(state.Assets)),
+		}
+		for _, asset := range state.Assets {
+			info.Escrow.Assets = append(info.Escrow.Assets, UserEscrowAsset{
+				Asset:        asset.Asset.Hex(),
+				Balance:      asset.Balance.String(),
+				LockBalance:  asset.Locked.String(),
+				LockDeadline: asset.Deadline.String(),
+			})
+		}
+	}
+	return err
+}
+
+func tvmUserInfo(ctx context.Context, chain *chains.Chain, input userInfoInput, info *UserChainInfo) error {
+	var owners []string
+	if input.tvmSigner != "" {
+		owners = append(owners, input.tvmSigner)
+	}
+
+	if input.hasEvmSigner && input.tvmSigner != "" {
+		proxyWalletAddress, init, err := tvmHandler.ProxyWalletState(ctx, input.evmSigner, input.tvmSigner)
+		if err != nil {
+			return err
+		}
+		info.ProxyWallet = &UserAccountInfo{
+			Address:    proxyWalletAddress,
+			AddressUrl: chain.AddressUrl(proxyWalletAddress),
+			Init:       init,
+		}
+		if init {
+			owners = append(owners, proxyWalletAddress)
+		}
+	}
+
+	if len(input.jettons) == 0 {
+		return nil
+	}
+	for _, owner := range owners {
+		balances, err := tvmHandler.JettonBalances(ctx, owner, input.jettons)
+		if err != nil {
+			return err
+		}
+		for _, balance := range balances {
+			info.Jettons = append(info.Jettons, UserJettonBalance{
+				Owner:   balance.Owner,
+				Jetton:  balance.Jetton,
+				Wallet:  balance.Wallet,
+				Balance: balance.Balance,
+			})
+		}
+	}
+	return nil
+}
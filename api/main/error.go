@@ -1,46 +1,35 @@
 package handler
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"math/big"
 
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 )
 
-func errUnsupportedChain(chainId string) string {
-	return fmt.Sprintf("Chain ID %s not currently supported", chainId)
+func errUnsupportedChain(chainId string) error {
+	return utils.Err(apierr.UnsupportedChain, fmt.Sprintf("Chain ID %s not currently supported", chainId))
 }
 
-func errPaymasterAndDataMismatch(w http.ResponseWriter) {
-	w.WriteHeader(http.StatusBadRequest)
-	json.NewEncoder(w).Encode(&utils.Error{
-		Code:    7,
-		Message: "PaymasterAndData mismatch",
-	})
+func errPaymasterAndDataMismatch() error {
+	return utils.Err(apierr.InvalidParam, "PaymasterAndData mismatch")
 }
 
-func errRpcFailed(w http.ResponseWriter) {
-	json.NewEncoder(w).Encode(&utils.Error{
-		Code:    501,
-		Message: "Internal server error: RPC connection failed",
-	})
+func errRpcFailed(err error) error {
+	return utils.Err(apierr.RpcFailure, err.Error())
 }
 
-func errEscrowNotFound(w http.ResponseWriter) {
-	w.WriteHeader(http.StatusBadRequest)
-	json.NewEncoder(w).Encode(&utils.Error{
-		Code:    1000,
-		Message: "Escrow address not exist",
-	})
+func errEscrowNotFound(escrow string) error {
+	return utils.Err(apierr.EscrowNotFound, fmt.Sprintf("escrow %s is not deployed", escrow))
 }
 
-func errInsufficientEscrowBalance(w http.ResponseWriter) {
-	w.WriteHeader(http.StatusBadRequest)
-	json.NewEncoder(w).Encode(&utils.Error{
-		Code:    1001,
-		Message: "Insufficient escrow balance",
-	})
+func errInsufficientEscrowBalance(locked *big.Int, required *big.Int) error {
+	return utils.Err(apierr.InsufficientEscrow, fmt.Sprintf("locked %s, required %s", locked, required))
+}
+
+func errEscrowLockExpiring(deadline *big.Int) error {
+	return utils.Err(apierr.EscrowLockExpiring, fmt.Sprintf("escrow lock deadline %s", deadline))
 }
 
 func GetOrigin() string {
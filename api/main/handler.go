@@ -2,7 +2,6 @@ package handler
 
 import (
 	"crypto/ecdsa"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -42,7 +41,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 				log.Printf("\nFailed to create Supabase client for panic logging: %v", err)
 			}
 
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			utils.WriteError(w, utils.ErrInternal("recovered from panic"))
 		}
 	}()
 
@@ -52,7 +51,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	supabaseKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
 	supabaseClient, err := supabase.NewClient(supabaseUrl, supabaseKey, nil)
 	if err != nil {
-		http.Error(w, "Failed to create Supabase client", http.StatusInternalServerError)
+		utils.WriteError(w, utils.ErrInternal("failed to create supabase client"))
 		return
 	}
 
@@ -65,7 +64,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	switch r.URL.Query().Get("query") {
 	case "unsigned-message":
-		response, err = UnsignedRequest(r)
+		response, err = UnsignedRequest(r, supabaseClient)
 		HandleResponse(w, r, supabaseClient, response, err)
 		return
 	case "unsigned-bytecode":
@@ -73,17 +72,20 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		HandleResponse(w, r, supabaseClient, response, err)
 		return
 	case "signed-bytecode":
-		response, err = SignedBytecode(r)
+		response, err = SignedBytecode(r, supabaseClient)
 		HandleResponse(w, r, supabaseClient, response, err)
 		return
 	case "signed-escrow-payout":
 		// will add env restriction on origin later
-		response, err = SignedEscrowPayout(r)
+		response, err = SignedEscrowPayout(r, supabaseClient)
+		HandleResponse(w, r, supabaseClient, response, err)
+		return
+	case "unsigned-escrow-payout":
+		response, err = UnsignedEscrowPayout(r)
 		HandleResponse(w, r, supabaseClient, response, err)
 		return
 	default:
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(utils.ErrMalformedRequest("Invalid query parameter"))
+		utils.WriteError(w, utils.ErrMalformedRequest("Invalid query parameter"))
 		return
 	}
 }
@@ -93,14 +95,6 @@ func HandleResponse(w http.ResponseWriter, r *http.Request, supabaseClient *supa
 		// if logErr := db.LogError(supabaseClient, err, r.URL.Query().Get("query"), response); logErr != nil {
 		// 	fmt.Printf("Failed to log error: %v\n", logErr.Error())
 		// }
-
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(err)
-		return
-	}
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 	}
+	utils.WriteResponse(w, response, err)
 }
@@ -139,6 +139,27 @@ type PaymasterAndData struct {
 // 	UserOpHash    string                      `json:"userop-hash"`
 // }
 
+type SignedBytecodeResponse struct {
+	UserOpHash  string `json:"op-hash"`
+	TxHash      string `json:"tx-hash"`
+	Status      string `json:"status"` // pending, success, op-failed or reverted
+	BlockNumber string `json:"block-number,omitempty"`
+	GasUsed     string `json:"gas-used,omitempty"`
+}
+
+type EscrowPayoutResponse struct {
+	TraceId     string `json:"trace-id"`
+	UserOpHash  string `json:"op-hash"`
+	Status      string `json:"status"` // preview, pending, dispatched, gas-unpaid or failed
+	Escrow      string `json:"escrow"`
+	Message     string `json:"message"`
+	MessageHash string `json:"message-hash"`
+	DispatchTx  string `json:"dispatch-tx,omitempty"`
+	MessageId   string `json:"message-id,omitempty"`
+	GasTx       string `json:"gas-tx,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
 type UnsignedDataResponse2 struct {
 	Header           utils.MessageHeader         `json:"header"` // first  ttoChain (entrypoint) then fromChain (escrow)
 	ScwInit          bool                        `json:"swc-init"`
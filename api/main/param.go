@@ -1,12 +1,18 @@
 package handler
 
-import "github.com/crosscall-labs/crosschain-api/pkg/utils"
+import (
+	"math/big"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type UnsignedRequestParams struct {
-	Header  utils.MessageHeader `query:"header"`
-	Target  string              `query:"target" optional:"true"`
-	Value   string              `query:"value" optional:"true"`
-	Payload string              `query:"payload" optional:"true"`
+	Header      utils.MessageHeader `query:"header"`
+	Target      string              `query:"target" optional:"true"`
+	Value       string              `query:"value" optional:"true"`
+	Payload     string              `query:"payload" optional:"true"`
+	EscrowValue *big.Int            `query:"escrow-value" optional:"true" validate:"uint"` // nano deposited and locked by a tvm origin
 	//Extra   string              `query:"extra" options:"true"` // stores extra data for tvm
 }
 
@@ -21,25 +27,32 @@ type UnsignedBytecodeParams struct {
 }
 
 type SignedBytecodeParams struct {
-	Signer                   string `query:"signer"`
-	DestinationId            string `query:"destination-id"`
-	OriginId                 string `query:"origin-id"`
-	AssetAddress             string `query:"asset-address"`
-	AssetAmount              string `query:"asset-amount"`
-	UseropSender             string `query:"useropSender"`
-	UseropNonce              string `query:"useropNonce"`
-	UseropInitCode           string `query:"useropInitCode" optional:"true"`
-	UseropCallData           string `query:"useropCallData"`
-	UseropAccountGasLimit    string `query:"useropAccountGasLimit"`
-	UseropPreVerificationGas string `query:"useropPreVerificationGas"`
-	UseropGasFees            string `query:"useropGasFees"`
-	UseropPaymasterAndData   string `query:"useropPaymasterAndData"`
-	UseropSignature          string `query:"useropSignature"`
+	Signer                   common.Address `query:"signer"`
+	DestinationId            string         `query:"destination-id"`
+	OriginId                 string         `query:"origin-id"`
+	AssetAddress             common.Address `query:"asset-address"`
+	AssetAmount              *big.Int       `query:"asset-amount" validate:"positive,uint=256"`
+	UseropSender             string         `query:"useropSender"`
+	UseropNonce              string         `query:"useropNonce"`
+	UseropInitCode           string         `query:"useropInitCode" optional:"true"`
+	UseropCallData           string         `query:"useropCallData"`
+	UseropAccountGasLimit    string         `query:"useropAccountGasLimit"`
+	UseropPreVerificationGas string         `query:"useropPreVerificationGas"`
+	UseropGasFees            string         `query:"useropGasFees"`
+	UseropPaymasterAndData   string         `query:"useropPaymasterAndData"`
+	UseropSignature          string         `query:"useropSignature"`
 }
 
 type SignedEscrowPayoutParams struct {
-	Bytecode string `query:"data"`
-	TraceId  string `query:"traceid"`
+	Bytecode      string `query:"data"`           // paymasterAndDataType1
+	TraceId       string `query:"traceid"`        // destination handleOps tx hash
+	DestinationId string `query:"destination-id"` // chain the user operation executed on
+}
+
+type UnsignedEscrowPayoutParams struct {
+	Bytecode      string `query:"data"`
+	TraceId       string `query:"traceid"`
+	DestinationId string `query:"destination-id"`
 }
 
 type UnsignedBytecodeResponse struct {
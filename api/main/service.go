@@ -6,28 +6,39 @@ import (
 	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	evmHandler "github.com/crosscall-labs/crosschain-api/api/evm"
+	svmHandler "github.com/crosscall-labs/crosschain-api/api/svm"
 	tvmHandler "github.com/crosscall-labs/crosschain-api/api/tvm"
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/db"
+	"github.com/crosscall-labs/crosschain-api/pkg/events"
+	"github.com/crosscall-labs/crosschain-api/pkg/intent"
+	"github.com/crosscall-labs/crosschain-api/pkg/rpcpool"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/supabase-community/supabase-go"
 	"golang.org/x/crypto/sha3"
 )
 
-func UnsignedRequest(r *http.Request) (interface{}, error) {
+func UnsignedRequest(r *http.Request, supabaseClient *supabase.Client) (interface{}, error) {
 	params := &UnsignedRequestParams{}
 
 	if err := utils.ParseAndValidateParams(r, params); err != nil {
@@ -65,6 +76,8 @@ func UnsignedRequest(r *http.Request) (interface{}, error) {
 		fmt.Print("I got this far2\n")
 		response, err := evmHandler.UnsignedEntryPointRequest(nil, &evmHandler.UnsignedEntryPointRequestParams{
 			Header:  params.Header,
+			Target:  params.Target,
+			Value:   params.Value,
 			Payload: params.Payload,
 		})
 		if err != nil {
@@ -72,6 +85,14 @@ func UnsignedRequest(r *http.Request) (interface{}, error) {
 		}
 		unsignedDataResponse.ToMessage = response.(MessageResponse)
 		// utils.PrintStructFields(response)
+		if op, ok := response.(evmHandler.MessageOpEvm); ok {
+			quoted := intent.Intent{Id: op.UserOpHash}
+			if op.PaymasterAndData.AssetAmount != "" && op.PaymasterAndData.AssetAmount != "0" {
+				quoted.AssetAddress = op.PaymasterAndData.AssetAddress
+				quoted.AssetAmount = op.PaymasterAndData.AssetAmount
+			}
+			createIntent(supabaseClient, quoted, params.Header)
+		}
 	case "tvm":
 		response, err := tvmHandler.UnsignedEntryPointRequest(nil, &tvmHandler.UnsignedEntryPointRequestParams{
 			Header: params.Header,
@@ -119,6 +140,9 @@ func UnsignedRequest(r *http.Request) (interface{}, error) {
 			return nil, utils.ErrInternal(err.Error())
 		}
 		unsignedDataResponse.ToMessage = response.(MessageResponse)
+		if message, ok := response.(tvmHandler.MessageOpTvm); ok {
+			createIntent(supabaseClient, intent.Intent{Id: message.MessageHash}, params.Header)
+		}
 		//value := unsignedDataResponse.ToMessage.GetType()
 		/*
 			type ProxyParams struct {
@@ -188,6 +212,18 @@ func UnsignedRequest(r *http.Request) (interface{}, error) {
 		unsignedDataResponse.FromMessage = response.(MessageResponse)
 		// utils.PrintStructFields(response)
 	case "tvm":
+		// the escrow lock commits to the destination op, so the to message is built first
+		response, err := tvmHandler.UnsignedEscrowLock(utils.PartialHeader{
+			TxType:      params.Header.TxType,
+			ChainName:   params.Header.FromChainName,
+			ChainType:   params.Header.FromChainType,
+			ChainId:     params.Header.FromChainId,
+			ChainSigner: params.Header.FromChainSigner,
+		}, unsignedDataResponse.ToMessage, params.EscrowValue)
+		if err != nil {
+			return nil, err
+		}
+		unsignedDataResponse.FromMessage = response.(MessageResponse)
 	case "svm":
 	default:
 		return nil, utils.ErrInternal(fmt.Sprintf("%s type chains are not yet supported", params.Header.FromChainType))
@@ -560,307 +596,383 @@ func UnsignedBytecode(r *http.Request) (interface{}, error) {
 	return nil, nil
 }
 
-func SignedBytecode(r *http.Request) (interface{}, error) {
-	privateKey, relayAddress, err := utils.EnvKey2Ecdsa()
-	fmt.Print(privateKey, relayAddress)
-	if err != nil {
-		return nil, utils.ErrInternal(err.Error())
-	}
-	params := &SignedBytecodeParams{}
+// escrow salt used by the escrow requests, see SALT in the handler
+var escrowSalt = common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000037")
 
-	if err := utils.ParseAndValidateParams(r, &params); err != nil {
-		return nil, err
+// the escrow must stay locked long enough for the payout to be relayed back, one hour for the MVP
+const escrowLockMinRemaining = time.Hour
+
+// createIntent records a quoted request, failing to record never fails the request
+func createIntent(supabaseClient *supabase.Client, quoted intent.Intent, header utils.MessageHeader) {
+	if supabaseClient == nil || quoted.Id == "" {
+		return
 	}
-	/*
-		// client, chainInfo, ok := checkClient(w, params.OriginId)
-		// if !ok {
-		// 	return
-		// }
+	quoted.VM = header.ToChainType
+	quoted.OriginId = header.FromChainId
+	quoted.DestinationId = header.ToChainId
+	quoted.OriginSigner = header.FromChainSigner
+	quoted.DestinationSigner = header.ToChainSigner
+	if _, err := intent.Create(supabaseClient, quoted); err != nil {
+		log.Printf("\nFailed to create intent %s: %v", quoted.Id, err)
+	}
+	expireStaleIntents(supabaseClient)
+}
 
-		// client2, chainInfo2, ok := checkClient(w, params.TargetId)
-		// if !ok {
-		// 	return
-		// }
+// stale quotes are expired while new ones are written, at most once per interval on an instance
+const expireStaleInterval = time.Minute
 
-		//var packedUserOperation PackedUserOperation
+var lastExpireStale atomic.Int64
 
-		// // need to fetch sender using signer
-		// packedUserOperation = PackedUserOperation{
-		// 	Sender:             common.HexToAddress(useropSender),
-		// 	Nonce:              packedUserOperation.Nonce,
-		// 	InitCode:           common.FromHex(useropInitCode),
-		// 	CallData:           common.FromHex(useropCallData),
-		// 	AccountGasLimits:   [32]byte(common.FromHex(useropAccountGasLimit)),
-		// 	PreVerificationGas: packedUserOperation.PreVerificationGas,
-		// 	GasFees:            [32]byte(common.FromHex(useropGasFees)),
-		// 	PaymasterAndData:   common.FromHex(useropPaymasterAndData),
-		// 	Signature:          common.FromHex(useropSignature),
-		// }
+func expireStaleIntents(supabaseClient *supabase.Client) {
+	now := time.Now().UnixNano()
+	last := lastExpireStale.Load()
+	if now-last < int64(expireStaleInterval) || !lastExpireStale.CompareAndSwap(last, now) {
+		return
+	}
+	if _, err := intent.ExpireStale(supabaseClient); err != nil {
+		log.Printf("\nFailed to expire stale intents: %v", err)
+	}
+}
 
-		// var someint int64
-		// // parse nonce to proper format
-		// someint, err = strconv.ParseInt(useropNonce, 10, 64)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
-		// packedUserOperation.Nonce = big.NewInt(someint)
-		// // parse perverificationgas to proper format
-		// someint, err = strconv.ParseInt(useropPreVerificationGas, 10, 64)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
-		// packedUserOperation.PreVerificationGas = big.NewInt(someint)
+// advanceIntent records a lifecycle step of a request, failing to record never fails the request
+func advanceIntent(supabaseClient *supabase.Client, id string, to intent.State, detail string, update func(*intent.Intent)) {
+	if supabaseClient == nil {
+		return
+	}
+	advanced, err := intent.Advance(supabaseClient, id, to, detail, update)
+	if err != nil {
+		log.Printf("\nFailed to move intent %s to %s: %v", id, to, err)
+		return
+	}
+	publishIntent(advanced, detail)
+}
 
-		// // evaluate is paymaster matches expected cost
-		// var paymasterAndData []byte
-		// paymasterPrefix := append(common.FromHex(chainInfo2.AddressPaymaster), common.FromHex("0x0000000000000000000000000098968000000000000000000000000000989680")...)
-		// paymasterSigner := common.FromHex(signer)
-		// someint, err = strconv.ParseInt(originId, 10, 64)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
-		// paymasterOrigin := padLeftHex(int(someint))
-		// paymasterAsset := common.FromHex(assetAddress)
-		// someint, err = strconv.ParseInt(assetAmount, 10, 64)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
-		// paymasterAmount := padLeftHex(int(someint))
-		// paymasterAndData = bytes.Join([][]byte{
-		// 	paymasterPrefix,
-		// 	paymasterSigner,
-		// 	paymasterOrigin,
-		// 	paymasterAsset,
-		// 	paymasterAmount,
-		// }, nil)
-		// if !bytes.Equal(packedUserOperation.PaymasterAndData, paymasterAndData) {
-		// 	fmt.Printf("packedUserOperation.PaymasterAndData: %s", common.Bytes2Hex(packedUserOperation.PaymasterAndData))
-		// 	fmt.Printf("paymasterAndData: %s", common.Bytes2Hex(paymasterAndData))
-		// 	errPaymasterAndDataMismatch(w)
-		// 	return
-		// }
+// publishIntent sends the lifecycle event of the state the intent was moved to, to the
+// subscribers of its id and signers
+func publishIntent(i intent.Intent, detail string) {
+	event := events.Event{
+		Keys:   []string{i.Id, i.OriginSigner, i.DestinationSigner},
+		VM:     i.VM,
+		TxHash: i.TxHash,
+	}
+	switch i.State {
+	case intent.EscrowLocked:
+		event.Kind = events.EscrowLocked
+	case intent.Executed:
+		event.Kind = events.DestinationExecuted
+	case intent.PaidOut:
+		event.Kind = events.PayoutSent
+		event.TxHash = i.PayoutTx
+	case intent.Failed, intent.Expired:
+		event.Kind = events.Failed
+		event.Reason = detail
+		if event.Reason == "" {
+			event.Reason = string(i.State)
+		}
+	default:
+		return
+	}
+	events.Publish(event)
+}
 
-		// initializerBytes, err := GetViewCallBytes(*client, parsedABIs["Escrow"], "initialize", common.HexToAddress(signer), common.HexToAddress(chainInfo.AddressEscrow))
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
+// signIntent moves the quoted intent of a signed op to signed, ops that were not quoted
+// through this api are recorded when they are first seen. Returns an error only when
+// the quote has expired.
+func signIntent(supabaseClient *supabase.Client, signed intent.Intent) error {
+	if supabaseClient == nil {
+		return nil
+	}
+	current, err := intent.Get(supabaseClient, signed.Id)
+	if errors.Is(err, intent.ErrNotFound) {
+		if current, err = intent.Create(supabaseClient, signed); err != nil {
+			log.Printf("\nFailed to create intent %s: %v", signed.Id, err)
+			return nil
+		}
+	} else if err != nil {
+		log.Printf("\nFailed to get intent %s: %v", signed.Id, err)
+		return nil
+	}
 
-		// calls := []struct {
-		// 	contractName    string
-		// 	contractAddress string
-		// 	method          string
-		// 	params          []interface{}
-		// }{
-		// 	{
-		// 		contractName: "EscrowFactory",
-		// 		method:       "getEscrowAddress",
-		// 		params:       []interface{}{initializerBytes, SALT},
-		// 	},
-		// }
+	if current.State == intent.Quoted && current.IsExpired(time.Now().UTC()) {
+		advanceIntent(supabaseClient, signed.Id, intent.Expired, "quote expired", nil)
+		return utils.Err(apierr.QuoteExpired, fmt.Sprintf("quote %s expired, request a new one", signed.Id))
+	}
+	if current.State == intent.Quoted {
+		advanceIntent(supabaseClient, signed.Id, intent.Signed, "", nil)
+	}
+	return nil
+}
 
-		// results, err := getMulticallViewResults(client, parsedABIs, chainInfo, calls)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
+func SignedBytecode(r *http.Request, supabaseClient *supabase.Client) (interface{}, error) {
+	privateKey, relayAddress, err := utils.EnvKey2Ecdsa()
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	params := &SignedBytecodeParams{}
 
-		// if !results[0].Success {
-		// 	fmt.Printf("Escrow: getEscrowAddress failed for chain chain %s\n", chainInfo.ChainId)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
+	if err := utils.ParseAndValidateParams(r, &params); err != nil {
+		return nil, err
+	}
 
-		// parsedResults, err := parsedABIs["EscrowFactory"].Unpack("getEscrowAddress", results[0].ReturnData)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
+	originChain, err := chains.Get(params.OriginId)
+	if err != nil {
+		return nil, errUnsupportedChain(params.OriginId)
+	}
+	destinationChain, err := chains.Get(params.DestinationId)
+	if err != nil {
+		return nil, errUnsupportedChain(params.DestinationId)
+	}
+	if originChain.VM != "evm" || destinationChain.VM != "evm" {
+		return nil, utils.ErrMalformedRequest("signed bytecode only supports evm origin and destination")
+	}
+	signer := params.Signer
+	assetAddress := params.AssetAddress
+	assetAmount := params.AssetAmount
+
+	// rebuild the op exactly as it was signed
+	packedUserOperation, err := evmHandler.FromPackedUserOperationResponse(evmHandler.PackedUserOperationResponse{
+		Sender:             params.UseropSender,
+		Nonce:              params.UseropNonce,
+		InitCode:           params.UseropInitCode,
+		CallData:           params.UseropCallData,
+		AccountGasLimits:   params.UseropAccountGasLimit,
+		PreVerificationGas: params.UseropPreVerificationGas,
+		GasFees:            params.UseropGasFees,
+		PaymasterAndData:   params.UseropPaymasterAndData,
+		Signature:          params.UseropSignature,
+	})
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(err.Error())
+	}
 
-		// escrowAddress := parsedResults[0].(common.Address)
+	destinationChainId, _ := new(big.Int).SetString(destinationChain.ID, 10)
+	userOpHash, err := evmHandler.GetUserOpHash(packedUserOperation, common.HexToAddress(destinationChain.Contracts.Entrypoint), destinationChainId)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	recovered, err := evmHandler.RecoverUserOpSigner(userOpHash, packedUserOperation.Signature)
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(err.Error())
+	}
+	if recovered != signer {
+		return nil, utils.Err(apierr.SignatureInvalid, fmt.Sprintf("signature signer %s does not match %s", recovered.Hex(), signer.Hex()))
+	}
 
-		// calls = []struct {
-		// 	contractName    string
-		// 	contractAddress string
-		// 	method          string
-		// 	params          []interface{}
-		// }{
-		// 	{
-		// 		contractName: "Multicall",
-		// 		method:       "getExtcodesize",
-		// 		params:       []interface{}{escrowAddress},
-		// 	},
-		// 	// {
-		// 	// 	contractName: "Escrow",
-		// 	// 	contractAddress: escrowAddress.Hex(),
-		// 	// 	method: // no public function for mapping(address => uint256) assetLocked;
-		// 	// }
-		// }
+	// the paymaster is paid out of the signer's escrow on the origin chain
+	paymasterAndData, err := evmHandler.DecodePaymasterAndData(packedUserOperation.PaymasterAndData)
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(err.Error())
+	}
+	if paymasterAndData.Paymaster != common.HexToAddress(destinationChain.Contracts.Paymaster) ||
+		paymasterAndData.Signer != signer ||
+		paymasterAndData.DestinationDomain != evmHandler.DomainBytes(originChain.Domain) ||
+		paymasterAndData.AssetAddress != assetAddress ||
+		paymasterAndData.AssetAmount.Cmp(assetAmount) != 0 {
+		return nil, utils.ErrMalformedRequest("PaymasterAndData mismatch")
+	}
+
+	intentId := userOpHash.Hex()
+	if err := signIntent(supabaseClient, intent.Intent{
+		Id:                intentId,
+		VM:                destinationChain.VM,
+		OriginId:          originChain.ID,
+		DestinationId:     destinationChain.ID,
+		OriginSigner:      signer.Hex(),
+		DestinationSigner: signer.Hex(),
+		AssetAddress:      assetAddress.Hex(),
+		AssetAmount:       assetAmount.String(),
+	}); err != nil {
+		return nil, err
+	}
 
-		// results, err = getMulticallViewResults(client, parsedABIs, chainInfo, calls)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
+	originClient, err := rpcpool.EvmClient(originChain.ID)
+	if err != nil {
+		return nil, errRpcFailed(err)
+	}
+	escrowLock, err := evmHandler.GetEscrowLock(originClient, originChain, signer, assetAddress, escrowSalt[:])
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	if !escrowLock.Deployed {
+		return nil, errEscrowNotFound(escrowLock.EscrowAddress.Hex())
+	}
+	if escrowLock.Locked.Cmp(assetAmount) < 0 {
+		return nil, errInsufficientEscrowBalance(escrowLock.Locked, assetAmount)
+	}
+	minDeadline := time.Now().Add(escrowLockMinRemaining).Unix()
+	if escrowLock.Deadline.Cmp(big.NewInt(minDeadline)) < 0 {
+		return nil, errEscrowLockExpiring(escrowLock.Deadline)
+	}
+	advanceIntent(supabaseClient, intentId, intent.EscrowLocked, escrowLock.EscrowAddress.Hex(), func(i *intent.Intent) {
+		deadline := time.Unix(escrowLock.Deadline.Int64(), 0).UTC()
+		i.ExpiresAt = &deadline
+	})
 
-		// parsedResults, err = parsedABIs["Multicall"].Unpack("getExtcodesize", results[0].ReturnData)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
+	destinationClient, err := rpcpool.EvmClient(destinationChain.ID)
+	if err != nil {
+		return nil, errRpcFailed(err)
+	}
+	ctx := context.Background()
+	accountValidation, paymasterValidation, err := evmHandler.SimulateUserOperation(ctx, destinationClient, destinationChain, packedUserOperation)
+	if err != nil {
+		return nil, evmHandler.SimulationError(fmt.Errorf("simulation failed: %w", err))
+	}
+	if accountValidation.SigFailed || paymasterValidation.SigFailed {
+		return nil, utils.Err(apierr.SignatureInvalid, "simulation failed: signature validation failed")
+	}
+	now := uint64(time.Now().Unix())
+	for _, validation := range []evmHandler.ValidationData{accountValidation, paymasterValidation} {
+		if validation.ValidAfter > now || (validation.ValidUntil != 0 && validation.ValidUntil < now) {
+			return nil, utils.ErrMalformedRequest("simulation failed: user operation outside its validity window")
+		}
+	}
 
-		// extcodesize := parsedResults[0].(*big.Int)
+	result, err := evmHandler.SubmitHandleOps(ctx, destinationClient, destinationChain, packedUserOperation, privateKey, relayAddress)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	utils.LogInfo("handleOps submitted", utils.FormatKeyValueLogs([][2]string{
+		{"chain", destinationChain.ID},
+		{"userop", userOpHash.Hex()},
+		{"tx", result.TxHash.Hex()},
+	}))
+
+	response := SignedBytecodeResponse{
+		UserOpHash: userOpHash.Hex(),
+		TxHash:     result.TxHash.Hex(),
+		Status:     "pending",
+	}
+	if result.Mined {
+		response.BlockNumber = result.BlockNumber.String()
+		response.GasUsed = strconv.FormatUint(result.GasUsed, 10)
+		switch {
+		case result.Status != types.ReceiptStatusSuccessful:
+			response.Status = "reverted"
+		case !result.OpSuccess:
+			response.Status = "op-failed"
+		default:
+			response.Status = "success"
+		}
+	}
 
-		// if extcodesize.Int64() == 0 {
-		// 	errEscrowNotFound(w)
-		// 	return
-		// }
+	setTxHash := func(i *intent.Intent) { i.TxHash = response.TxHash }
+	switch response.Status {
+	case "success":
+		advanceIntent(supabaseClient, intentId, intent.Executed, "", setTxHash)
+	case "reverted", "op-failed":
+		advanceIntent(supabaseClient, intentId, intent.Failed, response.Status, func(i *intent.Intent) {
+			i.TxHash = response.TxHash
+			i.Error = response.Status
+		})
+	}
+	// pending ops stay escrow-locked until the payout proves their execution
+	return response, nil
+}
 
-		// // because no public function, just call balance because we are only using address(0)
-		// escrowBalance, err := client.BalanceAt(context.Background(), escrowAddress, nil)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
+// escrowPayoutInput is the verified part of a payout request shared by the unsigned and signed queries
+type escrowPayoutInput struct {
+	request          evmHandler.PayoutRequest
+	traceId          common.Hash
+	userOpHash       common.Hash
+	originChain      *chains.Chain
+	destinationChain *chains.Chain
+	escrowAddress    common.Address
+}
 
-		// someint, err = strconv.ParseInt(assetAmount, 10, 64)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
-		// compareResult := escrowBalance.Cmp(big.NewInt(someint))
-		// if compareResult == -1 {
-		// 	errInsufficientEscrowBalance(w)
-		// 	return
-		// }
+func verifyEscrowPayout(bytecode, traceId, destinationId string) (escrowPayoutInput, error) {
+	data, err := hexutil.Decode(bytecode)
+	if err != nil {
+		return escrowPayoutInput{}, utils.ErrMalformedRequest(fmt.Sprintf("invalid data: %v", err))
+	}
+	request, err := evmHandler.ParsePaymasterAndDataType1(data)
+	if err != nil {
+		return escrowPayoutInput{}, utils.ErrMalformedRequest(err.Error())
+	}
+	traceHash, err := hexutil.Decode(traceId)
+	if err != nil || len(traceHash) != 32 {
+		return escrowPayoutInput{}, utils.ErrMalformedRequest("invalid trace id")
+	}
 
-		// // need to validate userop, not going to happen need to use entrypointsimulations
-		// // calling base simpleaccount
-		// // validateUserOp
-		// // function validateUserOp(
-		// // 			PackedUserOperation calldata userOp,
-		// // 			bytes32 userOpHash,
-		// // 			uint256 missingAccountFunds
-		// // 	) external virtual override returns (uint256 validationData) {
-		// // 			_requireFromEntryPoint();
-		// // 			validationData = _validateSignature(userOp, userOpHash);
-		// // 			_validateNonce(userOp.nonce);
-		// // 			_payPrefund(missingAccountFunds);
-		// // 	}
-
-		// // var executablePackedUserop []PackedUserOperation
-		// // executablePackedUserop = append(executablePackedUserop, packedUserOperation)
-
-		// someint, err = strconv.ParseInt(assetAmount, 10, 64)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
-		// // datainput, err := parsedABIs["Entrypoint"].Pack("handleOps", executablePackedUserop, common.HexToAddress("0xaeD6b252635DcEF5Ba85dE52173FF040a18CEC6a"))
-		// // if err != nil {
-		// // 	fmt.Print(err)
-		// // 	return //fmt.Errorf("failed to pack multicallExecuteAll input: %v", err)
-		// // }
-
-		// // var noinput []byte
-		// //recipet, data, err := PackedExecuteFunction(*client2, common.HexToAddress(chainInfo2.AddressEntrypoint), common.Big0, datainput)
-		// // recipet, data, err := PackedExecuteFunction(*client2, common.HexToAddress(signer), big.NewInt(someint), noinput)
-		// // if err != nil {
-		// // 	fmt.Print(err)
-		// // 	return //fmt.Errorf("failed to pack multicallExecuteAll input: %v", err)
-		// // }
-
-		// // fmt.Printf("recipet: %s", recipet)
-		// // fmt.Printf("data: %s", data)
-
-		// gasPrice, _ := client2.SuggestGasPrice(context.Background())
-		// fmt.Printf("gasPrice: %s\n", gasPrice)
-		// fmt.Printf("gasPrice: %s\n", gasPrice)
-		// // //lchainid, _ := client2.ChainID(context.Background())
-		// // // auth, _ := bind.NewKeyedTransactorWithChainID(privateKey, lchainid)
-		// // // auth.Value = big.NewInt(1000000000000000000)
-
-		// // addy := common.HexToAddress(signer)
-		// // callMsg := ethereum.CallMsg{
-		// // 	From:     relayAddress,
-		// // 	To:       &addy,
-		// // 	Gas:      0,
-		// // 	GasPrice: gasPrice,
-		// // 	Value:    big.NewInt(someint),
-		// // 	Data:     noinput,
-		// // }
-
-		// // _, _ = client.CallContract(context.Background(), callMsg, nil)
-
-		// receipt, err := TransferEth(*client, "8e80f019af2ae825c10e261594aa7ce5f8898fcc30eec7a25110a906914968d7", signer, someint)
-		// if err != nil {
-		// 	fmt.Println(err)
-		// 	utils.ErrInternal(w)
-		// 	return
-		// }
+	originChain, err := chains.Get(request.OriginChainId.String())
+	if err != nil {
+		return escrowPayoutInput{}, errUnsupportedChain(request.OriginChainId.String())
+	}
+	destinationChain, err := chains.Get(destinationId)
+	if err != nil {
+		return escrowPayoutInput{}, errUnsupportedChain(destinationId)
+	}
+	if originChain.VM != "evm" || destinationChain.VM != "evm" {
+		return escrowPayoutInput{}, utils.ErrMalformedRequest("escrow payout only supports evm origin and destination")
+	}
 
-		// fmt.Printf("receipt: %s\n", receipt)
+	destinationClient, err := rpcpool.EvmClient(destinationChain.ID)
+	if err != nil {
+		return escrowPayoutInput{}, errRpcFailed(err)
+	}
+	userOpHash, err := evmHandler.VerifyPayoutTrace(context.Background(), destinationClient, destinationChain, common.BytesToHash(traceHash), request, originChain.Domain)
+	if err != nil {
+		return escrowPayoutInput{}, utils.ErrMalformedRequest(err.Error())
+	}
 
-		// fmt.Println(chainInfo2)
-		// // TestReceipt
-		// // for not only handle escrowPayload, which is a payload to execute test contract increment
-		// w.WriteHeader(http.StatusOK)
-		// w.Header().Set("Content-Type", "application/json")
-		// if err := json.NewEncoder(w).Encode(packedUserOperation); err != nil {
-		// 	http.Error(w, err.Error(), http.StatusInternalServerError)
-		// 	return
-		// }
-	*/
-	return nil, nil
+	originClient, err := rpcpool.EvmClient(originChain.ID)
+	if err != nil {
+		return escrowPayoutInput{}, errRpcFailed(err)
+	}
+	escrowAddressBytes, _, err := evmHandler.GetEscrowAddress(
+		originClient,
+		request.Signer,
+		common.HexToAddress(originChain.Contracts.EscrowFactory),
+		common.HexToAddress(originChain.Contracts.Escrow),
+		escrowSalt[:])
+	if err != nil {
+		return escrowPayoutInput{}, utils.ErrInternal(err.Error())
+	}
+
+	return escrowPayoutInput{
+		request:          request,
+		traceId:          common.BytesToHash(traceHash),
+		userOpHash:       userOpHash,
+		originChain:      originChain,
+		destinationChain: destinationChain,
+		escrowAddress:    common.BytesToAddress(escrowAddressBytes),
+	}, nil
 }
 
+// UnsignedEscrowPayout verifies a payout and returns the message the relay would dispatch
 func UnsignedEscrowPayout(r *http.Request) (interface{}, error) {
 	privateKey, relayAddress, err := utils.EnvKey2Ecdsa()
-	fmt.Print(privateKey, relayAddress)
 	if err != nil {
 		return nil, utils.ErrInternal(err.Error())
 	}
-	// params := &UnsignedEscrowPayoutParams{}
+	params := &UnsignedEscrowPayoutParams{}
 
-	// if !utils.ParseAndValidateParams(w, r, params) {
-	// 	return
-	// }
-	/*
-	   _CrosschainPaymasterAddress 0x3647fbDD26946850f7A18599394A4685aaD550BC
-	   paymasterAndDataType1_ data:
-	   0x0000000000000000000000003647fbdd26946850f7a18599394a4685aad550bc00000000000000000000000000989680000000000000000000000000009896800000000000000000000000000000000000000000000000000000000000000001000000000000000000000000f814aa444c49a5dbbbf8f59a654036a0ede26cce00000000000000000000000006e7cb26c760a7a2b72cd73515de65ee431b01240000000000000000000000000000000000000000000000000000000000aa36a700000000000000000000000000000000000000000000000000000000499602d20000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000
-	   The above data is expected to be returned with
-	   signer
-	   data
-	   v
-	   s
-	   r
-	*/
+	if err := utils.ParseAndValidateParams(r, &params); err != nil {
+		return nil, err
+	}
 
-	// fmt.Printf("\ninput data: %s", params.Bytecode)
-	// fmt.Printf("\ntrace id: %s", params.TraceId)
-	return nil, nil
+	input, err := verifyEscrowPayout(params.Bytecode, params.TraceId, params.DestinationId)
+	if err != nil {
+		return nil, err
+	}
+	message, err := evmHandler.BuildPayoutMessage(input.request, input.userOpHash, relayAddress, privateKey)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+
+	return EscrowPayoutResponse{
+		TraceId:     input.traceId.Hex(),
+		UserOpHash:  input.userOpHash.Hex(),
+		Status:      "preview",
+		Escrow:      input.escrowAddress.Hex(),
+		Message:     hexutil.Encode(message.Body),
+		MessageHash: message.Hash.Hex(),
+	}, nil
 }
 
-func SignedEscrowPayout(r *http.Request) (interface{}, error) {
+// SignedEscrowPayout relays the payout for an executed user operation to the origin escrow
+// through hyperlane, every trace id is paid out at most once
+func SignedEscrowPayout(r *http.Request, supabaseClient *supabase.Client) (interface{}, error) {
 	privateKey, relayAddress, err := utils.EnvKey2Ecdsa()
-	fmt.Print(privateKey, relayAddress)
 	if err != nil {
 		return nil, utils.ErrInternal(err.Error())
 	}
@@ -869,21 +981,153 @@ func SignedEscrowPayout(r *http.Request) (interface{}, error) {
 	if err := utils.ParseAndValidateParams(r, &params); err != nil {
 		return nil, err
 	}
-	/*
-	   _CrosschainPaymasterAddress 0x3647fbDD26946850f7A18599394A4685aaD550BC
-	   paymasterAndDataType1_ data:
-	   0x0000000000000000000000003647fbdd26946850f7a18599394a4685aad550bc00000000000000000000000000989680000000000000000000000000009896800000000000000000000000000000000000000000000000000000000000000001000000000000000000000000f814aa444c49a5dbbbf8f59a654036a0ede26cce00000000000000000000000006e7cb26c760a7a2b72cd73515de65ee431b01240000000000000000000000000000000000000000000000000000000000aa36a700000000000000000000000000000000000000000000000000000000499602d20000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000
-	   The above data is expected to be returned signed as
-	   signer
-	   data
-	   v will be skipped for now due to a known bug
-	   s
-	   r
-	*/
 
-	fmt.Printf("\ninput data: %s", params.Bytecode)
-	fmt.Printf("\ntrace id: %s", params.TraceId)
-	return nil, nil
+	input, err := verifyEscrowPayout(params.Bytecode, params.TraceId, params.DestinationId)
+	if err != nil {
+		return nil, err
+	}
+	message, err := evmHandler.BuildPayoutMessage(input.request, input.userOpHash, relayAddress, privateKey)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+
+	response := EscrowPayoutResponse{
+		TraceId:     input.traceId.Hex(),
+		UserOpHash:  input.userOpHash.Hex(),
+		Escrow:      input.escrowAddress.Hex(),
+		Message:     hexutil.Encode(message.Body),
+		MessageHash: message.Hash.Hex(),
+	}
+
+	record, reserved, err := db.ReserveEscrowPayout(supabaseClient, db.EscrowPayout{
+		TraceId:       input.traceId.Hex(),
+		OriginId:      input.originChain.ID,
+		DestinationId: input.destinationChain.ID,
+		Signer:        input.request.Signer.Hex(),
+		AssetAddress:  input.request.AssetAddress.Hex(),
+		AssetAmount:   input.request.AssetAmount.String(),
+		UserOpHash:    input.userOpHash.Hex(),
+	})
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	if !reserved {
+		// already paid out or in flight, report the stored outcome
+		response.Status = record.Status
+		response.DispatchTx = record.DispatchTx
+		response.MessageId = record.MessageId
+		response.GasTx = record.GasTx
+		response.Error = record.Error
+		return response, nil
+	}
+
+	destinationClient, err := rpcpool.EvmClient(input.destinationChain.ID)
+	if err != nil {
+		if record.DispatchTx == "" {
+			record.Status = db.PayoutFailed
+		} else {
+			record.Status = db.PayoutGasUnpaid
+		}
+		record.Error = err.Error()
+		if updateErr := db.UpdateEscrowPayout(supabaseClient, record); updateErr != nil {
+			log.Printf("\nFailed to update payout %s: %v", record.TraceId, updateErr)
+		}
+		return nil, errRpcFailed(err)
+	}
+
+	record = relayPayout(context.Background(), supabaseClient, destinationClient, input, message, record, privateKey)
+	if err := db.UpdateEscrowPayout(supabaseClient, record); err != nil {
+		log.Printf("\nFailed to update payout %s: %v", record.TraceId, err)
+	}
+
+	if record.Status == db.PayoutFailed {
+		return nil, utils.ErrInternal(record.Error)
+	}
+	// the intent is paid out once the delivery is paid for
+	if record.GasTx != "" {
+		payIntent(supabaseClient, input.userOpHash.Hex(), input.traceId.Hex(), record.DispatchTx)
+	}
+
+	response.Status = record.Status
+	response.DispatchTx = record.DispatchTx
+	response.MessageId = record.MessageId
+	response.GasTx = record.GasTx
+	response.Error = record.Error
+	return response, nil
+}
+
+// relayPayout sends the steps of a reserved payout that are not on chain yet: the mailbox
+// dispatch, then the IGP payment for its message. A reclaimed row with a dispatch tx only
+// pays the IGP, the dispatch is sent again only when it reverted or was never mined
+func relayPayout(ctx context.Context, supabaseClient *supabase.Client, client *ethclient.Client, input escrowPayoutInput, message evmHandler.PayoutMessage, record db.EscrowPayout, privateKey *ecdsa.PrivateKey) db.EscrowPayout {
+	record.Error = ""
+	if record.DispatchTx != "" && record.MessageId == "" {
+		messageId, mined, err := evmHandler.PayoutDispatchMessageId(ctx, client, input.destinationChain, common.HexToHash(record.DispatchTx))
+		switch {
+		case err != nil && !mined:
+			record.Status = db.PayoutGasUnpaid
+			record.Error = err.Error()
+			return record
+		case err != nil:
+			// a reverted dispatch sent nothing
+			record.DispatchTx = ""
+		case mined:
+			record.MessageId = messageId.Hex()
+		case record.UpdatedAt != nil && time.Since(*record.UpdatedAt) > db.PayoutPendingTimeout:
+			// dropped, or never sent before a crash
+			record.DispatchTx = ""
+		default:
+			record.Status = db.PayoutGasUnpaid
+			record.Error = fmt.Sprintf("dispatch %s not mined yet", record.DispatchTx)
+			return record
+		}
+	}
+
+	if record.DispatchTx == "" {
+		// the dispatch tx is stored before it is sent so a crash leaves it recoverable
+		dispatch, err := evmHandler.DispatchPayout(ctx, client, input.destinationChain, input.originChain.Domain, input.escrowAddress, message, privateKey, func(tx common.Hash) error {
+			pending := record
+			pending.DispatchTx = tx.Hex()
+			return db.UpdateEscrowPayout(supabaseClient, pending)
+		})
+		if dispatch.DispatchTx == (common.Hash{}) {
+			// nothing reached the chain so the payout can be retried
+			record.Status = db.PayoutFailed
+			record.Error = err.Error()
+			return record
+		}
+		record.DispatchTx = dispatch.DispatchTx.Hex()
+		if err != nil {
+			record.Status = db.PayoutGasUnpaid
+			record.Error = err.Error()
+			return record
+		}
+		record.MessageId = dispatch.MessageId.Hex()
+	}
+
+	gasTx, err := evmHandler.PayPayoutGas(ctx, client, input.destinationChain, input.originChain.Domain, common.HexToHash(record.MessageId), privateKey)
+	if err != nil {
+		record.Status = db.PayoutGasUnpaid
+		record.Error = err.Error()
+		return record
+	}
+	record.Status = db.PayoutDispatched
+	record.GasTx = gasTx.Hex()
+	return record
+}
+
+// payIntent moves the intent of a paid out op to paid-out, the verified trace proves the
+// execution of ops that were still pending when they were submitted
+func payIntent(supabaseClient *supabase.Client, id string, traceId string, dispatchTx string) {
+	current, err := intent.Get(supabaseClient, id)
+	if err != nil {
+		log.Printf("\nFailed to get intent %s: %v", id, err)
+		return
+	}
+	if current.State == intent.EscrowLocked {
+		advanceIntent(supabaseClient, id, intent.Executed, "", func(i *intent.Intent) { i.TxHash = traceId })
+	}
+	advanceIntent(supabaseClient, id, intent.PaidOut, "", func(i *intent.Intent) { i.PayoutTx = dispatchTx })
 }
 
 func ViewFunction(client ethclient.Client, contractAddress common.Address, parsedABI abi.ABI, methodName string, args ...interface{}) ([]byte, error) {
@@ -1557,105 +1801,56 @@ func createEscrowBytecodeTVM(messageTypeInt int, signer string, originId string,
 	return "", "", nil
 }
 
+// createEscrowBytecodeSVM returns the escrow pda and the unsigned deposit and lock transaction of the signer
 func createEscrowBytecodeSVM(messageTypeInt int, signer string, originId string, assetAddress string, assetAmount string) (string, string, error) {
-	return "", "", nil
+	amount, err := strconv.ParseUint(assetAmount, 10, 64)
+	if err != nil {
+		return "", "", utils.ErrMalformedRequest("invalid asset amount: expected lamports or token base units")
+	}
+	response, err := svmHandler.UnsignedEscrowRequest(nil, &svmHandler.UnsignedEscrowRequestParams{
+		Header: utils.PartialHeader{
+			TxType:      strconv.Itoa(messageTypeInt),
+			ChainId:     originId,
+			ChainSigner: signer,
+		},
+		Asset:  assetAddress,
+		Amount: amount,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	message := response.(svmHandler.MessageEscrowSvm)
+	return message.Escrow.Address, message.Transaction.Transaction, nil
 }
 
 func checkChainStatus(chainId string) (*ethclient.Client, *Chain, error) {
-	var client *ethclient.Client
-	var chain *Chain
-	var err error
-
-	var rpcURL string
-	var addresses Chain
-
-	switch chainId {
-	case "0x3106A", "200810":
-		chainId = "200810"
-		rpcURL = "https://testnet-rpc.bitlayer.org"
-		addresses = Chain{
-			AddressEntrypoint:            "0x317bBdFbAe7845648864348A0C304392d0F2925F",
-			AddressEntrypointSimulations: "0x6960fA06d5119258533B5d715c8696EE66ca4042",
-			AddressSimpleAccountFactory:  "0xCF730748FcDc78A5AB854B898aC24b6d6001AbF7",
-			AddressSimpleAccount:         "0xfaAe830bA56C40d17b7e23bfe092f23503464114",
-			AddressMulticall:             "0x66e4f2437c5F612Ae25e94C1C549cb9f151E0cB3",
-			AddressHyperlaneMailbox:      "0x2EaAd60F982f7B99b42f30e98B3b3f8ff89C0A46",
-			AddressHyperlaneIgp:          "0x16e81e1973939bD166FDc61651F731e1658060F3",
-			AddressPaymaster:             "0xdAE5e7CEBe4872BF0776477EcCCD2A0eFdF54f0e",
-			AddressEscrow:                "0x9925D4a40ea432A25B91ab424b16c8FC6e0Eec5A",
-			AddressEscrowFactory:         "0xC531388B2C2511FDFD16cD48f1087A747DC34b33",
-		}
-	case "0x4268", "17000":
-		chainId = "200810"
-		rpcURL = "https://ethereum-holesky-rpc.publicnode.com"
-		addresses = Chain{
-			AddressEntrypoint:            "0xc5Ff094002cdaF36d6a766799eB63Ec82B8C79F1",
-			AddressEntrypointSimulations: "0x67B9841e9864D394FDc02e787A0Ac37f32B49eC7",
-			AddressSimpleAccountFactory:  "0x39351b719D044CF6E91DEC75E78e5d128c582bE7",
-			AddressSimpleAccount:         "0x0983a4e9D9aB03134945BFc9Ec9EF31338AB7465",
-			AddressMulticall:             "0x98876409cc48507f8Ee8A0CCdd642469DBfB3E21",
-			AddressHyperlaneMailbox:      "0x913A6477496eeb054C9773843a64c8621Fc46e8C",
-			AddressHyperlaneIgp:          "0x2Fb9F9bd9034B6A5CAF3eCDB30db818619EbE9f1",
-			AddressPaymaster:             "0xA5bcda4aA740C02093Ba57A750a8f424BC8B4B13",
-			AddressEscrow:                "0x686130A96724734F0B6f99C6D32213BC62C1830A",
-			AddressEscrowFactory:         "0x45d5D46B097870223fDDBcA9a9eDe35A7D37e2A1",
-		}
-	case "0xaa36a7", "11155111":
-		chainId = "11155111"
-		rpcURL = "https://rpc2.sepolia.org"
-		addresses = Chain{
-			AddressEntrypoint:            "0xA6eBc93dA2C99654e7D6BC12ed24362061805C82",
-			AddressEntrypointSimulations: "0x0d17dE0436b65279c8D7A75847F84626687A1647",
-			AddressSimpleAccountFactory:  "0x54bed3E354cbF23C2CADaB1dF43399473e38a358",
-			AddressSimpleAccount:         "0x54bed3E354cbF23C2CADaB1dF43399473e38a358",
-			AddressMulticall:             "0x6958206f218D8f889ECBb76B89eE9bF1CAe37715",
-			AddressHyperlaneMailbox:      "0xAc165ff97Dc42d87D858ba8BC4AA27429a8C48e8",
-			AddressHyperlaneIgp:          "0x00eb6D45afac57E708eC3FA6214BFe900aFDb95D",
-			AddressPaymaster:             "0x31aCA626faBd9df61d24A537ecb9D646994b4d4d",
-			AddressEscrow:                "0xea8D264dF67c9476cA80A24067c2F3CF7726aC4d",
-			AddressEscrowFactory:         "0xd9842E241B7015ea1E1B5A90Ae20b6453ADF2723",
-		}
-	case "0xe34", "3636":
-		chainId = "3636"
-		rpcURL = "https://node.botanixlabs.dev"
-		addresses = Chain{
-			AddressEntrypoint:            "0xF7B12fFBC58dd654aeA52f1c863bf3f4731f848F",
-			AddressEntrypointSimulations: "0x1db7F1263FbfBe5d91548B3422563179f6bE8d99",
-			AddressSimpleAccountFactory:  "0xFB23dB8098Faf2dB307110905dC3698Fe27E136d",
-			AddressSimpleAccount:         "0x15aA997cC02e103a7570a1C26F09996f6FBc1829",
-			AddressMulticall:             "0x6cB50ee0241C7AE6Ebc30A34a9F3C23A96098bBf",
-			AddressHyperlaneMailbox:      "0xd2DB8440B7dC1d05aC2366b353f1cF205Cf875EA",
-			AddressHyperlaneIgp:          "0x8439DBdca66C9F72725f1B2d50dFCdc7c6CBBbEb",
-			AddressPaymaster:             "0xbbfb649f42Baf44729a150464CBf6B89349A634a",
-			AddressEscrow:                "0xCD77545cA802c4B05ff359f7b10355EC220E7476",
-			AddressEscrowFactory:         "0xA6eBc93dA2C99654e7D6BC12ed24362061805C82",
-		}
-	default:
-		return nil, nil, fmt.Errorf("unsupported chain ID: %s", chainId)
-	}
-
-	client, err = ethclient.Dial(rpcURL)
+	chainConfig, err := chains.Get(chainId)
 	if err != nil {
 		return nil, nil, err
 	}
+	if chainConfig.VM != "evm" {
+		return nil, nil, fmt.Errorf("chain %s is not an evm chain", chainConfig.ID)
+	}
 
-	domain, err := strconv.ParseUint(chainId, 0, 32)
+	client, err := rpcpool.EvmClient(chainConfig.ID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	chain = &Chain{
-		ChainId:                      chainId,
-		Domain:                       uint32(domain),
-		AddressEntrypoint:            addresses.AddressEntrypoint,
-		AddressEntrypointSimulations: addresses.AddressEntrypointSimulations,
-		AddressSimpleAccountFactory:  addresses.AddressSimpleAccountFactory,
-		AddressMulticall:             addresses.AddressMulticall,
-		AddressHyperlaneMailbox:      addresses.AddressHyperlaneMailbox,
-		AddressHyperlaneIgp:          addresses.AddressHyperlaneIgp,
-		AddressPaymaster:             addresses.AddressPaymaster,
-		AddressEscrow:                addresses.AddressEscrow,
-		AddressEscrowFactory:         addresses.AddressEscrowFactory,
+	contracts := chainConfig.Contracts
+	chain := &Chain{
+		ChainId:                      chainConfig.ID,
+		Domain:                       chainConfig.Domain,
+		AddressEntrypoint:            contracts.Entrypoint,
+		AddressEntrypointSimulations: contracts.EntrypointSimulations,
+		AddressSimpleAccountFactory:  contracts.SimpleAccountFactory,
+		AddressSimpleAccount:         contracts.SimpleAccount,
+		AddressMulticall:             contracts.Multicall,
+		AddressHyperlaneMailbox:      contracts.HyperlaneMailbox,
+		AddressHyperlaneIgp:          contracts.HyperlaneIgp,
+		AddressPaymaster:             contracts.Paymaster,
+		AddressEscrow:                contracts.Escrow,
+		AddressEscrowFactory:         contracts.EscrowFactory,
 	}
 
 	return client, chain, nil
@@ -1667,7 +1862,7 @@ func checkClient(chainId string) (*ethclient.Client, *Chain, error) {
 		return nil, nil, err
 	}
 	if client == nil {
-		return nil, nil, utils.ErrInternal(errUnsupportedChain(chainId))
+		return nil, nil, errUnsupportedChain(chainId)
 	}
 	return client, chainInfo, nil
 }
@@ -1,7 +1,6 @@
 package requestHandler
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -20,7 +19,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		supabaseKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
 		supabaseClient, err := supabase.NewClient(supabaseUrl, supabaseKey, nil)
 		if err != nil {
-			http.Error(w, "Failed to create Supabase client", http.StatusInternalServerError)
+			utils.WriteError(w, utils.ErrInternal("failed to create supabase client"))
 			return
 		}
 
@@ -35,8 +34,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 			HandleResponse(w, r, supabaseClient, response, err)
 			return
 		default:
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(utils.ErrMalformedRequest("Invalid query parameter"))
+			utils.WriteError(w, utils.ErrMalformedRequest("Invalid query parameter"))
 			return
 		}
 	}))
@@ -49,14 +47,6 @@ func HandleResponse(w http.ResponseWriter, r *http.Request, supabaseClient *supa
 		if logErr := db.LogError(supabaseClient, err, r.URL.Query().Get("query"), response); logErr != nil {
 			fmt.Printf("Failed to log error: %v\n", logErr.Error())
 		}
-
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(err)
-		return
-	}
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 	}
+	utils.WriteResponse(w, response, err)
 }
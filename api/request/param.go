@@ -1,13 +1,16 @@
 package requestHandler
 
 import (
+	"math/big"
+
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 )
 
 type UnsignedCrosschainRequestParams struct {
-	Header  utils.MessageHeader `query:"header"`
-	Target  string              `query:"target" optional:"true"`
-	Value   string              `query:"value" optional:"true"`
-	Payload string              `query:"payload" optional:"true"`
+	Header      utils.MessageHeader `query:"header"`
+	Target      string              `query:"target" optional:"true"`
+	Value       string              `query:"value" optional:"true"`
+	Payload     string              `query:"payload" optional:"true"`
+	EscrowValue *big.Int            `query:"escrow-value" optional:"true" validate:"uint"` // nano deposited and locked by a tvm origin
 	//Extra   string              `query:"extra" options:"true"` // stores extra data for tvm
 }
@@ -204,6 +204,18 @@ func UnsignedCrosschainRequest(r *http.Request) (interface{}, error) {
 		unsignedDataResponse.FromMessage = response.(MessageResponse)
 		// utils.PrintStructFields(response)
 	case "tvm":
+		// the escrow lock commits to the destination op, so the to message is built first
+		response, err := tvmHandler.UnsignedEscrowLock(utils.PartialHeader{
+			TxType:      params.Header.TxType,
+			ChainName:   params.Header.FromChainName,
+			ChainType:   params.Header.FromChainType,
+			ChainId:     params.Header.FromChainId,
+			ChainSigner: params.Header.FromChainSigner,
+		}, unsignedDataResponse.ToMessage, params.EscrowValue)
+		if err != nil {
+			return nil, err
+		}
+		unsignedDataResponse.FromMessage = response.(MessageResponse)
 	case "svm":
 	default:
 		return nil, utils.ErrInternal(fmt.Sprintf("%s type chains are not yet supported", params.Header.FromChainType))
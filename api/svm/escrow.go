@@ -0,0 +1,208 @@
+package svmHandler
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+)
+
+// pda seeds of the escrow and entrypoint programs
+var (
+	escrowSeed = []byte("escrow")
+	proxySeed  = []byte("proxy")
+)
+
+// escrow account layout: anchor discriminator | owner | bump | locked amount | lock deadline
+const (
+	escrowLockedOffset   = 8 + 32 + 1
+	escrowDeadlineOffset = escrowLockedOffset + 8
+	escrowAccountSize    = escrowDeadlineOffset + 8
+)
+
+// proxy account layout: anchor discriminator | bump | nonce | owner
+const proxyNonceOffset = 8 + 1
+
+// default lock extension of depositAndLock, same as the evm escrow
+const defaultLockSeconds = 3600
+
+// programs returns the escrow and entrypoint program ids of the chain, unset programs are zero
+func programs(chain *chains.Chain) (PublicKey, PublicKey, error) {
+	var escrow, entrypoint PublicKey
+	var err error
+	if chain.Contracts.Escrow != "" {
+		if escrow, err = ParsePublicKey(chain.Contracts.Escrow); err != nil {
+			return escrow, entrypoint, fmt.Errorf("invalid escrow program for chain id %s: %v", chain.ID, err)
+		}
+	}
+	if chain.Contracts.Entrypoint != "" {
+		if entrypoint, err = ParsePublicKey(chain.Contracts.Entrypoint); err != nil {
+			return escrow, entrypoint, fmt.Errorf("invalid entrypoint program for chain id %s: %v", chain.ID, err)
+		}
+	}
+	return escrow, entrypoint, nil
+}
+
+// FindEscrowAddress returns the escrow pda of the owner
+func FindEscrowAddress(escrowProgram PublicKey, owner PublicKey) (PublicKey, uint8, error) {
+	return FindProgramAddress([][]byte{escrowSeed, owner[:]}, escrowProgram)
+}
+
+// FindProxyAddress returns the proxy pda controlled through the entrypoint by the owner,
+// a 20 byte evm address or a 32 byte solana key
+func FindProxyAddress(entrypointProgram PublicKey, owner []byte) (PublicKey, uint8, error) {
+	return FindProgramAddress([][]byte{proxySeed, owner}, entrypointProgram)
+}
+
+// discriminator is the anchor instruction selector, sha256("global:<name>")[:8]
+func discriminator(name string) []byte {
+	hash := sha256.Sum256([]byte("global:" + name))
+	return hash[:8]
+}
+
+// DepositInstruction moves lamports, or spl tokens when mint is set, from the owner into the escrow
+func DepositInstruction(escrowProgram PublicKey, owner PublicKey, mint PublicKey, amount uint64) (Instruction, error) {
+	return escrowInstruction("deposit", escrowProgram, owner, mint, binary.LittleEndian.AppendUint64(nil, amount))
+}
+
+// DepositAndLockInstruction deposits and locks the amount for lockSeconds
+func DepositAndLockInstruction(escrowProgram PublicKey, owner PublicKey, mint PublicKey, amount uint64, lockSeconds int64) (Instruction, error) {
+	data := binary.LittleEndian.AppendUint64(nil, amount)
+	data = binary.LittleEndian.AppendUint64(data, uint64(lockSeconds))
+	return escrowInstruction("deposit_and_lock", escrowProgram, owner, mint, data)
+}
+
+func escrowInstruction(name string, escrowProgram PublicKey, owner PublicKey, mint PublicKey, args []byte) (Instruction, error) {
+	escrow, _, err := FindEscrowAddress(escrowProgram, owner)
+	if err != nil {
+		return Instruction{}, err
+	}
+	accounts := []AccountMeta{
+		{PublicKey: owner, IsSigner: true, IsWritable: true},
+		{PublicKey: escrow, IsWritable: true},
+		{PublicKey: SystemProgramId},
+	}
+	if !mint.IsZero() {
+		ownerTokenAccount, err := FindAssociatedTokenAddress(owner, mint)
+		if err != nil {
+			return Instruction{}, err
+		}
+		escrowTokenAccount, err := FindAssociatedTokenAddress(escrow, mint)
+		if err != nil {
+			return Instruction{}, err
+		}
+		accounts = append(accounts,
+			AccountMeta{PublicKey: mint},
+			AccountMeta{PublicKey: ownerTokenAccount, IsWritable: true},
+			AccountMeta{PublicKey: escrowTokenAccount, IsWritable: true},
+			AccountMeta{PublicKey: TokenProgramId},
+			AccountMeta{PublicKey: AssociatedTokenProgramId},
+		)
+	}
+	return Instruction{
+		ProgramId: escrowProgram,
+		Accounts:  accounts,
+		Data:      append(discriminator(name), args...),
+	}, nil
+}
+
+// EntrypointMessage is the message the proxy owner signs for a proxy execution:
+// entrypoint | proxy | nonce (le) | payload
+func EntrypointMessage(entrypointProgram PublicKey, proxy PublicKey, nonce uint64, payload []byte) []byte {
+	message := append(append([]byte{}, entrypointProgram[:]...), proxy[:]...)
+	message = binary.LittleEndian.AppendUint64(message, nonce)
+	return append(message, payload...)
+}
+
+// ExecuteInstruction executes the payload through the proxy of the owner, the signature is checked by the
+// entrypoint against the secp256k1 or ed25519 instruction that must precede it
+func ExecuteInstruction(entrypointProgram PublicKey, relayer PublicKey, owner []byte, nonce uint64, payload []byte, remaining []AccountMeta) (Instruction, error) {
+	proxy, _, err := FindProxyAddress(entrypointProgram, owner)
+	if err != nil {
+		return Instruction{}, err
+	}
+	data := discriminator("execute")
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(owner)))
+	data = append(data, owner...)
+	data = binary.LittleEndian.AppendUint64(data, nonce)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(payload)))
+	data = append(data, payload...)
+
+	accounts := []AccountMeta{
+		{PublicKey: relayer, IsSigner: true, IsWritable: true},
+		{PublicKey: proxy, IsWritable: true},
+		{PublicKey: SysvarInstructionsId},
+		{PublicKey: SystemProgramId},
+	}
+	return Instruction{
+		ProgramId: entrypointProgram,
+		Accounts:  append(accounts, remaining...),
+		Data:      data,
+	}, nil
+}
+
+// Secp256k1Instruction builds the native secp256k1 verify instruction for one signature, everything is
+// stored inline so the instruction index is the position of this instruction in the transaction
+func Secp256k1Instruction(instructionIndex uint8, ethAddress [20]byte, signature []byte, message []byte) (Instruction, error) {
+	if len(signature) != 65 {
+		return Instruction{}, fmt.Errorf("secp256k1 signature must be 65 bytes, got %d", len(signature))
+	}
+	if len(message) > 0xffff {
+		return Instruction{}, fmt.Errorf("message too long: %d", len(message))
+	}
+	// count | offsets (11) | eth address (20) | signature + recovery id (65) | message
+	const (
+		ethAddressOffset = 1 + 11
+		signatureOffset  = ethAddressOffset + 20
+		messageOffset    = signatureOffset + 65
+	)
+	recoveryId := signature[64]
+	if recoveryId >= 27 {
+		recoveryId -= 27
+	}
+
+	data := []byte{1}
+	data = binary.LittleEndian.AppendUint16(data, signatureOffset)
+	data = append(data, instructionIndex)
+	data = binary.LittleEndian.AppendUint16(data, ethAddressOffset)
+	data = append(data, instructionIndex)
+	data = binary.LittleEndian.AppendUint16(data, messageOffset)
+	data = binary.LittleEndian.AppendUint16(data, uint16(len(message)))
+	data = append(data, instructionIndex)
+	data = append(data, ethAddress[:]...)
+	data = append(data, signature[:64]...)
+	data = append(data, recoveryId)
+	data = append(data, message...)
+	return Instruction{ProgramId: Secp256k1ProgramId, Data: data}, nil
+}
+
+// Ed25519Instruction builds the native ed25519 verify instruction for one inline signature
+func Ed25519Instruction(publicKey PublicKey, signature []byte, message []byte) (Instruction, error) {
+	if len(signature) != 64 {
+		return Instruction{}, fmt.Errorf("ed25519 signature must be 64 bytes, got %d", len(signature))
+	}
+	if len(message) > 0xffff {
+		return Instruction{}, fmt.Errorf("message too long: %d", len(message))
+	}
+	// count | padding | offsets (14) | public key (32) | signature (64) | message
+	const (
+		publicKeyOffset = 2 + 14
+		signatureOffset = publicKeyOffset + 32
+		messageOffset   = signatureOffset + 64
+		currentIndex    = 0xffff
+	)
+
+	data := []byte{1, 0}
+	data = binary.LittleEndian.AppendUint16(data, signatureOffset)
+	data = binary.LittleEndian.AppendUint16(data, currentIndex)
+	data = binary.LittleEndian.AppendUint16(data, publicKeyOffset)
+	data = binary.LittleEndian.AppendUint16(data, currentIndex)
+	data = binary.LittleEndian.AppendUint16(data, messageOffset)
+	data = binary.LittleEndian.AppendUint16(data, uint16(len(message)))
+	data = binary.LittleEndian.AppendUint16(data, currentIndex)
+	data = append(data, publicKey[:]...)
+	data = append(data, signature...)
+	data = append(data, message...)
+	return Instruction{ProgramId: Ed25519ProgramId, Data: data}, nil
+}
@@ -0,0 +1,136 @@
+package svmHandler
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// offsetSlice reads an (offset, size) pair of a verify instruction
+func offsetSlice(t *testing.T, data []byte, offset int, size int) []byte {
+	t.Helper()
+	start := int(binary.LittleEndian.Uint16(data[offset:]))
+	if start+size > len(data) {
+		t.Fatalf("offset %d with size %d is out of the %d data bytes", start, size, len(data))
+	}
+	return data[start : start+size]
+}
+
+func TestEd25519Instruction(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signer PublicKey
+	copy(signer[:], publicKey)
+	message := []byte("crosscall entrypoint message")
+	signature := ed25519.Sign(privateKey, message)
+
+	instruction, err := Ed25519Instruction(signer, signature, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := instruction.Data
+	if instruction.ProgramId != Ed25519ProgramId || len(instruction.Accounts) != 0 {
+		t.Fatalf("unexpected program %s or accounts %v", instruction.ProgramId, instruction.Accounts)
+	}
+	if data[0] != 1 || data[1] != 0 {
+		t.Fatalf("expected one signature and padding, got %x", data[:2])
+	}
+	// signature, public key and message offsets, every instruction index points at itself
+	for _, indexOffset := range []int{4, 8, 14} {
+		if index := binary.LittleEndian.Uint16(data[indexOffset:]); index != 0xffff {
+			t.Fatalf("instruction index at %d is %x", indexOffset, index)
+		}
+	}
+	messageSize := int(binary.LittleEndian.Uint16(data[12:]))
+	gotSignature := offsetSlice(t, data, 2, ed25519.SignatureSize)
+	gotKey := offsetSlice(t, data, 6, ed25519.PublicKeySize)
+	gotMessage := offsetSlice(t, data, 10, messageSize)
+	if !bytes.Equal(gotMessage, message) || !bytes.Equal(gotKey, signer[:]) {
+		t.Fatalf("instruction data does not carry the key and message: %x", data)
+	}
+	if !VerifyEd25519(signer, gotMessage, gotSignature) {
+		t.Fatal("signature read from the instruction does not verify")
+	}
+
+	if _, err := Ed25519Instruction(signer, signature[:63], message); err == nil {
+		t.Fatal("short signature accepted")
+	}
+}
+
+func TestSecp256k1Instruction(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	message := []byte("crosscall entrypoint message")
+	signature, err := crypto.Sign(crypto.Keccak256(message), privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// wallets return v as 27/28, the program expects the recovery id
+	signature[64] += 27
+
+	const instructionIndex = 1
+	instruction, err := Secp256k1Instruction(instructionIndex, address, signature, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := instruction.Data
+	if instruction.ProgramId != Secp256k1ProgramId || data[0] != 1 {
+		t.Fatalf("unexpected program %s or count %d", instruction.ProgramId, data[0])
+	}
+	for _, indexOffset := range []int{3, 6, 11} {
+		if data[indexOffset] != instructionIndex {
+			t.Fatalf("instruction index at %d is %d", indexOffset, data[indexOffset])
+		}
+	}
+	messageSize := int(binary.LittleEndian.Uint16(data[9:]))
+	gotSignature := offsetSlice(t, data, 1, 65)
+	gotAddress := offsetSlice(t, data, 4, 20)
+	gotMessage := offsetSlice(t, data, 7, messageSize)
+	if gotSignature[64] > 1 {
+		t.Fatalf("recovery id %d was not normalised", gotSignature[64])
+	}
+	if !bytes.Equal(gotAddress, address[:]) || !bytes.Equal(gotMessage, message) {
+		t.Fatalf("instruction data does not carry the address and message: %x", data)
+	}
+	recovered, err := RecoverSecp256k1(gotMessage, gotSignature)
+	if err != nil || recovered != address {
+		t.Fatalf("recovered %s, want %s: %v", recovered, address, err)
+	}
+}
+
+func TestExecuteInstruction(t *testing.T) {
+	entrypoint := MustParsePublicKey("BPFLoaderUpgradeab1e11111111111111111111111")
+	relayer := key(1)
+	owner := bytes.Repeat([]byte{0xab}, 20)
+	payload := []byte{1, 2, 3}
+
+	instruction, err := ExecuteInstruction(entrypoint, relayer, owner, 7, payload, []AccountMeta{{PublicKey: key(9), IsWritable: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, _, err := FindProxyAddress(entrypoint, owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if instruction.Accounts[0].PublicKey != relayer || instruction.Accounts[1].PublicKey != proxy || instruction.Accounts[4].PublicKey != key(9) {
+		t.Fatalf("unexpected accounts %v", instruction.Accounts)
+	}
+
+	want := append([]byte{}, discriminator("execute")...)
+	want = append(want, 20, 0, 0, 0)
+	want = append(want, owner...)
+	want = append(want, 7, 0, 0, 0, 0, 0, 0, 0)
+	want = append(want, 3, 0, 0, 0, 1, 2, 3)
+	if !bytes.Equal(instruction.Data, want) {
+		t.Fatalf("execute data\n got %x\nwant %x", instruction.Data, want)
+	}
+}
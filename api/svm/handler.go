@@ -1,64 +1,73 @@
 package svmHandler
 
 import (
-	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
-	"strings"
-)
-
-// Error data structure
-type Error struct {
-	Code    uint64 `json:"code"`
-	Message string `json:"message"`
-}
-
-type SpotResponse struct {
-	Asset0   string `json:"asset0"`
-	Asset1   string `json:"asset1"`
-	AmountIn string `json:"amount-in"`
-	Spot     string `json:"spot-price"`
-	SpotMin  string `json:"spot-price-min"`
-	SpotMax  string `json:"spot-price-max"`
-}
+	"os"
 
-type OrderbookResponse struct {
-	RetCode    int           `json:"retCode"`
-	RetMsg     string        `json:"retMsg"`
-	Result     OrderbookData `json:"result"`
-	RetExtInfo struct{}      `json:"retExtInfo"`
-	Time       int64         `json:"time"`
-}
+	"github.com/crosscall-labs/crosschain-api/pkg/db"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/supabase-community/supabase-go"
+)
 
-type OrderbookData struct {
-	Symbol    string     `json:"s"`
-	Asks      [][]string `json:"a"`
-	Bids      [][]string `json:"b"`
-	Timestamp int64      `json:"ts"`
-	UpdateID  int        `json:"u"`
-	CrossSeq  int        `json:"seq"`
-}
+func Handler(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Printf("\nRecovered from panic: %v", rec)
+
+			supabaseUrl := os.Getenv("SUPABASE_URL")
+			supabaseKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
+			supabaseClient, err := supabase.NewClient(supabaseUrl, supabaseKey, nil)
+			if err == nil {
+				logErr := db.LogPanic(supabaseClient, fmt.Sprintf("%v", rec), nil)
+				if logErr != nil {
+					log.Printf("\nFailed to log panic to Supabase: %v", logErr)
+				}
+			} else {
+				log.Printf("\nFailed to create Supabase client for panic logging: %v", err)
+			}
 
-type HelloWorld struct {
-	Test string `json:"test"`
-}
+			utils.WriteError(w, utils.ErrInternal("recovered from panic"))
+		}
+	}()
 
-func Handler(w http.ResponseWriter, r *http.Request) {
-	handlerWithCORS := EnableCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handlerWithCORS := utils.EnableCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		query := r.URL.Query()
+		var response interface{}
+		var err error
+		supabaseUrl := os.Getenv("SUPABASE_URL")
+		supabaseKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
+		supabaseClient, err := supabase.NewClient(supabaseUrl, supabaseKey, nil)
+		if err != nil {
+			utils.WriteError(w, utils.ErrInternal("failed to create supabase client"))
+			return
+		}
 
+		w.Header().Set("Content-Type", "application/json")
 		switch query.Get("query") {
+		case "unsigned-escrow-request":
+			response, err = UnsignedEscrowRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
+		case "unsigned-entrypoint-request":
+			response, err = UnsignedEntryPointRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
+		case "verify-signature":
+			response, err = VerifySignatureRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
+		case "asset-info":
+			response, err = AssetInfoRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
 		case "test":
-			var hellowWorld HelloWorld
-			hellowWorld.Test = "Hello, World!"
-
-			w.Header().Set("Content-Type", "application/json")
-			if err := json.NewEncoder(w).Encode(hellowWorld); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
+			response, err = TestRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
 		default:
-			http.Error(w, "Invalid query parameter", http.StatusBadRequest)
+			utils.WriteError(w, utils.ErrMalformedRequest("Invalid query parameter"))
 			return
 		}
 	}))
@@ -66,42 +75,11 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	handlerWithCORS.ServeHTTP(w, r)
 }
 
-func getSymbol(asset0, asset1 string) string {
-	if strings.ToUpper(asset0+asset1) == "BTCETH" {
-		return "ETHBTC"
-	}
-	return strings.ToUpper(asset0 + asset1)
-}
-
-func errMalformedRequest(w http.ResponseWriter) {
-	json.NewEncoder(w).Encode(&Error{
-		Code:    400,
-		Message: "Malformed request",
-	})
-}
-
-func errInternal(w http.ResponseWriter) {
-	json.NewEncoder(w).Encode(&Error{
-		Code:    500,
-		Message: "Internal server error",
-	})
-}
-
-func EnableCORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
-		w.Header().Set("Access-Control-Allow-Credentials", "true")
-
-		// Handle preflight requests
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+func HandleResponse(w http.ResponseWriter, r *http.Request, supabaseClient *supabase.Client, response interface{}, err error) {
+	if err != nil {
+		if logErr := db.LogError(supabaseClient, err, r.URL.Query().Get("query"), response); logErr != nil {
+			fmt.Printf("Failed to log error: %v\n", logErr.Error())
 		}
-		fmt.Printf("Method: %s, URL: %s", r.Method, r.URL)
-
-		next.ServeHTTP(w, r)
-	})
+	}
+	utils.WriteResponse(w, response, err)
 }
@@ -0,0 +1,79 @@
+package svmHandler
+
+import "encoding/base64"
+
+type EscrowAccountRaw struct {
+	Program      string `json:"program"`
+	Address      string `json:"address"`
+	Bump         string `json:"bump"`
+	IsInit       bool   `json:"init"`
+	AssetAddress string `json:"asset-address"`
+	AssetAmount  string `json:"asset-amount"`
+	AssetLocked  string `json:"asset-locked"`
+	Deadline     string `json:"asset-deadline"`
+}
+
+type InstructionRaw struct {
+	Program  string           `json:"program"`
+	Accounts []AccountMetaRaw `json:"accounts"`
+	Data     string           `json:"data"` // base64
+}
+
+type AccountMetaRaw struct {
+	Address    string `json:"address"`
+	IsSigner   bool   `json:"signer"`
+	IsWritable bool   `json:"writable"`
+}
+
+type TransactionRaw struct {
+	Blockhash            string   `json:"blockhash"`
+	LastValidBlockHeight string   `json:"last-valid-block-height"`
+	Signers              []string `json:"signers"`
+	Message              string   `json:"message"`     // base64 message bytes to sign
+	Transaction          string   `json:"transaction"` // base64 wire transaction with empty signatures
+}
+
+type MessageEscrowSvm struct {
+	Escrow      EscrowAccountRaw `json:"escrow"`
+	Instruction InstructionRaw   `json:"instruction"`
+	Transaction TransactionRaw   `json:"transaction"`
+}
+
+type MessageEntryPointSvm struct {
+	Owner        string           `json:"owner"`
+	Scheme       string           `json:"scheme"` // secp256k1 or ed25519
+	Proxy        string           `json:"proxy"`
+	ProxyBump    string           `json:"proxy-bump"`
+	IsInit       bool             `json:"init"`
+	Nonce        string           `json:"nonce"`
+	Message      string           `json:"message"`                // hex message the owner signs
+	Instructions []InstructionRaw `json:"instructions,omitempty"` // verify and execute, with a signature only
+	Transaction  *TransactionRaw  `json:"transaction,omitempty"`  // with a signature only
+}
+
+type VerifySignatureResponse struct {
+	Signer    string `json:"signer"`
+	Scheme    string `json:"scheme"`
+	Valid     bool   `json:"valid"`
+	Recovered string `json:"recovered,omitempty"` // secp256k1 only
+}
+
+type HelloWorld struct {
+	Test string `json:"test"`
+}
+
+func toInstructionRaw(instruction Instruction) InstructionRaw {
+	raw := InstructionRaw{
+		Program:  instruction.ProgramId.String(),
+		Accounts: []AccountMetaRaw{},
+		Data:     base64.StdEncoding.EncodeToString(instruction.Data),
+	}
+	for _, account := range instruction.Accounts {
+		raw.Accounts = append(raw.Accounts, AccountMetaRaw{
+			Address:    account.PublicKey.String(),
+			IsSigner:   account.IsSigner,
+			IsWritable: account.IsWritable,
+		})
+	}
+	return raw
+}
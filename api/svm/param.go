@@ -0,0 +1,30 @@
+package svmHandler
+
+import (
+	"math/big"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/bind"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+)
+
+type UnsignedEscrowRequestParams struct {
+	Header      utils.PartialHeader `query:"header"`
+	Asset       string              `query:"asset" optional:"true"`                           // spl mint, empty for lamports
+	Amount      uint64              `query:"amount" validate:"positive"`                      // lamports or token base units
+	LockSeconds *big.Int            `query:"lock-seconds" optional:"true" validate:"uint=63"` // default 3600, 0 deposits without a lock
+}
+
+type UnsignedEntryPointRequestParams struct {
+	Header    utils.MessageHeader `query:"header"`
+	Payload   string              `query:"payload" optional:"true" validate:"hex"`   // hex instruction data forwarded by the proxy
+	Accounts  string              `query:"accounts" optional:"true"`                 // comma separated accounts the payload touches, passed writable
+	Nonce     *big.Int            `query:"nonce" optional:"true" validate:"uint=64"` // default read from the proxy account
+	Signature string              `query:"signature" optional:"true" validate:"hex"` // hex signature of the owner over the entrypoint message
+	Relayer   string              `query:"relayer" optional:"true"`                  // fee payer of the transaction, required with a signature
+}
+
+type VerifySignatureRequestParams struct {
+	Signer    string        `query:"signer"` // hex evm address (secp256k1) or base58 solana key (ed25519)
+	Message   bind.HexBytes `query:"message"`
+	Signature bind.HexBytes `query:"signature"`
+}
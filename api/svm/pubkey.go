@@ -0,0 +1,159 @@
+package svmHandler
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+)
+
+// PublicKey is a 32 byte solana account address
+type PublicKey [32]byte
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// pda limits from the solana runtime
+const (
+	maxSeeds      = 16
+	maxSeedLength = 32
+)
+
+var (
+	SystemProgramId          = MustParsePublicKey("11111111111111111111111111111111")
+	TokenProgramId           = MustParsePublicKey("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	AssociatedTokenProgramId = MustParsePublicKey("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	Secp256k1ProgramId       = MustParsePublicKey("KeccakSecp256k11111111111111111111111111111")
+	Ed25519ProgramId         = MustParsePublicKey("Ed25519SigVerify111111111111111111111111111")
+	SysvarInstructionsId     = MustParsePublicKey("Sysvar1nstructions1111111111111111111111111")
+)
+
+func ParsePublicKey(s string) (PublicKey, error) {
+	var key PublicKey
+	decoded, err := base58Decode(s)
+	if err != nil {
+		return key, err
+	}
+	if len(decoded) != len(key) {
+		return key, fmt.Errorf("invalid public key length %d: %s", len(decoded), s)
+	}
+	copy(key[:], decoded)
+	return key, nil
+}
+
+func MustParsePublicKey(s string) PublicKey {
+	key, err := ParsePublicKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func (k PublicKey) String() string {
+	return base58Encode(k[:])
+}
+
+func (k PublicKey) IsZero() bool {
+	return k == PublicKey{}
+}
+
+// IsOnCurve reports if the key is a valid ed25519 point, program addresses never are
+func (k PublicKey) IsOnCurve() bool {
+	compressed, err := curve.NewCompressedEdwardsYFromBytes(k[:])
+	if err != nil {
+		return false
+	}
+	_, err = new(curve.EdwardsPoint).SetCompressedY(compressed)
+	return err == nil
+}
+
+// CreateProgramAddress mirrors Pubkey::create_program_address
+func CreateProgramAddress(seeds [][]byte, programId PublicKey) (PublicKey, error) {
+	if len(seeds) > maxSeeds {
+		return PublicKey{}, fmt.Errorf("too many seeds: %d", len(seeds))
+	}
+	hash := sha256.New()
+	for _, seed := range seeds {
+		if len(seed) > maxSeedLength {
+			return PublicKey{}, fmt.Errorf("seed longer than %d bytes", maxSeedLength)
+		}
+		hash.Write(seed)
+	}
+	hash.Write(programId[:])
+	hash.Write([]byte("ProgramDerivedAddress"))
+
+	var key PublicKey
+	copy(key[:], hash.Sum(nil))
+	if key.IsOnCurve() {
+		return PublicKey{}, fmt.Errorf("invalid seeds, address must fall off the curve")
+	}
+	return key, nil
+}
+
+// FindProgramAddress mirrors Pubkey::find_program_address, the bump is searched down from 255
+func FindProgramAddress(seeds [][]byte, programId PublicKey) (PublicKey, uint8, error) {
+	for bump := 255; bump >= 0; bump-- {
+		key, err := CreateProgramAddress(append(append([][]byte{}, seeds...), []byte{byte(bump)}), programId)
+		if err == nil {
+			return key, uint8(bump), nil
+		}
+	}
+	return PublicKey{}, 0, fmt.Errorf("unable to find a viable program address bump seed")
+}
+
+// FindAssociatedTokenAddress returns the spl token account of the owner for the mint
+func FindAssociatedTokenAddress(owner PublicKey, mint PublicKey) (PublicKey, error) {
+	key, _, err := FindProgramAddress([][]byte{owner[:], TokenProgramId[:], mint[:]}, AssociatedTokenProgramId)
+	return key, err
+}
+
+func base58Encode(input []byte) string {
+	zeros := 0
+	for zeros < len(input) && input[zeros] == 0 {
+		zeros++
+	}
+
+	value := new(big.Int).SetBytes(input)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var encoded []byte
+	for value.Sign() > 0 {
+		value.DivMod(value, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+func base58Decode(input string) ([]byte, error) {
+	if input == "" {
+		return nil, fmt.Errorf("empty base58 string")
+	}
+	value := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range []byte(input) {
+		digit := -1
+		for i := 0; i < len(base58Alphabet); i++ {
+			if base58Alphabet[i] == c {
+				digit = i
+				break
+			}
+		}
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		value.Mul(value, radix)
+		value.Add(value, big.NewInt(int64(digit)))
+	}
+
+	zeros := 0
+	for zeros < len(input) && input[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), value.Bytes()...), nil
+}
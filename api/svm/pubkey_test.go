@@ -0,0 +1,82 @@
+package svmHandler
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPublicKeyRoundTrip(t *testing.T) {
+	if SystemProgramId != (PublicKey{}) {
+		t.Fatalf("system program is not the zero key: %x", SystemProgramId[:])
+	}
+	for _, key := range []PublicKey{SystemProgramId, TokenProgramId, AssociatedTokenProgramId, Ed25519ProgramId} {
+		parsed, err := ParsePublicKey(key.String())
+		if err != nil || parsed != key {
+			t.Fatalf("round trip of %s: %v", key, err)
+		}
+	}
+	if _, err := ParsePublicKey("0OIl"); err == nil {
+		t.Fatal("invalid base58 accepted")
+	}
+	if _, err := ParsePublicKey("1111"); err == nil {
+		t.Fatal("short key accepted")
+	}
+}
+
+// vectors of Pubkey::create_program_address in solana-program
+func TestCreateProgramAddress(t *testing.T) {
+	program := MustParsePublicKey("BPFLoaderUpgradeab1e11111111111111111111111")
+	tests := []struct {
+		seeds [][]byte
+		want  string
+	}{
+		{[][]byte{{}, {1}}, "BwqrghZA2htAcqq8dzP1WDAhTXYTYWj7CHxF5j7TDBAe"},
+		{[][]byte{[]byte("☉"), {0}}, "13yWmRpaTR4r5nAktwLqMpRNr28tnVUZw26rTvPSSB19"},
+		{[][]byte{[]byte("Talking"), []byte("Squirrels")}, "2fnQrngrQT4SeLcdToJAD96phoEjNL2man2kfRLCASVk"},
+	}
+	for _, test := range tests {
+		key, err := CreateProgramAddress(test.seeds, program)
+		if err != nil {
+			t.Fatalf("seeds %q: %v", test.seeds, err)
+		}
+		if key.String() != test.want {
+			t.Fatalf("seeds %q: got %s, want %s", test.seeds, key, test.want)
+		}
+	}
+
+	if _, err := CreateProgramAddress([][]byte{bytes.Repeat([]byte{1}, maxSeedLength+1)}, program); err == nil {
+		t.Fatal("seed longer than the limit accepted")
+	}
+	if _, err := CreateProgramAddress(make([][]byte, maxSeeds+1), program); err == nil {
+		t.Fatal("too many seeds accepted")
+	}
+}
+
+func TestFindProgramAddress(t *testing.T) {
+	program := MustParsePublicKey("BPFLoaderUpgradeab1e11111111111111111111111")
+	owner := MustParsePublicKey("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	seeds := [][]byte{escrowSeed, owner[:]}
+
+	key, bump, err := FindProgramAddress(seeds, program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.IsOnCurve() {
+		t.Fatalf("program address %s is on the curve", key)
+	}
+	created, err := CreateProgramAddress(append(seeds, []byte{bump}), program)
+	if err != nil || created != key {
+		t.Fatalf("bump %d does not recreate %s: %v", bump, key, err)
+	}
+	// the first viable bump from 255 down is the canonical one
+	for higher := 255; higher > int(bump); higher-- {
+		if _, err := CreateProgramAddress(append(seeds, []byte{byte(higher)}), program); err == nil {
+			t.Fatalf("bump %d is viable but %d was returned", higher, bump)
+		}
+	}
+
+	escrow, escrowBump, err := FindEscrowAddress(program, owner)
+	if err != nil || escrow != key || escrowBump != bump {
+		t.Fatalf("escrow pda %s/%d, want %s/%d: %v", escrow, escrowBump, key, bump, err)
+	}
+}
@@ -0,0 +1,119 @@
+package svmHandler
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const commitment = "confirmed"
+
+// AccountInfo is the decoded value of getAccountInfo
+type AccountInfo struct {
+	Lamports   uint64
+	Owner      PublicKey
+	Data       []byte
+	Executable bool
+}
+
+type TokenAmount struct {
+	Amount         string `json:"amount"`
+	Decimals       uint8  `json:"decimals"`
+	UiAmountString string `json:"uiAmountString"`
+}
+
+func GetLatestBlockhash(ctx context.Context, client *rpc.Client) (PublicKey, uint64, error) {
+	var result struct {
+		Value struct {
+			Blockhash            string `json:"blockhash"`
+			LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+		} `json:"value"`
+	}
+	if err := client.CallContext(ctx, &result, "getLatestBlockhash", map[string]string{"commitment": commitment}); err != nil {
+		return PublicKey{}, 0, fmt.Errorf("getLatestBlockhash failed: %v", err)
+	}
+	blockhash, err := ParsePublicKey(result.Value.Blockhash)
+	if err != nil {
+		return PublicKey{}, 0, fmt.Errorf("invalid blockhash: %v", err)
+	}
+	return blockhash, result.Value.LastValidBlockHeight, nil
+}
+
+// GetAccountInfo returns nil when the account does not exist
+func GetAccountInfo(ctx context.Context, client *rpc.Client, account PublicKey) (*AccountInfo, error) {
+	var result struct {
+		Value *struct {
+			Lamports   uint64    `json:"lamports"`
+			Owner      string    `json:"owner"`
+			Data       [2]string `json:"data"`
+			Executable bool      `json:"executable"`
+		} `json:"value"`
+	}
+	options := map[string]string{"encoding": "base64", "commitment": commitment}
+	if err := client.CallContext(ctx, &result, "getAccountInfo", account.String(), options); err != nil {
+		return nil, fmt.Errorf("getAccountInfo failed: %v", err)
+	}
+	if result.Value == nil {
+		return nil, nil
+	}
+
+	owner, err := ParsePublicKey(result.Value.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account owner: %v", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(result.Value.Data[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid account data: %v", err)
+	}
+	return &AccountInfo{
+		Lamports:   result.Value.Lamports,
+		Owner:      owner,
+		Data:       data,
+		Executable: result.Value.Executable,
+	}, nil
+}
+
+func GetBalance(ctx context.Context, client *rpc.Client, account PublicKey) (uint64, error) {
+	var result struct {
+		Value uint64 `json:"value"`
+	}
+	if err := client.CallContext(ctx, &result, "getBalance", account.String(), map[string]string{"commitment": commitment}); err != nil {
+		return 0, fmt.Errorf("getBalance failed: %v", err)
+	}
+	return result.Value, nil
+}
+
+func GetTokenSupply(ctx context.Context, client *rpc.Client, mint PublicKey) (TokenAmount, error) {
+	var result struct {
+		Value TokenAmount `json:"value"`
+	}
+	if err := client.CallContext(ctx, &result, "getTokenSupply", mint.String(), map[string]string{"commitment": commitment}); err != nil {
+		return TokenAmount{}, fmt.Errorf("getTokenSupply failed: %v", err)
+	}
+	return result.Value, nil
+}
+
+// GetTokenBalance returns the spl balance of the owner's associated token account, zero when it does not exist
+func GetTokenBalance(ctx context.Context, client *rpc.Client, owner PublicKey, mint PublicKey) (string, error) {
+	tokenAccount, err := FindAssociatedTokenAddress(owner, mint)
+	if err != nil {
+		return "", err
+	}
+	info, err := GetAccountInfo(ctx, client, tokenAccount)
+	if err != nil {
+		return "", err
+	}
+	if info == nil {
+		return "0", nil
+	}
+
+	var result struct {
+		Value TokenAmount `json:"value"`
+	}
+	if err := client.CallContext(ctx, &result, "getTokenAccountBalance", tokenAccount.String(), map[string]string{"commitment": commitment}); err != nil {
+		return "", fmt.Errorf("getTokenAccountBalance failed: %v", err)
+	}
+	return result.Value.Amount, nil
+}
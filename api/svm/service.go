@@ -0,0 +1,436 @@
+package svmHandler
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/rpcpool"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const rpcTimeout = 15 * time.Second
+
+func TestRequest(r *http.Request, parameters ...interface{}) (interface{}, error) {
+	return HelloWorld{Test: "Hello, World!"}, nil
+}
+
+// UnsignedEscrowRequest returns the unsigned deposit, or deposit and lock, transaction of the signer's escrow pda
+func UnsignedEscrowRequest(r *http.Request, parameters ...*UnsignedEscrowRequestParams) (interface{}, error) {
+	var params *UnsignedEscrowRequestParams
+
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &UnsignedEscrowRequestParams{}
+	}
+
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	var errorStr string
+	params.Header.ChainId, params.Header.ChainType, params.Header.ChainName, errorStr = utils.CheckChainPartialType(params.Header.ChainId, "escrow", params.Header.TxType)
+	if errorStr != "" {
+		return nil, utils.ErrMalformedRequest(errorStr)
+	}
+
+	chain, err := chains.Get(params.Header.ChainId)
+	if err != nil {
+		return nil, utils.Err(apierr.UnsupportedChain, err.Error())
+	}
+	escrowProgram, _, err := programs(chain)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	if escrowProgram.IsZero() {
+		return nil, utils.ErrMalformedRequest(fmt.Sprintf("escrow not configured for chain id: %s", chain.ID))
+	}
+
+	signer, err := ParsePublicKey(params.Header.ChainSigner)
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid signer: %v", err))
+	}
+	var mint PublicKey
+	if params.Asset != "" {
+		if mint, err = ParsePublicKey(params.Asset); err != nil {
+			return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid asset: %v", err))
+		}
+	}
+	amount := params.Amount
+	if amount == 0 {
+		return nil, utils.ErrMalformedRequest("invalid amount")
+	}
+	lockSeconds := int64(defaultLockSeconds)
+	if params.LockSeconds != nil {
+		lockSeconds = params.LockSeconds.Int64()
+	}
+
+	client, err := rpcpool.SvmClient(chain.ID)
+	if err != nil {
+		return nil, utils.Err(apierr.RpcFailure, err.Error())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	escrow, err := escrowAccount(ctx, client, escrowProgram, signer, mint)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+
+	var instruction Instruction
+	if lockSeconds == 0 {
+		instruction, err = DepositInstruction(escrowProgram, signer, mint, amount)
+	} else {
+		instruction, err = DepositAndLockInstruction(escrowProgram, signer, mint, amount, lockSeconds)
+	}
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	transaction, err := buildTransaction(ctx, client, signer, []Instruction{instruction})
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+
+	return MessageEscrowSvm{
+		Escrow:      escrow,
+		Instruction: toInstructionRaw(instruction),
+		Transaction: transaction,
+	}, nil
+}
+
+// UnsignedEntryPointRequest returns the message the owner signs for a proxy execution, with a signature it
+// also returns the verify and execute instructions in an unsigned transaction paid by the relayer
+func UnsignedEntryPointRequest(r *http.Request, parameters ...*UnsignedEntryPointRequestParams) (interface{}, error) {
+	var params *UnsignedEntryPointRequestParams
+
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &UnsignedEntryPointRequestParams{}
+	}
+
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	var errorStr string
+	params.Header.ToChainId, params.Header.ToChainType, params.Header.ToChainName, errorStr = utils.CheckChainPartialType(params.Header.ToChainId, "entrypoint", params.Header.TxType)
+	if errorStr != "" {
+		return nil, utils.ErrMalformedRequest(errorStr)
+	}
+
+	chain, err := chains.Get(params.Header.ToChainId)
+	if err != nil {
+		return nil, utils.Err(apierr.UnsupportedChain, err.Error())
+	}
+	_, entrypointProgram, err := programs(chain)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	if entrypointProgram.IsZero() {
+		return nil, utils.ErrMalformedRequest(fmt.Sprintf("entrypoint not configured for chain id: %s", chain.ID))
+	}
+
+	owner, scheme, err := parseOwner(params.Header.ToChainSigner)
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid destination signer: %v", err))
+	}
+	payload, err := hex.DecodeString(strings.TrimPrefix(params.Payload, "0x"))
+	if err != nil {
+		return nil, utils.ErrMalformedRequest("invalid payload")
+	}
+	var remaining []AccountMeta
+	if params.Accounts != "" {
+		for _, account := range strings.Split(params.Accounts, ",") {
+			key, err := ParsePublicKey(strings.TrimSpace(account))
+			if err != nil {
+				return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid account: %v", err))
+			}
+			remaining = append(remaining, AccountMeta{PublicKey: key, IsWritable: true})
+		}
+	}
+
+	proxy, bump, err := FindProxyAddress(entrypointProgram, owner)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+
+	client, err := rpcpool.SvmClient(chain.ID)
+	if err != nil {
+		return nil, utils.Err(apierr.RpcFailure, err.Error())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	info, err := GetAccountInfo(ctx, client, proxy)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	var nonce uint64
+	if params.Nonce != nil {
+		nonce = params.Nonce.Uint64()
+	} else if info != nil && len(info.Data) >= proxyNonceOffset+8 {
+		nonce = binary.LittleEndian.Uint64(info.Data[proxyNonceOffset:])
+	}
+
+	message := EntrypointMessage(entrypointProgram, proxy, nonce, payload)
+	response := MessageEntryPointSvm{
+		Owner:     params.Header.ToChainSigner,
+		Scheme:    scheme,
+		Proxy:     proxy.String(),
+		ProxyBump: strconv.Itoa(int(bump)),
+		IsInit:    info != nil,
+		Nonce:     strconv.FormatUint(nonce, 10),
+		Message:   hex.EncodeToString(message),
+	}
+	if params.Signature == "" {
+		return response, nil
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(params.Signature, "0x"))
+	if err != nil {
+		return nil, utils.ErrMalformedRequest("invalid signature")
+	}
+	valid, _, err := verifyOwnerSignature(owner, scheme, message, signature)
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(err.Error())
+	}
+	if !valid {
+		return nil, utils.Err(apierr.SignatureInvalid, "signature does not match the destination signer")
+	}
+
+	// the verify instruction comes first, the entrypoint reads it through the instructions sysvar
+	var verify Instruction
+	if scheme == "secp256k1" {
+		verify, err = Secp256k1Instruction(0, common.BytesToAddress(owner), signature, message)
+	} else {
+		verify, err = Ed25519Instruction(PublicKey(owner), signature, message)
+	}
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(err.Error())
+	}
+
+	relayer, err := ParsePublicKey(params.Relayer)
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid relayer: %v", err))
+	}
+	execute, err := ExecuteInstruction(entrypointProgram, relayer, owner, nonce, payload, remaining)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	transaction, err := buildTransaction(ctx, client, relayer, []Instruction{verify, execute})
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	response.Instructions = []InstructionRaw{toInstructionRaw(verify), toInstructionRaw(execute)}
+	response.Transaction = &transaction
+	return response, nil
+}
+
+// VerifySignatureRequest checks a signature the way the native verify programs do
+func VerifySignatureRequest(r *http.Request, parameters ...*VerifySignatureRequestParams) (interface{}, error) {
+	var params *VerifySignatureRequestParams
+
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &VerifySignatureRequestParams{}
+	}
+
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	owner, scheme, err := parseOwner(params.Signer)
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid signer: %v", err))
+	}
+	valid, recovered, err := verifyOwnerSignature(owner, scheme, params.Message, params.Signature)
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(err.Error())
+	}
+	return VerifySignatureResponse{
+		Signer:    params.Signer,
+		Scheme:    scheme,
+		Valid:     valid,
+		Recovered: recovered,
+	}, nil
+}
+
+// AssetInfoRequest returns the lamport or spl balances of the user and its escrow pda
+func AssetInfoRequest(r *http.Request, parameters ...*utils.AssetInfoRequestParams) (interface{}, error) {
+	var params *utils.AssetInfoRequestParams
+
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &utils.AssetInfoRequestParams{}
+	}
+
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	chain, err := chains.Get(params.ChainId)
+	if err != nil {
+		return nil, utils.Err(apierr.UnsupportedChain, err.Error())
+	}
+	user, err := ParsePublicKey(params.UserAddress)
+	if err != nil {
+		return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid user-address: %v", err))
+	}
+	// the native asset is the empty or system program address
+	var mint PublicKey
+	if params.AssetAddress != "" && params.AssetAddress != SystemProgramId.String() {
+		if mint, err = ParsePublicKey(params.AssetAddress); err != nil {
+			return nil, utils.ErrMalformedRequest(fmt.Sprintf("invalid asset-address: %v", err))
+		}
+	}
+
+	client, err := rpcpool.SvmClient(chain.ID)
+	if err != nil {
+		return nil, utils.Err(apierr.RpcFailure, err.Error())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	response := &utils.AssetInfoRequestResponse{}
+	response.ChainId = chain.ID
+	response.VM = chain.VM
+	response.Name = chain.Name
+	response.Asset.Address = SystemProgramId.String()
+	response.Asset.Name = "Solana"
+	response.Asset.Symbol = "SOL"
+	response.Asset.Decimal = "9"
+
+	if mint.IsZero() {
+		balance, err := GetBalance(ctx, client, user)
+		if err != nil {
+			return nil, utils.ErrInternal(err.Error())
+		}
+		response.User.Balance = strconv.FormatUint(balance, 10)
+	} else {
+		supply, err := GetTokenSupply(ctx, client, mint)
+		if err != nil {
+			return nil, utils.ErrInternal(fmt.Sprintf("asset could not be found: %v", err))
+		}
+		response.Asset.Address = mint.String()
+		response.Asset.Name = ""
+		response.Asset.Symbol = ""
+		response.Asset.Decimal = strconv.Itoa(int(supply.Decimals))
+		response.Asset.TotalSupply = supply.Amount
+		response.Asset.Supply = supply.Amount
+
+		if response.User.Balance, err = GetTokenBalance(ctx, client, user, mint); err != nil {
+			return nil, utils.ErrInternal(err.Error())
+		}
+	}
+
+	escrowProgram, _, err := programs(chain)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	if !escrowProgram.IsZero() {
+		escrow, err := escrowAccount(ctx, client, escrowProgram, user, mint)
+		if err != nil {
+			return nil, utils.ErrInternal(err.Error())
+		}
+		response.Escrow.Init = escrow.IsInit
+		response.Escrow.Balance = escrow.AssetAmount
+		response.Escrow.LockBalance = escrow.AssetLocked
+		response.Escrow.LockDeadline = escrow.Deadline
+	}
+
+	return response, nil
+}
+
+// escrowAccount reads the escrow pda of the owner, the balance excludes the rent of the account
+func escrowAccount(ctx context.Context, client *rpc.Client, escrowProgram PublicKey, owner PublicKey, mint PublicKey) (EscrowAccountRaw, error) {
+	escrow, bump, err := FindEscrowAddress(escrowProgram, owner)
+	if err != nil {
+		return EscrowAccountRaw{}, err
+	}
+	raw := EscrowAccountRaw{
+		Program:      escrowProgram.String(),
+		Address:      escrow.String(),
+		Bump:         strconv.Itoa(int(bump)),
+		AssetAddress: SystemProgramId.String(),
+		AssetAmount:  "0",
+		AssetLocked:  "0",
+		Deadline:     "0",
+	}
+	if !mint.IsZero() {
+		raw.AssetAddress = mint.String()
+	}
+
+	info, err := GetAccountInfo(ctx, client, escrow)
+	if err != nil {
+		return raw, err
+	}
+	if info == nil {
+		return raw, nil
+	}
+	raw.IsInit = true
+
+	if mint.IsZero() {
+		var rent uint64
+		if err := client.CallContext(ctx, &rent, "getMinimumBalanceForRentExemption", len(info.Data)); err != nil {
+			return raw, fmt.Errorf("getMinimumBalanceForRentExemption failed: %v", err)
+		}
+		if info.Lamports > rent {
+			raw.AssetAmount = strconv.FormatUint(info.Lamports-rent, 10)
+		}
+	} else if raw.AssetAmount, err = GetTokenBalance(ctx, client, escrow, mint); err != nil {
+		return raw, err
+	}
+
+	if len(info.Data) >= escrowAccountSize {
+		raw.AssetLocked = strconv.FormatUint(binary.LittleEndian.Uint64(info.Data[escrowLockedOffset:]), 10)
+		raw.Deadline = strconv.FormatInt(int64(binary.LittleEndian.Uint64(info.Data[escrowDeadlineOffset:])), 10)
+	}
+	return raw, nil
+}
+
+// parseOwner accepts a hex evm address, verified with secp256k1, or a base58 solana key, verified with ed25519
+func parseOwner(signer string) ([]byte, string, error) {
+	if common.IsHexAddress(signer) {
+		return common.HexToAddress(signer).Bytes(), "secp256k1", nil
+	}
+	key, err := ParsePublicKey(signer)
+	if err != nil {
+		return nil, "", err
+	}
+	return key[:], "ed25519", nil
+}
+
+func verifyOwnerSignature(owner []byte, scheme string, message []byte, signature []byte) (bool, string, error) {
+	if scheme == "secp256k1" {
+		recovered, err := RecoverSecp256k1(message, signature)
+		if err != nil {
+			return false, "", err
+		}
+		return recovered == common.BytesToAddress(owner), recovered.Hex(), nil
+	}
+	if len(signature) != 64 {
+		return false, "", fmt.Errorf("ed25519 signature must be 64 bytes, got %d", len(signature))
+	}
+	return VerifyEd25519(PublicKey(owner), message, signature), "", nil
+}
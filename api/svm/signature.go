@@ -0,0 +1,43 @@
+package svmHandler
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// VerifyEd25519 checks a signature of a solana signer
+func VerifyEd25519(publicKey PublicKey, message []byte, signature []byte) bool {
+	if len(signature) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(publicKey[:], message, signature)
+}
+
+// VerifySecp256k1 checks a signature the way the native secp256k1 program does,
+// the signer signs keccak256(message) without the ethereum message prefix
+func VerifySecp256k1(ethAddress common.Address, message []byte, signature []byte) (bool, error) {
+	recovered, err := RecoverSecp256k1(message, signature)
+	if err != nil {
+		return false, err
+	}
+	return recovered == ethAddress, nil
+}
+
+// RecoverSecp256k1 returns the eth address that signed keccak256(message)
+func RecoverSecp256k1(message []byte, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("secp256k1 signature must be %d bytes, got %d", crypto.SignatureLength, len(signature))
+	}
+	sig := append([]byte{}, signature...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	publicKey, err := crypto.SigToPub(crypto.Keccak256(message), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
@@ -0,0 +1,165 @@
+package svmHandler
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type AccountMeta struct {
+	PublicKey  PublicKey
+	IsSigner   bool
+	IsWritable bool
+}
+
+type Instruction struct {
+	ProgramId PublicKey
+	Accounts  []AccountMeta
+	Data      []byte
+}
+
+// Message is a compiled legacy transaction message
+type Message struct {
+	NumRequiredSignatures       uint8
+	NumReadonlySignedAccounts   uint8
+	NumReadonlyUnsignedAccounts uint8
+	AccountKeys                 []PublicKey
+	RecentBlockhash             PublicKey
+	Instructions                []compiledInstruction
+}
+
+type compiledInstruction struct {
+	ProgramIdIndex uint8
+	Accounts       []uint8
+	Data           []byte
+}
+
+// CompileMessage orders the accounts the way the runtime expects: the fee payer, writable signers,
+// readonly signers, writable accounts and then readonly accounts including the programs
+func CompileMessage(feePayer PublicKey, instructions []Instruction, recentBlockhash PublicKey) (*Message, error) {
+	metas := []AccountMeta{{PublicKey: feePayer, IsSigner: true, IsWritable: true}}
+	index := map[PublicKey]int{feePayer: 0}
+	add := func(meta AccountMeta) {
+		if i, found := index[meta.PublicKey]; found {
+			metas[i].IsSigner = metas[i].IsSigner || meta.IsSigner
+			metas[i].IsWritable = metas[i].IsWritable || meta.IsWritable
+			return
+		}
+		index[meta.PublicKey] = len(metas)
+		metas = append(metas, meta)
+	}
+	for _, instruction := range instructions {
+		for _, account := range instruction.Accounts {
+			add(account)
+		}
+		add(AccountMeta{PublicKey: instruction.ProgramId})
+	}
+
+	var ordered []AccountMeta
+	for _, group := range []struct{ signer, writable bool }{{true, true}, {true, false}, {false, true}, {false, false}} {
+		for _, meta := range metas {
+			if meta.IsSigner == group.signer && meta.IsWritable == group.writable {
+				ordered = append(ordered, meta)
+			}
+		}
+	}
+	if len(ordered) > 256 {
+		return nil, fmt.Errorf("too many accounts: %d", len(ordered))
+	}
+
+	message := &Message{RecentBlockhash: recentBlockhash}
+	position := make(map[PublicKey]uint8, len(ordered))
+	for i, meta := range ordered {
+		position[meta.PublicKey] = uint8(i)
+		message.AccountKeys = append(message.AccountKeys, meta.PublicKey)
+		switch {
+		case meta.IsSigner && !meta.IsWritable:
+			message.NumRequiredSignatures++
+			message.NumReadonlySignedAccounts++
+		case meta.IsSigner:
+			message.NumRequiredSignatures++
+		case !meta.IsWritable:
+			message.NumReadonlyUnsignedAccounts++
+		}
+	}
+
+	for _, instruction := range instructions {
+		compiled := compiledInstruction{ProgramIdIndex: position[instruction.ProgramId], Data: instruction.Data}
+		for _, account := range instruction.Accounts {
+			compiled.Accounts = append(compiled.Accounts, position[account.PublicKey])
+		}
+		message.Instructions = append(message.Instructions, compiled)
+	}
+	return message, nil
+}
+
+// Serialize returns the message bytes every signer signs
+func (m *Message) Serialize() []byte {
+	data := []byte{m.NumRequiredSignatures, m.NumReadonlySignedAccounts, m.NumReadonlyUnsignedAccounts}
+	data = appendCompactU16(data, len(m.AccountKeys))
+	for _, key := range m.AccountKeys {
+		data = append(data, key[:]...)
+	}
+	data = append(data, m.RecentBlockhash[:]...)
+	data = appendCompactU16(data, len(m.Instructions))
+	for _, instruction := range m.Instructions {
+		data = append(data, instruction.ProgramIdIndex)
+		data = appendCompactU16(data, len(instruction.Accounts))
+		data = append(data, instruction.Accounts...)
+		data = appendCompactU16(data, len(instruction.Data))
+		data = append(data, instruction.Data...)
+	}
+	return data
+}
+
+// Signers returns the accounts that have to sign the message, in signature order
+func (m *Message) Signers() []PublicKey {
+	return m.AccountKeys[:m.NumRequiredSignatures]
+}
+
+// UnsignedTransaction returns the base64 wire transaction with zeroed signatures, wallets fill them in
+func (m *Message) UnsignedTransaction() string {
+	data := appendCompactU16(nil, int(m.NumRequiredSignatures))
+	data = append(data, make([]byte, 64*int(m.NumRequiredSignatures))...)
+	data = append(data, m.Serialize()...)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// appendCompactU16 appends the shortvec length encoding used by solana
+func appendCompactU16(data []byte, n int) []byte {
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(data, b)
+		}
+		data = append(data, b|0x80)
+	}
+}
+
+// buildTransaction compiles the instructions against the latest blockhash
+func buildTransaction(ctx context.Context, client *rpc.Client, feePayer PublicKey, instructions []Instruction) (TransactionRaw, error) {
+	blockhash, lastValidBlockHeight, err := GetLatestBlockhash(ctx, client)
+	if err != nil {
+		return TransactionRaw{}, err
+	}
+	message, err := CompileMessage(feePayer, instructions, blockhash)
+	if err != nil {
+		return TransactionRaw{}, err
+	}
+
+	signers := []string{}
+	for _, signer := range message.Signers() {
+		signers = append(signers, signer.String())
+	}
+	return TransactionRaw{
+		Blockhash:            blockhash.String(),
+		LastValidBlockHeight: strconv.FormatUint(lastValidBlockHeight, 10),
+		Signers:              signers,
+		Message:              base64.StdEncoding.EncodeToString(message.Serialize()),
+		Transaction:          message.UnsignedTransaction(),
+	}, nil
+}
@@ -0,0 +1,103 @@
+package svmHandler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func TestAppendCompactU16(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []byte
+	}{
+		{0, []byte{0x00}},
+		{0x7f, []byte{0x7f}},
+		{0x80, []byte{0x80, 0x01}},
+		{0x3fff, []byte{0xff, 0x7f}},
+		{0x4000, []byte{0x80, 0x80, 0x01}},
+		{0xffff, []byte{0xff, 0xff, 0x03}},
+	}
+	for _, test := range tests {
+		if got := appendCompactU16(nil, test.n); !bytes.Equal(got, test.want) {
+			t.Fatalf("%d: got %x, want %x", test.n, got, test.want)
+		}
+	}
+}
+
+func key(b byte) PublicKey {
+	var k PublicKey
+	k[0] = b
+	return k
+}
+
+func TestCompileMessage(t *testing.T) {
+	var (
+		payer          = key(1)
+		readonlySigner = key(2)
+		writable       = key(3)
+		readonly       = key(4)
+		program        = key(5)
+		blockhash      = key(6)
+	)
+	instructions := []Instruction{
+		{
+			ProgramId: program,
+			Accounts: []AccountMeta{
+				{PublicKey: readonly},
+				{PublicKey: readonlySigner, IsSigner: true},
+				{PublicKey: writable},
+			},
+			Data: []byte{0xaa},
+		},
+		{
+			// a later writable meta upgrades the account, the payer is not listed twice
+			ProgramId: program,
+			Accounts: []AccountMeta{
+				{PublicKey: writable, IsWritable: true},
+				{PublicKey: payer, IsSigner: true, IsWritable: true},
+			},
+			Data: []byte{0xbb, 0xcc},
+		},
+	}
+	message, err := CompileMessage(payer, instructions, blockhash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantKeys := []PublicKey{payer, readonlySigner, writable, readonly, program}
+	if len(message.AccountKeys) != len(wantKeys) {
+		t.Fatalf("got %d accounts, want %d", len(message.AccountKeys), len(wantKeys))
+	}
+	for i, want := range wantKeys {
+		if message.AccountKeys[i] != want {
+			t.Fatalf("account %d: got %x, want %x", i, message.AccountKeys[i][:1], want[:1])
+		}
+	}
+	if message.NumRequiredSignatures != 2 || message.NumReadonlySignedAccounts != 1 || message.NumReadonlyUnsignedAccounts != 2 {
+		t.Fatalf("header %d %d %d, want 2 1 2", message.NumRequiredSignatures, message.NumReadonlySignedAccounts, message.NumReadonlyUnsignedAccounts)
+	}
+	if signers := message.Signers(); len(signers) != 2 || signers[0] != payer || signers[1] != readonlySigner {
+		t.Fatalf("unexpected signers %v", signers)
+	}
+
+	want := []byte{2, 1, 2, 5}
+	for _, k := range wantKeys {
+		want = append(want, k[:]...)
+	}
+	want = append(want, blockhash[:]...)
+	want = append(want, 2)
+	want = append(want, 4, 3, 3, 1, 2, 1, 0xaa)
+	want = append(want, 4, 2, 2, 0, 2, 0xbb, 0xcc)
+	if got := message.Serialize(); !bytes.Equal(got, want) {
+		t.Fatalf("serialized message\n got %x\nwant %x", got, want)
+	}
+
+	wire, err := base64.StdEncoding.DecodeString(message.UnsignedTransaction())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wire[0] != 2 || !bytes.Equal(wire[1:129], make([]byte, 128)) || !bytes.Equal(wire[129:], want) {
+		t.Fatalf("unexpected wire transaction %x", wire)
+	}
+}
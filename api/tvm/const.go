@@ -2,10 +2,15 @@ package tvmHandler
 
 import (
 	"encoding/hex"
+	"fmt"
 
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/boc"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 	"github.com/xssnick/tonutils-go/address"
 	"github.com/xssnick/tonutils-go/tlb"
+	"github.com/xssnick/tonutils-go/tvm/cell"
 )
 
 var escrowCodeHex = "b5ee9c724102060100011e000114ff00f4a413f4bcf2c80b01020162020502f8d06c2220c700915be001d0d3030171b0915be0fa403001d31fed44d0fa4001f861fa4001f862d39f01f863d3ff01f864d401d0f866d430d0fa0030f86521c01ee30221c01f8e2031f84212c705f2e192708018c8cb0502fa403012cf1621fa02cb6ac98306fb00e030312082103b307c4bba9130e0208210622117e90304007631f84112c705f2e191d430d0f866f846d749810208baf2e1f5c8f845fa02c9c8f846cf16c9f844f843c8f841cf16f842cf16cb9fcbffccccc9ed540024ba9130e020c01e9130e082100b7ba46fbadc0071a04e37da89a1f48003f0c3f48003f0c5a73e03f0c7a7fe03f0c9a803a1f0cda861a1f40061f0cbf08da60ff0cdf08da7fff0cdf08da7fff0cd5649929b"
@@ -14,7 +19,33 @@ var proxyWalletCodeHex = "b5ee9c7241010a01008b000114ff00f4a413f4bcf2c80b01020162
 var escrowCodeBytes, _ = utils.HexToBytes(escrowCodeHex)
 var proxyWalletCodeBytes, _ = hex.DecodeString(proxyWalletCodeHex)
 
-var entryPointAddress, _ = address.ParseAddr("kQAGJK50PW_a1ZbQWK0yldegu56FlX0nXKQIa7xzoWCzQiV2")
+// codeCell decodes a compiled code bag with pkg/boc, the bags of the js sdk round-trip byte for byte
+func codeCell(code []byte) (*cell.Cell, error) {
+	root, err := boc.DecodeRoot(code)
+	if err != nil {
+		return nil, err
+	}
+	return root.ToCell()
+}
+
+// testnetChainId is the ton testnet of the registry, requests without a tvm chain id use it
+const testnetChainId = "1667471769"
+
+// entrypointOf reads the entrypoint of a tvm chain from the registry
+func entrypointOf(chainId string) (*address.Address, error) {
+	chain, err := chains.Get(chainId)
+	if err != nil {
+		return nil, utils.Err(apierr.UnsupportedChain, err.Error())
+	}
+	if chain.VM != "tvm" {
+		return nil, utils.Err(apierr.UnsupportedChain, fmt.Sprintf("chain %s is not a tvm chain", chain.ID))
+	}
+	entrypoint, err := address.ParseAddr(chain.Contracts.Entrypoint)
+	if err != nil {
+		return nil, utils.ErrInternal(fmt.Sprintf("chain %s has no valid entrypoint: %v", chain.ID, err))
+	}
+	return entrypoint, nil
+}
 
 var TestnetInfo = &tlb.BlockInfo{
 	Workchain: -1,
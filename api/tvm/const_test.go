@@ -0,0 +1,71 @@
+package tvmHandler
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/boc"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+// the compiled code fixtures are bags of the js sdk, pkg/boc must re-encode them byte for byte
+// and agree with tonutils-go on every hash
+func TestCodeFixturesRoundTrip(t *testing.T) {
+	fixtures := map[string][]byte{
+		"escrowCodeHex":         escrowCodeBytes,
+		"proxyWalletCodeHex":    proxyWalletCodeBytes,
+		"escrowBocBuffer":       escrowBocBuffer,
+		"proxyWalletBocBuffer":  proxyWalletBocBuffer,
+		"jettonMinterBocBuffer": jettonMinterBocBuffer,
+		"jettonWalletBocBuffer": jettonWalletBocBuffer,
+	}
+	for name, data := range fixtures {
+		t.Run(name, func(t *testing.T) {
+			root, err := boc.DecodeRoot(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if encoded := root.ToBOC(); !bytes.Equal(encoded, data) {
+				t.Fatalf("re-encoded bag differs\n got %x\nwant %x", encoded, data)
+			}
+
+			reference, err := cell.FromBOC(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(root.Hash(), reference.Hash()) {
+				t.Fatalf("hash %x, tonutils %x", root.Hash(), reference.Hash())
+			}
+			if root.Depth() != reference.Depth() {
+				t.Fatalf("depth %d, tonutils %d", root.Depth(), reference.Depth())
+			}
+
+			converted, err := codeCell(data)
+			if err != nil || !bytes.Equal(converted.Hash(), reference.Hash()) {
+				t.Fatalf("codeCell does not match tonutils: %v", err)
+			}
+		})
+	}
+}
+
+func TestEntrypointOf(t *testing.T) {
+	chain, err := chains.Get(testnetChainId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entrypoint, err := entrypointOf("0x63639999")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entrypoint.String() != chain.Contracts.Entrypoint {
+		t.Fatalf("entrypoint %s, registry %s", entrypoint, chain.Contracts.Entrypoint)
+	}
+
+	for _, chainId := range []string{"11155111", "1"} {
+		if _, err := entrypointOf(chainId); !apierr.Is(err, apierr.UnsupportedChain) {
+			t.Fatalf("%s: err %v", chainId, err)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package tvmHandler
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/entrypoint"
+	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/proxyWallet"
+	"github.com/crosscall-labs/crosschain-api/pkg/tonx"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
+	"github.com/xssnick/tonutils-go/ton/wallet"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+// gas budgets of our contracts, the estimate can not emulate internal messages
+const (
+	entrypointGas  = 15000
+	proxyWalletGas = 25000 // includes the ecrecover of the signature
+	proxyDeployGas = 5000
+)
+
+const (
+	feeMarginBps   = 2000 // added to the internal steps, estimateFee covers the wallet step exactly
+	feeConfigTTL   = 10 * time.Minute
+	entrypointStep = "entrypoint"
+	walletStep     = "wallet"
+	deployStep     = "proxy-deploy"
+	proxyStep      = "proxy"
+)
+
+type FeeItem struct {
+	Step    string `json:"step"`
+	Gas     string `json:"gas"`     // nanotons
+	Storage string `json:"storage"` // nanotons
+	Forward string `json:"forward"` // nanotons
+	Total   string `json:"total"`   // nanotons
+}
+
+type FeeQuote struct {
+	WithProxyInit bool      `json:"with-proxy-init"`
+	Items         []FeeItem `json:"items"`
+	Margin        string    `json:"margin"` // nanotons added to the internal steps
+	Total         string    `json:"total"`  // nanotons, margin included
+}
+
+// entryPointPlan is what the backend wallet sends for a proxy message, state is nil once the proxy is deployed
+type entryPointPlan struct {
+	Entrypoint   *address.Address
+	Proxy        *address.Address
+	State        *tlb.StateInit
+	ProxyMessage proxyWallet.ProxyWalletMessage
+}
+
+type entryPointFees struct {
+	Quote            FeeQuote
+	DeployAmount     uint64 // nanotons sent with the proxy deploy
+	EntrypointAmount uint64 // nanotons sent to the entrypoint, the execution value included
+	Total            uint64 // nanotons of every fee, the quote total
+}
+
+type gasPrices struct {
+	flatGasLimit uint64
+	flatGasPrice uint64
+	gasPrice     uint64 // nanotons per 65536 gas
+}
+
+type forwardPrices struct {
+	lumpPrice uint64
+	bitPrice  uint64 // nanotons per 65536 bits
+	cellPrice uint64 // nanotons per 65536 cells
+}
+
+type feeConfig struct {
+	gas       gasPrices
+	forward   forwardPrices
+	fetchedAt time.Time
+}
+
+var feeConfigs = struct {
+	mu      sync.Mutex
+	configs map[int32]feeConfig // by workchain
+}{configs: map[int32]feeConfig{}}
+
+// entryPointMessages are the messages of the plan, the deploy only goes out when the proxy has no state
+func entryPointMessages(plan entryPointPlan, fees entryPointFees) []*wallet.Message {
+	var messages []*wallet.Message
+	if plan.State != nil {
+		messages = append(messages, &wallet.Message{
+			Mode: wallet.PayGasSeparately + wallet.IgnoreErrors,
+			InternalMessage: &tlb.InternalMessage{
+				IHRDisabled: true,
+				Bounce:      false,
+				DstAddr:     plan.Proxy,
+				Amount:      tlb.FromNanoTONU(fees.DeployAmount),
+				Body:        proxyWallet.ProxyWalletMessageToCell(plan.ProxyMessage),
+				StateInit:   plan.State,
+			},
+		})
+	}
+
+	queryId := uint64(0)
+	messages = append(messages, &wallet.Message{
+		Mode: wallet.PayGasSeparately + wallet.IgnoreErrors,
+		InternalMessage: &tlb.InternalMessage{
+			IHRDisabled: true,
+			Bounce:      false,
+			DstAddr:     plan.Entrypoint,
+			Amount:      tlb.FromNanoTONU(fees.EntrypointAmount),
+			Body: entrypoint.EntrypointMessageToCell(entrypoint.EntrypointMessage{
+				Destination: plan.Proxy,
+				Body:        proxyWallet.ProxyWalletMessageToCell(plan.ProxyMessage),
+			}, queryId),
+		},
+	})
+	return messages
+}
+
+// estimateEntryPointFees quotes the wallet → entrypoint → proxy → destination chain of a plan, the wallet
+// step is estimated by tonx and the others are priced from the gas and forward config of the proxy workchain
+func estimateEntryPointFees(ctx context.Context, w *wallet.Wallet, plan entryPointPlan) (entryPointFees, error) {
+	client, err := tonx.NewTestnetClient()
+	if err != nil {
+		return entryPointFees{}, err
+	}
+	config, err := getFeeConfig(ctx, client, plan.Proxy.Workchain())
+	if err != nil {
+		return entryPointFees{}, err
+	}
+
+	// the signature does not change the size of the messages, the unsigned quote sizes the same cells
+	executionData := plan.ProxyMessage.Data
+	ext, err := w.BuildExternalMessageForMany(ctx, entryPointMessages(plan, entryPointFees{
+		DeployAmount:     executionData.Value,
+		EntrypointAmount: executionData.Value,
+	}))
+	if err != nil {
+		return entryPointFees{}, fmt.Errorf("failed to build external message: %v", err)
+	}
+	estimate, err := client.EstimateFee(ctx, tonx.TonEstimateFee{
+		Address:      w.WalletAddress().String(),
+		Body:         base64.StdEncoding.EncodeToString(ext.Body.ToBOC()),
+		IgnoreChksig: true,
+	})
+	if err != nil {
+		return entryPointFees{}, fmt.Errorf("failed to estimate wallet fee: %v", err)
+	}
+
+	walletFees := feeStep{walletStep,
+		uint64(estimate.SourceFees.GasFee),
+		uint64(estimate.SourceFees.StorageFee),
+		uint64(estimate.SourceFees.InFwdFee + estimate.SourceFees.FwdFee)}
+	entrypointFees := feeStep{entrypointStep,
+		config.gas.fee(entrypointGas),
+		0,
+		config.forward.fee(&tlb.InternalMessage{
+			DstAddr: plan.Proxy,
+			Amount:  tlb.ZeroCoins,
+			Body:    proxyWallet.ProxyWalletMessageToCell(plan.ProxyMessage),
+		})}
+	proxyFees := feeStep{proxyStep,
+		config.gas.fee(proxyWalletGas),
+		0,
+		config.forward.fee(&tlb.InternalMessage{
+			DstAddr: executionData.Destination,
+			Amount:  tlb.ZeroCoins,
+			Body:    executionData.Body,
+		})}
+
+	var deployFees *feeStep
+	if plan.State != nil {
+		deployFees = &feeStep{deployStep, config.gas.fee(proxyDeployGas), 0, 0}
+	}
+	return quoteEntryPointFees(executionData.Value, walletFees, entrypointFees, proxyFees, deployFees), nil
+}
+
+// quoteEntryPointFees sums the steps into the quote and the amounts the wallet sends, deploy is nil
+// when the proxy is deployed. The deploy message carries the signed proxy message, so the proxy
+// executes it on deploy and the deploy amount covers a proxy step besides the deploy itself.
+func quoteEntryPointFees(value uint64, walletFees, entrypointFees, proxyFees feeStep, deploy *feeStep) entryPointFees {
+	quote := FeeQuote{
+		WithProxyInit: deploy != nil,
+		Items:         []FeeItem{walletFees.item(), entrypointFees.item()},
+	}
+	internal := entrypointFees.total() + proxyFees.total()
+	var deployAmount uint64
+	if deploy != nil {
+		quote.Items = append(quote.Items, deploy.item(), proxyFees.item())
+		deployAmount = withMargin(deploy.total() + proxyFees.total())
+		internal += deploy.total() + proxyFees.total()
+	}
+	quote.Items = append(quote.Items, proxyFees.item())
+
+	margin := withMargin(internal) - internal
+	total := walletFees.total() + internal + margin
+	quote.Margin = fmt.Sprint(margin)
+	quote.Total = fmt.Sprint(total)
+
+	return entryPointFees{
+		Quote:            quote,
+		Total:            total,
+		DeployAmount:     deployAmount,
+		EntrypointAmount: value + withMargin(entrypointFees.total()+proxyFees.total()),
+	}
+}
+
+type feeStep struct {
+	step    string
+	gas     uint64
+	storage uint64
+	forward uint64
+}
+
+func (f feeStep) total() uint64 {
+	return f.gas + f.storage + f.forward
+}
+
+func (f feeStep) item() FeeItem {
+	return FeeItem{
+		Step:    f.step,
+		Gas:     fmt.Sprint(f.gas),
+		Storage: fmt.Sprint(f.storage),
+		Forward: fmt.Sprint(f.forward),
+		Total:   fmt.Sprint(f.total()),
+	}
+}
+
+func withMargin(nano uint64) uint64 {
+	return nano + nano*feeMarginBps/10000
+}
+
+func (p gasPrices) fee(gas uint64) uint64 {
+	if gas <= p.flatGasLimit {
+		return p.flatGasPrice
+	}
+	return p.flatGasPrice + ceilDiv16(p.gasPrice*(gas-p.flatGasLimit))
+}
+
+// fee is the forward fee of a message, the root cell is free and repeated cells are counted once
+func (p forwardPrices) fee(message *tlb.InternalMessage) uint64 {
+	message.IHRDisabled = true
+	if message.SrcAddr == nil {
+		message.SrcAddr = address.NewAddressNone()
+	}
+	messageCell, err := tlb.ToCell(message)
+	if err != nil {
+		return p.lumpPrice
+	}
+
+	var bits, cells uint64
+	seen := map[string]bool{}
+	var count func(c *cell.Cell)
+	count = func(c *cell.Cell) {
+		for i := 0; i < int(c.RefsNum()); i++ {
+			ref := c.MustPeekRef(i)
+			if seen[string(ref.Hash())] {
+				continue
+			}
+			seen[string(ref.Hash())] = true
+			bits += uint64(ref.BitsSize())
+			cells++
+			count(ref)
+		}
+	}
+	count(messageCell)
+
+	return p.lumpPrice + ceilDiv16(p.bitPrice*bits+p.cellPrice*cells)
+}
+
+func ceilDiv16(x uint64) uint64 {
+	return (x + 0xffff) >> 16
+}
+
+// getFeeConfig reads config params 20/21 (gas) and 24/25 (forward) of the workchain, they rarely change
+// so they are cached for a few minutes
+func getFeeConfig(ctx context.Context, client *tonx.Client, workchain int32) (feeConfig, error) {
+	feeConfigs.mu.Lock()
+	config, ok := feeConfigs.configs[workchain]
+	feeConfigs.mu.Unlock()
+	if ok && time.Since(config.fetchedAt) < feeConfigTTL {
+		return config, nil
+	}
+
+	gasParam, forwardParam := 21, 25
+	if workchain == -1 {
+		gasParam, forwardParam = 20, 24
+	}
+
+	gasSlice, err := getConfigParam(ctx, client, gasParam)
+	if err != nil {
+		return feeConfig{}, err
+	}
+	if config.gas, err = parseGasPrices(gasSlice); err != nil {
+		return feeConfig{}, fmt.Errorf("invalid config param %d: %v", gasParam, err)
+	}
+	forwardSlice, err := getConfigParam(ctx, client, forwardParam)
+	if err != nil {
+		return feeConfig{}, err
+	}
+	if config.forward, err = parseForwardPrices(forwardSlice); err != nil {
+		return feeConfig{}, fmt.Errorf("invalid config param %d: %v", forwardParam, err)
+	}
+	config.fetchedAt = time.Now()
+
+	feeConfigs.mu.Lock()
+	feeConfigs.configs[workchain] = config
+	feeConfigs.mu.Unlock()
+	return config, nil
+}
+
+func getConfigParam(ctx context.Context, client *tonx.Client, id int) (*cell.Slice, error) {
+	param, err := client.GetConfigParam(ctx, tonx.TonGetConfigParam{ConfigId: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config param %d: %v", id, err)
+	}
+	boc, err := base64.StdEncoding.DecodeString(param.Config.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config param %d: %v", id, err)
+	}
+	paramCell, err := cell.FromBOC(boc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config param %d: %v", id, err)
+	}
+	return paramCell.BeginParse(), nil
+}
+
+// gas_flat_pfx#d1 flat_gas_limit:uint64 flat_gas_price:uint64 other:GasLimitsPrices
+// gas_prices#dd gas_price:uint64 ..., gas_prices_ext#de gas_price:uint64 ...
+func parseGasPrices(s *cell.Slice) (gasPrices, error) {
+	var prices gasPrices
+	for {
+		tag, err := s.LoadUInt(8)
+		if err != nil {
+			return prices, err
+		}
+		switch tag {
+		case 0xd1:
+			if prices.flatGasLimit, err = s.LoadUInt(64); err != nil {
+				return prices, err
+			}
+			if prices.flatGasPrice, err = s.LoadUInt(64); err != nil {
+				return prices, err
+			}
+		case 0xdd, 0xde:
+			prices.gasPrice, err = s.LoadUInt(64)
+			return prices, err
+		default:
+			return prices, fmt.Errorf("unknown gas prices tag %x", tag)
+		}
+	}
+}
+
+// msg_forward_prices#ea lump_price:uint64 bit_price:uint64 cell_price:uint64 ...
+func parseForwardPrices(s *cell.Slice) (forwardPrices, error) {
+	var prices forwardPrices
+	tag, err := s.LoadUInt(8)
+	if err != nil {
+		return prices, err
+	}
+	if tag != 0xea {
+		return prices, fmt.Errorf("unknown forward prices tag %x", tag)
+	}
+	if prices.lumpPrice, err = s.LoadUInt(64); err != nil {
+		return prices, err
+	}
+	if prices.bitPrice, err = s.LoadUInt(64); err != nil {
+		return prices, err
+	}
+	prices.cellPrice, err = s.LoadUInt(64)
+	return prices, err
+}
@@ -0,0 +1,52 @@
+package tvmHandler
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestQuoteEntryPointFees(t *testing.T) {
+	const value = 1_000_000_000
+	walletFees := feeStep{walletStep, 3_000_000, 1_000, 700_000}
+	entrypointFees := feeStep{entrypointStep, 6_000_000, 0, 900_000}
+	proxyFees := feeStep{proxyStep, 10_000_000, 0, 1_100_000}
+
+	tests := []struct {
+		name   string
+		deploy *feeStep
+		items  int
+	}{
+		{"deployed proxy", nil, 3},
+		{"proxy deploy", &feeStep{deployStep, 2_000_000, 0, 0}, 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fees := quoteEntryPointFees(value, walletFees, entrypointFees, proxyFees, test.deploy)
+			if len(fees.Quote.Items) != test.items {
+				t.Fatalf("%d quote items, want %d", len(fees.Quote.Items), test.items)
+			}
+			if fees.Quote.WithProxyInit != (test.deploy != nil) {
+				t.Fatal("with-proxy-init does not match the deploy")
+			}
+			if fees.Quote.Total != strconv.FormatUint(fees.Total, 10) {
+				t.Fatalf("quote total %s, fees total %d", fees.Quote.Total, fees.Total)
+			}
+
+			// the wallet never forwards more than the quote charges besides the execution value
+			sent := fees.DeployAmount + fees.EntrypointAmount - value
+			if sent > fees.Total-walletFees.total() {
+				t.Fatalf("wallet forwards %d of fees, quote covers %d", sent, fees.Total-walletFees.total())
+			}
+			if test.deploy == nil {
+				if fees.DeployAmount != 0 {
+					t.Fatalf("deploy amount %d without a deploy", fees.DeployAmount)
+				}
+				return
+			}
+			// the proxy runs the signed message of the deploy
+			if fees.DeployAmount < test.deploy.total()+proxyFees.total() {
+				t.Fatalf("deploy amount %d does not cover the deploy and a proxy step", fees.DeployAmount)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package tvmHandler
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -36,7 +35,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		if rec := recover(); rec != nil {
 			logrus.Errorf("Recovered from panic: %v", rec)
 			logPanicToSupabase(fmt.Sprintf("%v", rec))
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			utils.WriteError(w, utils.ErrInternal("recovered from panic"))
 		}
 	}()
 
@@ -48,7 +47,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		supabaseKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
 		supabaseClient, err := supabase.NewClient(supabaseUrl, supabaseKey, nil)
 		if err != nil {
-			http.Error(w, "Failed to create Supabase client", http.StatusInternalServerError)
+			utils.WriteError(w, utils.ErrInternal("failed to create supabase client"))
 			return
 		}
 
@@ -74,6 +73,18 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 			response, err = UnsignedMintFromRequest(r)
 			HandleResponse(w, r, supabaseClient, response, err)
 			return
+		case "swap-quote":
+			response, err = SwapQuoteRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
+		case "unsigned-swap-request":
+			response, err = UnsignedSwapRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
+		case "message-status":
+			response, err = MessageStatusRequest(r)
+			HandleResponse(w, r, supabaseClient, response, err)
+			return
 		case "asset-info":
 			response, err = AssetInfoRequest(r)
 			HandleResponse(w, r, supabaseClient, response, err)
@@ -111,8 +122,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 			HandleResponse(w, r, supabaseClient, response, err)
 			return
 		default:
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(utils.ErrMalformedRequest("Invalid query parameter"))
+			utils.WriteError(w, utils.ErrMalformedRequest("Invalid query parameter"))
 			return
 		}
 
@@ -126,14 +136,6 @@ func HandleResponse(w http.ResponseWriter, r *http.Request, supabaseClient *supa
 		if logErr := db.LogError(supabaseClient, err, r.URL.Query().Get("query"), response); logErr != nil {
 			fmt.Printf("Failed to log error: %v\n", logErr.Error())
 		}
-
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(err)
-		return
-	}
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 	}
+	utils.WriteResponse(w, response, err)
 }
@@ -0,0 +1,71 @@
+package tvmHandler
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
+)
+
+// ProxyWalletTx is a transaction of a user's proxy wallet
+type ProxyWalletTx struct {
+	Hash      string
+	LT        uint64
+	Now       uint32
+	Success   bool
+	Source    string // sender of the incoming internal message, empty for external messages
+	ValueNano string // value of the incoming internal message
+}
+
+// ProxyWalletHistory returns the proxy wallet address of the evm and tvm owners and its latest
+// transactions, newest first. A wallet that was never initialized has no transactions.
+func ProxyWalletHistory(ctx context.Context, evmOwner common.Address, tvmOwner string, limit uint32) (string, []ProxyWalletTx, error) {
+	ctx, api, proxyWalletAddress, account, err := proxyWalletAccount(ctx, evmOwner, tvmOwner)
+	if err != nil {
+		return proxyWalletAddress, nil, err
+	}
+	if !account.IsActive || account.LastTxLT == 0 {
+		return proxyWalletAddress, nil, nil
+	}
+
+	txs, err := api.ListTransactions(ctx, address.MustParseAddr(proxyWalletAddress), limit, account.LastTxLT, account.LastTxHash)
+	if err != nil {
+		return proxyWalletAddress, nil, fmt.Errorf("failed to list proxy wallet transactions: %v", err)
+	}
+
+	// liteservers return the oldest transaction first
+	history := make([]ProxyWalletTx, 0, len(txs))
+	for i := len(txs) - 1; i >= 0; i-- {
+		history = append(history, parseProxyWalletTx(txs[i]))
+	}
+	return proxyWalletAddress, history, nil
+}
+
+func parseProxyWalletTx(tx *tlb.Transaction) ProxyWalletTx {
+	parsed := ProxyWalletTx{
+		Hash:    hex.EncodeToString(tx.Hash),
+		LT:      tx.LT,
+		Now:     tx.Now,
+		Success: true,
+	}
+
+	if tx.IO.In != nil && tx.IO.In.MsgType == tlb.MsgTypeInternal {
+		in := tx.IO.In.AsInternal()
+		parsed.Source = in.SrcAddr.String()
+		parsed.ValueNano = in.Amount.Nano().String()
+	}
+
+	if description, ok := tx.Description.Description.(tlb.TransactionDescriptionOrdinary); ok {
+		parsed.Success = !description.Aborted
+		if computePhase, ok := description.ComputePhase.Phase.(tlb.ComputePhaseVM); ok && !computePhase.Success {
+			parsed.Success = false
+		}
+		if description.ActionPhase != nil && !description.ActionPhase.Success {
+			parsed.Success = false
+		}
+	}
+	return parsed
+}
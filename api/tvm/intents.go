@@ -0,0 +1,74 @@
+package tvmHandler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/intent"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/supabase-community/supabase-go"
+)
+
+// intents of tvm requests are keyed by the hex hash of the execution data the user signs, they are
+// created when api/main quotes the request. Requests quoted elsewhere have no intent.
+
+func intentClient() *supabase.Client {
+	supabaseUrl := os.Getenv("SUPABASE_URL")
+	if supabaseUrl == "" {
+		return nil
+	}
+	supabaseClient, err := supabase.NewClient(supabaseUrl, os.Getenv("SUPABASE_SERVICE_ROLE_KEY"), nil)
+	if err != nil {
+		utils.LogError("failed to create supabase client", err.Error())
+		return nil
+	}
+	return supabaseClient
+}
+
+// signIntent moves the quoted intent of a signed message to signed, an expired quote is
+// rejected. Storage errors only skip the intent, they never fail the request
+func signIntent(id string) error {
+	supabaseClient := intentClient()
+	if supabaseClient == nil {
+		return nil
+	}
+	current, err := intent.Get(supabaseClient, id)
+	if err != nil {
+		if !errors.Is(err, intent.ErrNotFound) {
+			utils.LogError("failed to get intent", err.Error())
+		}
+		return nil
+	}
+	if current.State != intent.Quoted {
+		return nil
+	}
+	if current.IsExpired(time.Now().UTC()) {
+		advanceIntent(supabaseClient, id, intent.Expired, "quote expired", nil)
+		return utils.Err(apierr.QuoteExpired, fmt.Sprintf("quote %s expired, request a new one", id))
+	}
+	advanceIntent(supabaseClient, id, intent.Signed, "", nil)
+	return nil
+}
+
+// executeIntent moves the intent of a tracked message to executed, or to failed with the reason
+func executeIntent(id string, txHash string, reason string) {
+	supabaseClient := intentClient()
+	if supabaseClient == nil {
+		return
+	}
+	if reason != "" {
+		advanceIntent(supabaseClient, id, intent.Failed, reason, func(i *intent.Intent) { i.Error = reason })
+		return
+	}
+	advanceIntent(supabaseClient, id, intent.Executed, "", func(i *intent.Intent) { i.TxHash = txHash })
+}
+
+// advanceIntent only records the state, the tracker publishes the events of the message
+func advanceIntent(supabaseClient *supabase.Client, id string, to intent.State, detail string, update func(*intent.Intent)) {
+	if _, err := intent.Advance(supabaseClient, id, to, detail, update); err != nil && !errors.Is(err, intent.ErrNotFound) {
+		utils.LogError(fmt.Sprintf("failed to move intent %s to %s", id, to), err.Error())
+	}
+}
@@ -1,6 +1,11 @@
 package tvmHandler
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/xssnick/tonutils-go/address"
+)
 
 /*
 tvm message to the entrypoint
@@ -278,16 +283,19 @@ type ViewFunctionResult struct {
 }
 
 type SignedEntryPointRequestParams struct {
-	EvmAddress   string `query:"evm-address"`
-	TvmAddress   string `query:"tvm-address"`
-	AssetAddress string `query:"asset-address"`
-	AssetAmount  string `query:"asset-amount"`
+	ChainId      string           `query:"chain-id" optional:"true"` // tvm chain of the proxy wallet, the ton testnet when empty
+	EvmAddress   common.Address   `query:"evm-address" validate:"nonzero"`
+	TvmAddress   *address.Address `query:"tvm-address"`
+	AssetAddress string           `query:"asset-address"`
+	AssetAmount  string           `query:"asset-amount"`
 	Message      struct {
 		QueryId   string `query:"msg-query-id"`
 		Signature struct {
 			V string `query:"sig-v"`
 			R string `query:"sig-r"`
 			S string `query:"sig-s"`
+			// evm chain of the typed data domain, without it only personal_sign is accepted
+			ChainId string `query:"sig-chain-id" optional:"true"`
 		} `query:"msg-signature"`
 		Data ExecutionDataParams `query:"msg-data"`
 	} `query:"message"`
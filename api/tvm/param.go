@@ -7,13 +7,14 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
-	"math"
 	"math/big"
 	"math/rand"
 	"strconv"
 
 	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/proxyWallet"
+	"github.com/crosscall-labs/crosschain-api/pkg/rpcpool"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/crypto/sha3"
 
@@ -341,416 +342,15 @@ func connectToClient(config string) (context.Context, ton.APIClientWrapped, erro
 	return ctx, api, nil
 }
 
+// ConnectToTestnetClient returns the pooled liteserver client, connections are reused across requests
 func ConnectToTestnetClient() (context.Context, ton.APIClientWrapped, error) {
-	ctx, client, err := connectToClient("https://ton.org/testnet-global.config.json")
-	if err != nil {
-		fmt.Printf("Error connecting to primary client: %v\n", err) // Log the error
-
-		// Attempt the fallback
-		ctx, client, fallbackErr := connectToClient("https://ton-blockchain.github.io/testnet-global.config.json")
-		if fallbackErr != nil {
-			return nil, nil, fmt.Errorf("both connections failed: primary error: %v, fallback error: %v", err, fallbackErr)
-		}
-		return ctx, client, nil
-	}
-	return ctx, client, nil
+	return rpcpool.TonClient(testnetChainId)
 }
 
 func ConnectToMainnetClient() (context.Context, ton.APIClientWrapped, error) {
 	return connectToClient("https://ton.org/global.config.json")
 }
 
-func loadData(src []byte, offset, size int) ([]byte, int, error) {
-	if offset < 0 || offset+size > len(src) {
-		return nil, 0, fmt.Errorf("offset and size are out of bounds")
-	}
-
-	return src[offset : offset+size], offset + size, nil
-}
-
-func loadDataPadded(src []byte, offset, size int) ([]byte, int, error) {
-	if offset < 0 || offset+size > len(src) {
-		return nil, 0, fmt.Errorf("offset and size are out of bounds")
-	}
-
-	data := src[offset : offset+size]
-	end := len(data)
-	for end > 0 && data[end-1] == 0 {
-		end--
-	}
-
-	return data[:end], offset + size, nil
-}
-
-func skipData(src []byte, offset, size int) (int, error) {
-	if offset < 0 || offset+size > len(src) {
-		return 0, fmt.Errorf("offset and size are out of bounds")
-	}
-
-	return offset + size, nil
-}
-
-func bytesToUint(b []byte) (uint, error) {
-	if len(b) > 8 {
-		return 0, fmt.Errorf("byte slice too long to convert to uint")
-	}
-
-	padded := make([]byte, 8)
-	copy(padded[8-len(b):], b)
-
-	result := binary.BigEndian.Uint64(padded)
-	return uint(result), nil
-}
-
-func extractBits(b byte, start int, size int) (byte, error) {
-	if start < 0 || start >= 8 || size < 1 || size > 8 || start+size > 8 {
-		return 0, fmt.Errorf("invalid start or size, must be within byte bounds")
-	}
-
-	return b << start >> (8 - start + size) << size, nil
-}
-
-func bigByteToBool(b byte) bool {
-	return (b & 0x80) != 0
-}
-
-type boc struct {
-	Size          []byte   `json:"size"`
-	OffBytes      []byte   `json:"off_bytes"`
-	Cells         []byte   `json:"cells"`
-	Roots         []byte   `json:"roots"`
-	Absent        []byte   `json:"absent"`
-	TotalCellSize []byte   `json:"total_cell_size"`
-	Index         []byte   `json:"index"`
-	CellData      []byte   `json:"cell_data"`
-	Root          [][]byte `json:"root"`
-}
-
-func parseBoc(src []byte) (boc, error) {
-
-	offset := 0
-	magic, offset, err := loadData(proxyWalletCodeBytes, offset, 4)
-	var root [][]byte
-	if err != nil {
-		return boc{}, fmt.Errorf("magic error: %s", err.Error())
-	}
-	switch hex.EncodeToString(magic) {
-	case "68ff65f3":
-		size, offset, err := loadData(proxyWalletCodeBytes, offset, 1)
-		if err != nil {
-			return boc{}, fmt.Errorf("size error: %s", err.Error())
-		}
-		sizeUint, err := bytesToUint(size)
-		if err != nil {
-			return boc{}, fmt.Errorf("sizeUint error: %s", err.Error())
-		}
-		offBytes, offset, err := loadData(proxyWalletCodeBytes, offset, 1)
-		if err != nil {
-			return boc{}, fmt.Errorf("offBytes error: %s", err.Error())
-		}
-		offBytesUint, err := bytesToUint(offBytes)
-		if err != nil {
-			return boc{}, fmt.Errorf("offBytesUint error: %s", err.Error())
-		}
-		cells, offset, err := loadData(proxyWalletCodeBytes, offset, int(sizeUint)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("cells error: %s", err.Error())
-		}
-		cellsUint, err := bytesToUint(cells)
-		if err != nil {
-			return boc{}, fmt.Errorf("cellsUint error: %s", err.Error())
-		}
-		roots, offset, err := loadData(proxyWalletCodeBytes, offset, int(sizeUint)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("roots error: %s", err.Error())
-		}
-		absent, offset, err := loadData(proxyWalletCodeBytes, offset, int(sizeUint)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("absent error: %s", err.Error())
-		}
-		totalCellSize, offset, err := loadData(proxyWalletCodeBytes, offset, int(offBytesUint)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("totalCellSize error: %s", err.Error())
-		}
-		totalCellSizeUint, err := bytesToUint(totalCellSize)
-		if err != nil {
-			return boc{}, fmt.Errorf("totalCellSizeUint error: %s", err.Error())
-		}
-		index, offset, err := loadData(proxyWalletCodeBytes, offset, int(cellsUint)*int(offBytesUint))
-		if err != nil {
-			return boc{}, fmt.Errorf("index error: %s", err.Error())
-		}
-		cellData, offset, err := loadData(proxyWalletCodeBytes, offset, int(totalCellSizeUint))
-		if err != nil {
-			return boc{}, fmt.Errorf("cellData error: %s", err.Error())
-		}
-		return boc{
-			Size:          size,
-			OffBytes:      offBytes,
-			Cells:         cells,
-			Roots:         roots,
-			Absent:        absent,
-			TotalCellSize: totalCellSize,
-			Index:         index,
-			CellData:      cellData,
-			Root:          root,
-		}, nil
-	case "acc3a728":
-		size, offset, err := loadData(proxyWalletCodeBytes, offset, 1)
-		if err != nil {
-			return boc{}, fmt.Errorf("size error: %s", err.Error())
-		}
-		sizeUint, err := bytesToUint(size)
-		if err != nil {
-			return boc{}, fmt.Errorf("sizeUint error: %s", err.Error())
-		}
-		offBytes, offset, err := loadData(proxyWalletCodeBytes, offset, 1)
-		if err != nil {
-			return boc{}, fmt.Errorf("offBytes error: %s", err.Error())
-		}
-		offBytesUint, err := bytesToUint(offBytes)
-		if err != nil {
-			return boc{}, fmt.Errorf("offBytesUint error: %s", err.Error())
-		}
-		cells, offset, err := loadData(proxyWalletCodeBytes, offset, int(sizeUint)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("cells error: %s", err.Error())
-		}
-		cellsUint, err := bytesToUint(cells)
-		if err != nil {
-			return boc{}, fmt.Errorf("cellsUint error: %s", err.Error())
-		}
-		roots, offset, err := loadData(proxyWalletCodeBytes, offset, int(sizeUint)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("roots error: %s", err.Error())
-		}
-		absent, offset, err := loadData(proxyWalletCodeBytes, offset, int(sizeUint)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("absent error: %s", err.Error())
-		}
-		totalCellSize, offset, err := loadData(proxyWalletCodeBytes, offset, int(offBytesUint)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("totalCellSize error: %s", err.Error())
-		}
-		totalCellSizeUint, err := bytesToUint(totalCellSize)
-		if err != nil {
-			return boc{}, fmt.Errorf("totalCellSizeUint error: %s", err.Error())
-		}
-		index, offset, err := loadData(proxyWalletCodeBytes, offset, int(cellsUint)*int(offBytesUint))
-		if err != nil {
-			return boc{}, fmt.Errorf("index error: %s", err.Error())
-		}
-		cellData, offset, err := loadData(proxyWalletCodeBytes, offset, int(totalCellSizeUint))
-		if err != nil {
-			return boc{}, fmt.Errorf("cellData error: %s", err.Error())
-		}
-		crc32, offset, err := loadData(proxyWalletCodeBytes, offset, 4)
-		if err != nil {
-			return boc{}, fmt.Errorf("crc32 error: %s", err.Error())
-		}
-		fmt.Print(crc32)
-		return boc{
-			Size:          size,
-			OffBytes:      offBytes,
-			Cells:         cells,
-			Roots:         roots,
-			Absent:        absent,
-			TotalCellSize: totalCellSize,
-			Index:         index,
-			CellData:      cellData,
-			Root:          root,
-		}, nil
-	case "b5ee9c72":
-		firstByte, offset, err := loadData(proxyWalletCodeBytes, offset, 1)
-		if err != nil {
-			return boc{}, fmt.Errorf("firstByte error: %s", err.Error())
-		}
-		hasIdx, err := extractBits(firstByte[0], 0, 1)
-		if err != nil {
-			return boc{}, fmt.Errorf("hasIdx error: %s", err.Error())
-		}
-		hasCrc32c, err := extractBits(firstByte[0], 1, 1)
-		if err != nil {
-			return boc{}, fmt.Errorf("hasCrc32c error: %s", err.Error())
-		}
-		fmt.Print(hasCrc32c)
-		hasCacheBits, err := extractBits(firstByte[0], 2, 1)
-		if err != nil {
-			return boc{}, fmt.Errorf("hasCacheBits error: %s", err.Error())
-		}
-		fmt.Print(hasCacheBits)
-		flags, err := extractBits(firstByte[0], 3, 2)
-		if err != nil {
-			return boc{}, fmt.Errorf("flags error: %s", err.Error())
-		}
-		fmt.Print(flags)
-		size, err := extractBits(firstByte[0], 5, 3)
-		if err != nil {
-			return boc{}, fmt.Errorf("size error: %s", err.Error())
-		}
-		var root [][]byte
-		var index []byte
-
-		offBytes, offset, err := loadData(proxyWalletCodeBytes, offset, 1)
-		if err != nil {
-			return boc{}, fmt.Errorf("offBytes error: %s", err.Error())
-		}
-		offBytesUint, err := bytesToUint(offBytes)
-		if err != nil {
-			return boc{}, fmt.Errorf("offBytesUint error: %s", err.Error())
-		}
-		cells, offset, err := loadData(proxyWalletCodeBytes, offset, int(size)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("cells error: %s", err.Error())
-		}
-		cellsUint, err := bytesToUint(cells)
-		if err != nil {
-			return boc{}, fmt.Errorf("cellsUint error: %s", err.Error())
-		}
-		roots, offset, err := loadData(proxyWalletCodeBytes, offset, int(size)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("roots error: %s", err.Error())
-		}
-		rootsUint, err := bytesToUint(roots)
-		if err != nil {
-			return boc{}, fmt.Errorf("rootsUint error: %s", err.Error())
-		}
-		absent, offset, err := loadData(proxyWalletCodeBytes, offset, int(size)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("absent error: %s", err.Error())
-		}
-		totalCellSize, offset, err := loadData(proxyWalletCodeBytes, offset, int(offBytesUint)*1)
-		if err != nil {
-			return boc{}, fmt.Errorf("totalCellSize error: %s", err.Error())
-		}
-		totalCellSizeUint, err := bytesToUint(totalCellSize)
-		if err != nil {
-			return boc{}, fmt.Errorf("totalCellSizeUint error: %s", err.Error())
-		}
-		for ; rootsUint > 0; rootsUint-- {
-			var data []byte
-			data, offset, err = loadData(proxyWalletCodeBytes, offset, int(size))
-			if err != nil {
-				return boc{}, fmt.Errorf("rootsUint %v data error: %s", rootsUint, err.Error())
-			}
-			root = append(root, data)
-		}
-		if bigByteToBool(hasIdx) {
-			index, offset, err = loadData(proxyWalletCodeBytes, offset, int(cellsUint)*int(offBytesUint))
-			if err != nil {
-				return boc{}, fmt.Errorf("index error: %s", err.Error())
-			}
-		}
-		cellData, offset, err := loadData(proxyWalletCodeBytes, offset, int(totalCellSizeUint))
-		if err != nil {
-			return boc{}, fmt.Errorf("cellData error: %s", err.Error())
-		}
-		return boc{
-			Size:          append([]byte{}, size),
-			OffBytes:      offBytes,
-			Cells:         cells,
-			Roots:         roots,
-			Absent:        absent,
-			TotalCellSize: totalCellSize,
-			Index:         index,
-			CellData:      cellData,
-			Root:          root,
-		}, nil
-	default:
-		return boc{}, fmt.Errorf("magic bytes not found")
-	}
-}
-
-func getHashesCount(levelMask byte) byte {
-	return getHashesCountFromMask(levelMask & 7)
-}
-func getHashesCountFromMask(mask byte) byte {
-	n := byte(0)
-	for i := 0; i < 3; i++ {
-		n += (mask & 1)
-		mask = mask >> 1
-	}
-	return n + 1 // 1 repr + up to 3 higher hashes
-}
-
-func readCell(src []byte, size int) ([]byte, [][]byte, bool, error) {
-	offset := 0
-	// D1
-	d1, offset, _ := loadData(src, offset, 1)
-	refsCount := int(d1[0] % 8)
-	exotic := (d1[0] & 8) != 0
-	// D2
-	d2, offset, _ := loadData(src, offset, 1)
-	dataBytesize := int(math.Ceil(float64(d2[0]) / 2.0))
-	paddingAdded := (d2[0] % 2) != 0
-	levelMask := d1[0] >> 5
-	hasHashes := (d1[0] & 16) != 0
-	const hash_bytes = 32
-	hashesSize := 0
-	if bool(hasHashes) {
-		hashesSize = int(getHashesCount(levelMask)) * hash_bytes
-	}
-	depthSize := 0
-	if bool(hasHashes) {
-		depthSize = int(getHashesCount(levelMask)) * 2
-	}
-	offset, _ = skipData(src, offset, hashesSize*1)
-	offset, _ = skipData(src, offset, depthSize*1)
-	var bits []byte
-	if dataBytesize > 0 {
-		if paddingAdded {
-			bits, offset, _ = loadDataPadded(src, offset, dataBytesize)
-		} else {
-			bits, offset, _ = loadData(src, offset, dataBytesize)
-		}
-	}
-
-	var refs [][]byte
-	var ref []byte
-	for i := 0; i < refsCount; i++ {
-		ref, offset, _ = loadData(src, offset, size)
-		refs = append(refs, ref)
-	}
-	return bits, refs, exotic, nil
-}
-
-func deserializeBoc(src []byte) (*cell.Cell, error) {
-	//var boc_ boc
-	// boc_, err := parseBoc(src)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("could not parse boc: %s", err.Error())
-	// }
-	// cellData := boc_.CellData
-
-	// var cells [][]byte
-	// cells_ := binary.BigEndian.Uint64(boc_.Cells)
-	// size_ := binary.BigEndian.Uint64(boc_.Size)
-
-	// offset := 0
-	// for i:=0; i<cellnum; i++ {
-	// 	cll, offset, _ = readCell(cellData, size_)
-	// }
-	// magic, offset, err := loadData(proxyWalletCodeBytes, offset, 4)
-
-	return cell.BeginCell().EndCell(), nil
-}
-
-/*
-type Cell struct {
-    special   bool
-    levelMask LevelMask
-    bitsSz    uint
-    data      []byte
-
-    hashes      []byte
-    depthLevels []uint16
-
-    refs []*Cell
-}
-*/
-
-// next parse bytes into it's own function, then
-// need to parse cellData
 func CalculateWallet(
 	evmAddress uint64,
 	tvmAddress *address.Address,
@@ -829,6 +429,8 @@ type MessageEscrowTvm struct {
 	EscrowAmount    string `json:"eamount"`
 	EscrowValueType string `json:"evaluetype"`
 	EscrowValue     string `json:"evalue"`
+	EscrowLock      string `json:"elock,omitempty"`     // deposit-and-lock body the user's wallet sends instead of epayload, needs the lock signature
+	EscrowLockHash  string `json:"elockhash,omitempty"` // destination op hash the evm signer signs (personal_sign) for the lock
 }
 
 type EntrypointMessageParams struct {
@@ -884,11 +486,14 @@ type UnsignedEscrowRequestParams struct {
 
 // NEED to create the escrow payload
 type EscrowLockParams struct {
-	SignerAddress string `query:"signer-address"`
-	AdminAddress  string `query:"admin-address" optional:"true"`
-	PayeeAddress  string `query:"payee-address" optional:"true"`
-	Id            string `query:"id" optional:"true"`
-	Value         string `query:"value" optional:"true"`
+	SignerAddress string         `query:"signer-address" optional:"true"` // default header signer
+	AdminAddress  string         `query:"admin-address" optional:"true"`  // default backend wallet
+	PayeeAddress  common.Address `query:"payee-address" optional:"true"`  // default relay
+	Id            *big.Int       `query:"id" optional:"true" validate:"uint"`
+	Value         *big.Int       `query:"value" optional:"true" validate:"uint"`            // nano attached to the deposit
+	LockHash      string         `query:"lock-hash" optional:"true" validate:"hex=32"`      // destination op hash the lock commits to
+	LockSigner    common.Address `query:"lock-signer" optional:"true"`                      // evm signer of the destination op
+	LockSignature string         `query:"lock-signature" optional:"true" validate:"hex=65"` // personal_sign of lock-hash by lock-signer
 }
 
 // message directly to entrypoint
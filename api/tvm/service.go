@@ -3,7 +3,6 @@ package tvmHandler
 import (
 	"context"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -11,8 +10,16 @@ import (
 	"strconv"
 	"strings"
 
+	evmHandler "github.com/crosscall-labs/crosschain-api/api/evm"
+	tvmUtils "github.com/crosscall-labs/crosschain-api/api/tvm/utils"
+	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/escrow"
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/eip712"
 	"github.com/crosscall-labs/crosschain-api/pkg/tonx"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/xssnick/tonutils-go/address"
 	"github.com/xssnick/tonutils-go/tlb"
 	"github.com/xssnick/tonutils-go/ton"
@@ -149,7 +156,7 @@ func AssetMintRequest(r *http.Request, parameters ...*utils.AssetMintRequestPara
 	msgBody := JettonMintMessage(*userAddress, queryId, jettonAmount, forwardTonAmount, *contractAddress, totalTonAmount)
 	amount := tlb.MustFromTON("0.01")
 
-	tx, block, err := w.SendWaitTransaction(ctx, &wallet.Message{
+	return sendExternalMessage(ctx, w, nil, &wallet.Message{
 		Mode: wallet.PayGasSeparately + wallet.IgnoreErrors,
 		InternalMessage: &tlb.InternalMessage{
 			IHRDisabled: true,
@@ -159,8 +166,6 @@ func AssetMintRequest(r *http.Request, parameters ...*utils.AssetMintRequestPara
 			Body:        msgBody,
 		},
 	})
-
-	return ParseTxBlockResponse(tx, block), nil
 }
 
 // now that we have a way to execute, deploy + execute, view, we can formulate and execute the escrow request
@@ -191,7 +196,173 @@ func UnsignedEscrowRequest(r *http.Request, parameters ...*UnsignedEscrowRequest
 		return nil, utils.ErrMalformedRequest(errorStr)
 	}
 
-	return MessageEscrowTvm{}, nil
+	config, err := toEscrowConfig(params.Header.ChainSigner, params.Escrow)
+	if err != nil {
+		return nil, err
+	}
+	value := big.NewInt(0)
+	if params.Escrow.Value != nil {
+		value = params.Escrow.Value
+	}
+
+	escrowCode, err := codeCell(escrowCodeBytes)
+	if err != nil {
+		return nil, utils.ErrInternal(fmt.Sprintf("invalid escrow code: %v", err))
+	}
+	escrowAddress, err := escrow.CalculateEscrowAddress(config, escrowCode, 0)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	escrowAddress.SetTestnetOnly(true)
+
+	chain, err := chains.Get(params.Header.ChainId)
+	if err != nil {
+		return nil, utils.Err(apierr.UnsupportedChain, err.Error())
+	}
+	accountsApi, err := tvmUtils.AccountsApi(chain)
+	if err != nil {
+		return nil, utils.ErrInternal(err.Error())
+	}
+	escrowData, err := escrow.GetEscrowData(accountsApi, escrowAddress.String())
+	if err != nil {
+		return nil, utils.ErrInternal(fmt.Sprintf("failed to get escrow state: %v", err))
+	}
+	isInit := escrowData.Status == "active"
+
+	// the first deposit deploys the escrow, it must not bounce
+	escrowInit := ""
+	if !isInit {
+		escrowAddress.SetBounce(false)
+		stateInit, err := tlb.ToCell(escrow.EscrowStateInit(config, escrowCode))
+		if err != nil {
+			return nil, utils.ErrInternal(err.Error())
+		}
+		escrowInit = hex.EncodeToString(stateInit.ToBOC())
+	}
+
+	message := MessageEscrowTvm{
+		EscrowAddress:   escrowAddress.String(),
+		EscrowInit:      escrowInit,
+		EscrowPayload:   hex.EncodeToString(escrow.DepositMessageToCell().ToBOC()),
+		EscrowAsset:     "0",
+		EscrowAmount:    escrowData.Balance,
+		EscrowValueType: "nano",
+		EscrowValue:     value.String(),
+	}
+
+	// the attached value is deposited by any message, so the lock body doubles as deposit-and-lock
+	if params.Escrow.LockHash != "" {
+		hash, err := hex.DecodeString(strings.TrimPrefix(params.Escrow.LockHash, "0x"))
+		if err != nil || len(hash) != 32 {
+			return nil, utils.ErrMalformedRequest("invalid lock hash, expected 32 bytes hex")
+		}
+		message.EscrowLockHash = "0x" + hex.EncodeToString(hash)
+		if params.Escrow.LockSignature != "" {
+			lock, err := lockMessageToCell(hash, params.Escrow.LockSigner, params.Escrow.LockSignature)
+			if err != nil {
+				return nil, err
+			}
+			message.EscrowLock = hex.EncodeToString(lock.ToBOC())
+		}
+	} else if params.Escrow.LockSignature != "" {
+		return nil, utils.ErrMalformedRequest("lock-signature without lock-hash")
+	}
+
+	return message, nil
+}
+
+// UnsignedEscrowLock is the tvm origin of a crosschain request: a deposit of value nano into the escrow
+// of the header signer, locked against the evm op of the destination. The response carries the op hash
+// the destination signer signs, the lock body is built once that signature is sent back
+func UnsignedEscrowLock(header utils.PartialHeader, destination interface{}, value *big.Int) (interface{}, error) {
+	op, ok := destination.(evmHandler.MessageOpEvm)
+	if !ok {
+		return nil, utils.ErrMalformedRequest("a tvm escrow lock needs an evm destination op to commit to")
+	}
+	if value == nil || value.Sign() <= 0 {
+		return nil, utils.ErrMalformedRequest("escrow-value is required, the nano deposited and locked on the tvm origin")
+	}
+	return UnsignedEscrowRequest(nil, &UnsignedEscrowRequestParams{
+		Header: header,
+		Escrow: EscrowLockParams{
+			Value:    value,
+			LockHash: op.UserOpHash,
+		},
+	})
+}
+
+// lockMessageToCell builds the lock body of a destination op. The signature is the personal_sign of
+// the op hash by the evm signer of the op, the lock can only be paid out against that op
+func lockMessageToCell(hash []byte, signer common.Address, signature string) (*cell.Cell, error) {
+	if signer == (common.Address{}) {
+		return nil, utils.ErrMalformedRequest("lock-signer is required with lock-signature")
+	}
+	signatureBytes, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil || len(signatureBytes) != crypto.SignatureLength {
+		return nil, utils.ErrMalformedRequest("invalid lock signature, expected 65 bytes hex")
+	}
+	scheme, err := eip712.VerifySigner(signer, signatureBytes, hash, nil)
+	if err != nil || scheme == "" {
+		return nil, utils.Err(apierr.SignatureInvalid, fmt.Sprintf("lock signature is not signed by %s", signer.Hex()))
+	}
+
+	v := uint64(signatureBytes[64])
+	if v < 27 {
+		v += 27
+	}
+	return escrow.LockMessageToCell(escrow.LockMessage{
+		Signature: escrow.Signature{
+			V: v,
+			R: new(big.Int).SetBytes(signatureBytes[:32]),
+			S: new(big.Int).SetBytes(signatureBytes[32:64]),
+		},
+	}), nil
+}
+
+// toEscrowConfig fills the escrow config of the signer, the admin defaults to the backend wallet
+// and the payee to the relay
+func toEscrowConfig(signer string, params EscrowLockParams) (escrow.EscrowConfig, error) {
+	if params.SignerAddress != "" {
+		signer = params.SignerAddress
+	}
+	userAddress, err := address.ParseAddr(signer)
+	if err != nil {
+		return escrow.EscrowConfig{}, utils.ErrMalformedRequest(fmt.Sprintf("invalid signer address: %v", err))
+	}
+
+	var adminAddress *address.Address
+	if params.AdminAddress != "" {
+		if adminAddress, err = address.ParseAddr(params.AdminAddress); err != nil {
+			return escrow.EscrowConfig{}, utils.ErrMalformedRequest(fmt.Sprintf("invalid admin address: %v", err))
+		}
+	} else {
+		backendWallet, err := wallet.FromSeed(nil, strings.Split(os.Getenv("TON_BACKEND_WALLET_MNEMONIC"), " "), wallet.V3R2)
+		if err != nil {
+			return escrow.EscrowConfig{}, utils.ErrInternal(fmt.Sprintf("FromSeed err: %s", err.Error()))
+		}
+		adminAddress = backendWallet.WalletAddress()
+	}
+
+	payee := params.PayeeAddress
+	if payee == (common.Address{}) {
+		if _, payee, err = utils.EnvKey2Ecdsa(); err != nil {
+			return escrow.EscrowConfig{}, utils.ErrInternal(err.Error())
+		}
+	}
+
+	// the escrow is unique to the user so the id is 0 unless the caller recovers an older one
+	id := big.NewInt(0)
+	if params.Id != nil {
+		id = params.Id
+	}
+
+	return escrow.EscrowConfig{
+		UserAddress:  userAddress,
+		AdminAddress: adminAddress,
+		Payee:        new(big.Int).SetBytes(payee.Bytes()),
+		Id:           id,
+		Value:        tlb.ZeroCoins,
+	}, nil
 }
 
 func calculateProxyWalletAddress(nonce uint64, entrypointAddress *address.Address, evmAddressBigInt *big.Int, tvmAddress *address.Address, workchain byte) (*address.Address, *tlb.StateInit) {
@@ -202,7 +373,7 @@ func calculateProxyWalletAddress(nonce uint64, entrypointAddress *address.Addres
 		MustStoreAddr(tvmAddress).
 		EndCell()
 
-	proxyWalletCodeCell, _ := cell.FromBOC(proxyWalletBocBuffer)
+	proxyWalletCodeCell, _ := codeCell(proxyWalletBocBuffer)
 
 	state := &tlb.StateInit{
 		Data: proxyWalletConfigCell,
@@ -431,34 +602,32 @@ func Test5Request(r *http.Request, parameters ...*UnsignedEntryPointRequestParam
 	// 	return nil, err
 	// }
 
-	url := os.Getenv("TONX_API_BASE_TESTNET_URL")
-	apiKey := os.Getenv("TONX_TESTNET_API_KEY_1")
-	jsonrpc := os.Getenv("TONX_API_JSONRPC")
+	client, err := tonx.NewTestnetClient()
+	if err != nil {
+		return nil, err
+	}
 
-	request := tonx.TonRunGetMethod{
+	request := tonx.RunGetMethod{
 		Address: "EQDuTkPoaFG8V6KZP0SVsaDF5nzYRxLfPn9o_9WdROMmqseY",
 		//4CDE9B6C823D71C3F9F31A19C78EE8F9B4649370B143BEF1660B2ADDE8362F4B
 		//1234567890123456789012345678901234567890123456789012345678901234
 		Method: "get_counter",
 	}
 
-	response, _ := tonx.SendTonXRequest(url, apiKey, jsonrpc, 1, "runGetMethod", request)
-	var parsedResponse tonx.TonRunGetMethodResponse
-	if err := json.Unmarshal([]byte(response), &parsedResponse); err != nil {
+	parsedResponse, err := client.RunGetMethod(context.Background(), request)
+	if err != nil {
 		fmt.Printf("Error parsing response: %v\n", err)
 		return nil, err
 	}
 
 	// Print parsed response details
 	fmt.Printf("Parsed Response: %+v\n", parsedResponse)
-	fmt.Printf("Gas Used: %d\n", parsedResponse.Result.GasUsed)
-	fmt.Printf("Exit Code: %d\n", parsedResponse.Result.ExitCode)
+	fmt.Printf("Gas Used: %d\n", parsedResponse.GasUsed)
+	fmt.Printf("Exit Code: %d\n", parsedResponse.ExitCode)
 	fmt.Println("Stack Pairs:")
-	for _, pair := range parsedResponse.Result.Stack {
-		fmt.Printf("Type: %s, Value: %s\n", pair[0], pair[1])
+	for _, pair := range parsedResponse.Stack {
+		fmt.Printf("Type: %s, Value: %s\n", pair.Type(), pair.Value())
 	}
-
-	fmt.Printf("\nresponse: \n%v", response)
 	// responseBody, err := io.ReadAll(response.Body)
 	// if err != nil {
 	// 	log.Fatalf("Error reading response body: %v", err)
@@ -516,13 +685,6 @@ func Test6Request(r *http.Request, parameters ...interface{}) (interface{}, erro
 	return nil, nil
 }
 
-func getTestnetTonx() (string, string, string) {
-	url := os.Getenv("TONX_API_BASE_TESTNET_URL")
-	apiKey := os.Getenv("TONX_TESTNET_API_KEY_1")
-	jsonrpc := os.Getenv("TONX_API_JSONRPC")
-	return url, apiKey, jsonrpc
-}
-
 func AssetInfoRequest(r *http.Request, parameters ...*utils.AssetInfoRequestParams) (interface{}, error) {
 	var params *utils.AssetInfoRequestParams
 
@@ -660,322 +822,3 @@ since we know raw bytes
 buuuuut the toboc and fromboc function are broke in tonutils
 so thus we need to rework them
 */
-
-// func FromBOC(input []byte) (*cell.Cell, error) {
-// // first lets investigate the tonutils serialization/deserialization
-// 	return nil, nil
-// }
-
-/*
-from cell/parse.go
-func FromBOC(data []byte) (*Cell, error) {
-	cells, err := FromBOCMultiRoot(data)
-	if err != nil {
-		return nil, err
-	}
-
-	return cells[0], nil
-}
-
-func FromBOCMultiRoot(data []byte) ([]*Cell, error) {
-	if len(data) < 10 {
-		return nil, errors.New("invalid boc")
-	}
-
-	r := newReader(data)
-
-	if !bytes.Equal(r.MustReadBytes(4), bocMagic) {
-		return nil, errors.New("invalid boc magic header")
-	}
-
-	flags, cellNumSizeBytes := parseBOCFlags(r.MustReadByte()) // has_idx:(## 1) has_crc32c:(## 1)  has_cache_bits:(## 1) flags:(## 2) { flags = 0 } size:(## 3) { size <= 4 }
-	dataSizeBytes := int(r.MustReadByte())                     // off_bytes:(## 8) { off_bytes <= 8 }
-
-	cellsNum := dynInt(r.MustReadBytes(cellNumSizeBytes)) // cells:(##(size * 8))
-	rootsNum := dynInt(r.MustReadBytes(cellNumSizeBytes)) // roots:(##(size * 8)) { roots >= 1 }
-
-	// complete BOCs - ??? (absent:(##(size * 8)) { roots + absent <= cells })
-	_ = r.MustReadBytes(cellNumSizeBytes)
-
-	dataLen := dynInt(r.MustReadBytes(dataSizeBytes)) // tot_cells_size:(##(off_bytes * 8))
-
-	// with checksum
-	if flags.HasCrc32c {
-		crc := crc32.Checksum(data[:len(data)-4], crc32.MakeTable(crc32.Castagnoli))
-		if binary.LittleEndian.Uint32(data[len(data)-4:]) != crc {
-			return nil, errors.New("checksum not matches")
-		}
-	}
-
-	rootsIndex := make([]int, rootsNum)
-	for i := 0; i < rootsNum; i++ {
-		rootsIndex[i] = dynInt(r.MustReadBytes(cellNumSizeBytes))
-	}
-
-	if flags.hasCacheBits && !flags.hasIndex {
-		return nil, fmt.Errorf("cache flag cant be set without index flag")
-	}
-
-	var index []int
-	if flags.hasIndex {
-		index = make([]int, 0, cellsNum)
-		idxData, err := r.ReadBytes(cellsNum * dataSizeBytes)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read custom index, err: %v", err)
-		}
-
-		for i := 0; i < cellsNum; i++ {
-			off := i * dataSizeBytes
-			val := dynInt(idxData[off : off+dataSizeBytes])
-			if flags.hasCacheBits {
-				// we don't need a cache, cause our loader uses memory
-				val /= 2
-			}
-			index = append(index, val)
-		}
-	}
-
-	if cellsNum > dataLen/2 {
-		return nil, fmt.Errorf("cells num looks malicious: data len %d, cells %d", dataLen, cellsNum)
-	}
-
-	payload, err := r.ReadBytes(dataLen)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read payload, want %d, has %d", dataLen, r.LeftLen())
-	}
-
-	cll, err := parseCells(rootsIndex, cellsNum, cellNumSizeBytes, payload, index)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse payload: %w", err)
-	}
-
-	return cll, nil
-}
-
-func parseCells(rootsIndex []int, cellsNum, refSzBytes int, data []byte, index []int) ([]*Cell, error) {
-	cells := make([]*Cell, cellsNum)
-	for i := 0; i < cellsNum; i++ {
-		// initialize them one by one for flexible gc and memory usage
-		cells[i] = &Cell{}
-	}
-
-	// index = nil
-	offset := 0
-	for i := 0; i < cellsNum; i++ {
-		if len(data)-offset < 2 {
-			return nil, errors.New("failed to parse cell header, corrupted data")
-		}
-
-		if index != nil {
-			// if we have index, then set offset from it, it stores end of each cell
-			offset = 0
-			if i > 0 {
-				offset = index[i-1]
-			}
-		}
-
-		// len(self.refs) + self.is_special() * 8 + self.level() * 32
-		flags := data[offset]
-		refsNum := int(flags & 0b111)
-		special := (flags & 0b1000) != 0
-		withHashes := (flags & 0b10000) != 0
-		levelMask := LevelMask{flags >> 5}
-
-		if refsNum > 4 {
-			return nil, errors.New("too many refs in cell")
-		}
-
-		ln := data[offset+1]
-		// round to 1 byte, len in octets
-		oneMore := ln % 2
-		sz := int(ln/2 + oneMore)
-
-		offset += 2
-		if len(data)-offset < sz {
-			return nil, errors.New("failed to parse cell payload, corrupted data")
-		}
-
-		if withHashes {
-			maskBits := int(math.Ceil(math.Log2(float64(levelMask.Mask) + 1)))
-			hashesNum := maskBits + 1
-
-			offset += hashesNum*hashSize + hashesNum*depthSize
-			// TODO: check depth and hashes
-		}
-
-		payload := data[offset : offset+sz]
-
-		offset += sz
-		if len(data)-offset < refsNum*refSzBytes {
-			return nil, errors.New("failed to parse cell refs, corrupted data")
-		}
-
-		refsIndex := make([]int, 0, refsNum)
-		for y := 0; y < refsNum; y++ {
-			refIndex := data[offset : offset+refSzBytes]
-
-			refsIndex = append(refsIndex, dynInt(refIndex))
-			offset += refSzBytes
-		}
-
-		refs := make([]*Cell, len(refsIndex))
-		for y, id := range refsIndex {
-			if i == id {
-				return nil, errors.New("recursive reference of cells")
-			}
-			if id < i && index == nil { // compatibility with c++ implementation
-				return nil, errors.New("reference to index which is behind parent cell")
-			}
-			if id >= len(cells) {
-				return nil, errors.New("invalid index, out of scope")
-			}
-
-			refs[y] = cells[id]
-		}
-
-		bitsSz := uint(int(ln) * 4)
-
-		// if not full byte
-		if int(ln)%2 != 0 {
-			// find last bit of byte which indicates the end and cut it and next
-			for y := uint(0); y < 8; y++ {
-				if (payload[len(payload)-1]>>y)&1 == 1 {
-					bitsSz += 3 - y
-					break
-				}
-			}
-		}
-
-		cells[i].special = special
-		cells[i].bitsSz = bitsSz
-		cells[i].levelMask = levelMask
-		cells[i].data = payload
-		cells[i].refs = refs
-	}
-
-	roots := make([]*Cell, len(rootsIndex))
-
-	for i := len(cells) - 1; i >= 0; i-- {
-		cells[i].calculateHashes()
-	}
-
-	for i, idx := range rootsIndex {
-		roots[i] = cells[idx]
-	}
-
-	return roots, nil
-}
-
-func parseBOCFlags(data byte) (bocFlags, int) {
-	return bocFlags{
-		hasIndex:     data&(1<<7) > 0,
-		HasCrc32c:    data&(1<<6) > 0,
-		hasCacheBits: data&(1<<5) > 0,
-	}, int(data & 0b00000111)
-}
-
-func dynInt(data []byte) int {
-	tmp := make([]byte, 8)
-	copy(tmp[8-len(data):], data)
-
-	return int(binary.BigEndian.Uint64(tmp))
-}
-
-
-
-
-
-
-first function to jank
-function parseBoc(src) {
-    let reader = new BitReader_1.BitReader(new BitString_1.BitString(src, 0, src.length * 8));
-    let magic = reader.loadUint(32);
-    if (magic === 0x68ff65f3) {
-        let size = reader.loadUint(8);
-        let offBytes = reader.loadUint(8);
-        let cells = reader.loadUint(size * 8);
-        let roots = reader.loadUint(size * 8); // Must be 1
-        let absent = reader.loadUint(size * 8);
-        let totalCellSize = reader.loadUint(offBytes * 8);
-        let index = reader.loadBuffer(cells * offBytes);
-        let cellData = reader.loadBuffer(totalCellSize);
-        return {
-            size,
-            offBytes,
-            cells,
-            roots,
-            absent,
-            totalCellSize,
-            index,
-            cellData,
-            root: [0]
-        };
-    }
-    else if (magic === 0xacc3a728) {
-        let size = reader.loadUint(8);
-        let offBytes = reader.loadUint(8);
-        let cells = reader.loadUint(size * 8);
-        let roots = reader.loadUint(size * 8); // Must be 1
-        let absent = reader.loadUint(size * 8);
-        let totalCellSize = reader.loadUint(offBytes * 8);
-        let index = reader.loadBuffer(cells * offBytes);
-        let cellData = reader.loadBuffer(totalCellSize);
-        let crc32 = reader.loadBuffer(4);
-        if (!(0, crc32c_1.crc32c)(src.subarray(0, src.length - 4)).equals(crc32)) {
-            throw Error('Invalid CRC32C');
-        }
-        return {
-            size,
-            offBytes,
-            cells,
-            roots,
-            absent,
-            totalCellSize,
-            index,
-            cellData,
-            root: [0]
-        };
-    }
-    else if (magic === 0xb5ee9c72) {
-        let hasIdx = reader.loadUint(1);
-        let hasCrc32c = reader.loadUint(1);
-        let hasCacheBits = reader.loadUint(1);
-        let flags = reader.loadUint(2); // Must be 0
-        let size = reader.loadUint(3);
-        let offBytes = reader.loadUint(8);
-        let cells = reader.loadUint(size * 8);
-        let roots = reader.loadUint(size * 8);
-        let absent = reader.loadUint(size * 8);
-        let totalCellSize = reader.loadUint(offBytes * 8);
-        let root = [];
-        for (let i = 0; i < roots; i++) {
-            root.push(reader.loadUint(size * 8));
-        }
-        let index = null;
-        if (hasIdx) {
-            index = reader.loadBuffer(cells * offBytes);
-        }
-        let cellData = reader.loadBuffer(totalCellSize);
-        if (hasCrc32c) {
-            let crc32 = reader.loadBuffer(4);
-            if (!(0, crc32c_1.crc32c)(src.subarray(0, src.length - 4)).equals(crc32)) {
-                throw Error('Invalid CRC32C');
-            }
-        }
-        return {
-            size,
-            offBytes,
-            cells,
-            roots,
-            absent,
-            totalCellSize,
-            index,
-            cellData,
-            root
-        };
-    }
-    else {
-        throw Error('Invalid magic');
-    }
-}
-*/
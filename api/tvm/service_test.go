@@ -0,0 +1,54 @@
+package tvmHandler
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/escrow"
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/eip712"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestLockMessageToCell(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+	hash := crypto.Keccak256([]byte("destination op"))
+	signature, err := crypto.Sign(eip712.PersonalHash(hash), privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := lockMessageToCell(hash, signer, hex.EncodeToString(signature))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slice := lock.BeginParse()
+	if op := slice.MustLoadUInt(32); op != escrow.OpLock {
+		t.Fatalf("op %d, want %d", op, escrow.OpLock)
+	}
+	stored := slice.MustLoadRef()
+	if stored.BitsLeft() != escrow.SignatureBits {
+		t.Fatalf("stored %d signature bits, want %d", stored.BitsLeft(), escrow.SignatureBits)
+	}
+	v := stored.MustLoadUInt(8)
+	r := stored.MustLoadBigUInt(256)
+	s := stored.MustLoadBigUInt(256)
+	if v != uint64(signature[64])+27 || r.Cmp(new(big.Int).SetBytes(signature[:32])) != 0 || s.Cmp(new(big.Int).SetBytes(signature[32:64])) != 0 {
+		t.Fatalf("stored v %d r %x s %x do not match the signature %x", v, r, s, signature)
+	}
+
+	other, _ := crypto.GenerateKey()
+	_, err = lockMessageToCell(hash, crypto.PubkeyToAddress(other.PublicKey), hex.EncodeToString(signature))
+	if !apierr.Is(err, apierr.SignatureInvalid) {
+		t.Fatalf("lock signed by another key: got %v", err)
+	}
+	if _, err := lockMessageToCell(hash, common.Address{}, hex.EncodeToString(signature)); !apierr.Is(err, apierr.InvalidParam) {
+		t.Fatalf("lock without signer: got %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+package tvmHandler
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	tvmUtils "github.com/crosscall-labs/crosschain-api/api/tvm/utils"
+	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/jettonMinter"
+	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/proxyWallet"
+	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/stonfiPool"
+	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/stonfiRouter"
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/xssnick/tonutils-go/address"
+)
+
+const (
+	defaultSwapSlippage = 100 // bps
+	defaultSwapDeadline = 15 * time.Minute
+)
+
+type SwapQuoteRequestParams struct {
+	ChainId     string   `query:"chain-id"`
+	Router      string   `query:"router" optional:"true"` // default swap router of the chain
+	OfferAsset  string   `query:"offer-asset"`            // jetton minter
+	AskAsset    string   `query:"ask-asset"`              // jetton minter, 0 for TON
+	OfferAmount *big.Int `query:"offer-amount" validate:"positive"`
+	Slippage    *big.Int `query:"slippage" optional:"true" validate:"uint"` // bps of the expected output, default 1%
+}
+
+type SwapQuoteResponse struct {
+	Router          string `json:"router"`
+	Pool            string `json:"pool"`
+	OfferAsset      string `json:"offer-asset"`
+	OfferWallet     string `json:"offer-wallet"` // router's wallet of the offer jetton
+	AskAsset        string `json:"ask-asset"`
+	AskWallet       string `json:"ask-wallet"` // router's wallet of the ask jetton or of pton
+	OfferAmount     string `json:"offer-amount"`
+	AskAmount       string `json:"ask-amount"`
+	MinAskAmount    string `json:"min-ask-amount"`
+	ProtocolFeePaid string `json:"protocol-fee-paid"`
+	RefFeePaid      string `json:"ref-fee-paid"`
+	Reserve0        string `json:"reserve0"`
+	Reserve1        string `json:"reserve1"`
+}
+
+type UnsignedSwapRequestParams struct {
+	Quote           SwapQuoteRequestParams `query:"quote"`
+	SenderAddress   *address.Address       `query:"sender-address"`                   // wallet holding the offer jetton, usually the proxy wallet
+	ReceiverAddress *address.Address       `query:"receiver-address" optional:"true"` // default sender
+	Deadline        uint64                 `query:"deadline" optional:"true"`         // unix seconds, default 15 minutes
+}
+
+type UnsignedSwapRequestResponse struct {
+	Quote SwapQuoteResponse `json:"quote"`
+	UnsignedMintToRequestResponse
+}
+
+func SwapQuoteRequest(r *http.Request, parameters ...*SwapQuoteRequestParams) (interface{}, error) {
+	var params *SwapQuoteRequestParams
+
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &SwapQuoteRequestParams{}
+	}
+
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	quote, _, err := quoteSwap(*params)
+	if err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// UnsignedSwapRequest returns the execution data of a swap for the sender, the proxy wallet runs it
+// before the rest of the intent so the user can receive any jetton the pool trades
+func UnsignedSwapRequest(r *http.Request, parameters ...*UnsignedSwapRequestParams) (interface{}, error) {
+	var params *UnsignedSwapRequestParams
+
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &UnsignedSwapRequestParams{}
+	}
+
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	sender := params.SenderAddress
+	receiver := sender
+	if params.ReceiverAddress != nil {
+		receiver = params.ReceiverAddress
+	}
+	deadline := uint64(time.Now().Add(defaultSwapDeadline).Unix())
+	if params.Deadline != 0 {
+		if int64(params.Deadline) <= time.Now().Unix() {
+			return nil, utils.ErrMalformedRequest("invalid deadline, expected a future unix time")
+		}
+		deadline = params.Deadline
+	}
+
+	quote, swap, err := quoteSwap(params.Quote)
+	if err != nil {
+		return nil, err
+	}
+	swap.Receiver = receiver
+	swap.RefundAddress = sender
+	swap.Deadline = deadline
+
+	// the transfer is sent to the sender's own wallet of the offer jetton
+	senderWallet, err := jettonWalletAddress(sender.String(), params.Quote.OfferAsset)
+	if err != nil {
+		return nil, utils.ErrInternal(fmt.Sprintf("failed to get sender jetton wallet: %v", err))
+	}
+
+	executionData := proxyWallet.ExecutionData{Destination: senderWallet}
+	if isTonAsset(params.Quote.AskAsset) {
+		executionData.Value = stonfiRouter.JettonToTonGasAmount.Nano().Uint64()
+		executionData.Body = stonfiRouter.JettonToTonSwapMessageToCell(swap)
+	} else {
+		executionData.Value = stonfiRouter.JettonToJettonGasAmount.Nano().Uint64()
+		executionData.Body = stonfiRouter.JettonToJettonSwapMessageToCell(swap)
+	}
+
+	return UnsignedSwapRequestResponse{
+		Quote: quote,
+		UnsignedMintToRequestResponse: UnsignedMintToRequestResponse{
+			Regime:      fmt.Sprint(executionData.Regime),
+			Destination: executionData.Destination.String(),
+			Value:       fmt.Sprint(executionData.Value),
+			Body:        hex.EncodeToString(executionData.Body.ToBOC()),
+			Hash:        hex.EncodeToString(proxyWallet.ExecutionDataToCell(executionData).Hash()),
+		},
+	}, nil
+}
+
+// quoteSwap quotes the swap against its pool and returns the swap message without sender, receiver and deadline
+func quoteSwap(params SwapQuoteRequestParams) (SwapQuoteResponse, stonfiRouter.SwapMessage, error) {
+	chain, err := chains.Get(params.ChainId)
+	if err != nil {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.Err(apierr.UnsupportedChain, err.Error())
+	}
+	if chain.VM != "tvm" {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrMalformedRequest(fmt.Sprintf("chain id %s is not a tvm chain", chain.ID))
+	}
+
+	routerRaw := params.Router
+	if routerRaw == "" {
+		routerRaw = chain.Contracts.SwapRouter
+	}
+	if routerRaw == "" {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrMalformedRequest(fmt.Sprintf("no swap router for chain id %s", chain.ID))
+	}
+	router, err := address.ParseAddr(routerRaw)
+	if err != nil {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrMalformedRequest(fmt.Sprintf("invalid router address: %v", err))
+	}
+
+	offerAmount := params.OfferAmount
+	if offerAmount == nil || offerAmount.Sign() <= 0 {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrMalformedRequest("invalid offer amount")
+	}
+	slippage := int64(defaultSwapSlippage)
+	if params.Slippage != nil {
+		if params.Slippage.Cmp(big.NewInt(10000)) > 0 {
+			return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrMalformedRequest("invalid slippage, expected 0 to 10000 bps")
+		}
+		slippage = params.Slippage.Int64()
+	}
+
+	// jetton→TON swaps into pton, the router's wallet of it is the ask wallet
+	askMinter := params.AskAsset
+	if isTonAsset(askMinter) {
+		if askMinter = chain.Contracts.SwapProxyTon; askMinter == "" {
+			return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrMalformedRequest(fmt.Sprintf("no proxy ton for chain id %s", chain.ID))
+		}
+	}
+	if isTonAsset(params.OfferAsset) {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrMalformedRequest("offer asset must be a jetton")
+	}
+
+	offerWallet, err := jettonWalletAddress(router.String(), params.OfferAsset)
+	if err != nil {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrInternal(fmt.Sprintf("failed to get router offer wallet: %v", err))
+	}
+	askWallet, err := jettonWalletAddress(router.String(), askMinter)
+	if err != nil {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrInternal(fmt.Sprintf("failed to get router ask wallet: %v", err))
+	}
+
+	pool, err := stonfiRouter.GetPoolAddress(router.String(), offerWallet.String(), askWallet.String())
+	if err != nil {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrInternal(fmt.Sprintf("failed to get pool: %v", err))
+	}
+	poolData, err := stonfiPool.GetPoolData(pool.PoolAddress)
+	if err != nil {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrInternal(fmt.Sprintf("failed to get pool data: %v", err))
+	}
+	if poolData.IsLocked {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrMalformedRequest(fmt.Sprintf("pool %s is locked", pool.PoolAddress))
+	}
+	outputs, err := stonfiPool.GetExpectedOutputs(pool.PoolAddress, offerAmount.String(), offerWallet.String())
+	if err != nil {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrInternal(fmt.Sprintf("failed to get expected outputs: %v", err))
+	}
+
+	askAmount, ok := new(big.Int).SetString(outputs.JettonsToReceive, 10)
+	if !ok || askAmount.Sign() <= 0 {
+		return SwapQuoteResponse{}, stonfiRouter.SwapMessage{}, utils.ErrMalformedRequest("offer amount is too small for the pool")
+	}
+	minAskAmount := new(big.Int).Mul(askAmount, big.NewInt(10000-slippage))
+	minAskAmount.Div(minAskAmount, big.NewInt(10000))
+
+	quote := SwapQuoteResponse{
+		Router:          router.String(),
+		Pool:            pool.PoolAddress,
+		OfferAsset:      params.OfferAsset,
+		OfferWallet:     offerWallet.String(),
+		AskAsset:        params.AskAsset,
+		AskWallet:       askWallet.String(),
+		OfferAmount:     offerAmount.String(),
+		AskAmount:       askAmount.String(),
+		MinAskAmount:    minAskAmount.String(),
+		ProtocolFeePaid: outputs.ProtocolFeePaid,
+		RefFeePaid:      outputs.RefFeePaid,
+		Reserve0:        poolData.Reserve0,
+		Reserve1:        poolData.Reserve1,
+	}
+	swap := stonfiRouter.SwapMessage{
+		OfferAmount:     offerAmount,
+		Router:          router,
+		AskJettonWallet: askWallet,
+		MinAskAmount:    minAskAmount,
+	}
+	return quote, swap, nil
+}
+
+func jettonWalletAddress(ownerRaw string, minterRaw string) (*address.Address, error) {
+	wallet, err := jettonMinter.GetWalletAddress(ownerRaw, minterRaw)
+	if err != nil {
+		return nil, err
+	}
+	return tvmUtils.ParseAddressCell(wallet.WalletAddress)
+}
+
+func isTonAsset(asset string) bool {
+	return asset == "" || asset == "0"
+}
@@ -0,0 +1,35 @@
+package tvmHandler
+
+import (
+	"testing"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/xssnick/tonutils-go/address"
+)
+
+// quoteSwap defaults to the configured router and swaps into its pton for jetton→TON
+func TestSwapContractsConfigured(t *testing.T) {
+	configured := 0
+	for _, chain := range chains.Default().Chains() {
+		if chain.VM != "tvm" || chain.Contracts.SwapRouter == "" {
+			continue
+		}
+		configured++
+		for name, raw := range map[string]string{"swap-router": chain.Contracts.SwapRouter, "swap-proxy-ton": chain.Contracts.SwapProxyTon} {
+			if _, err := address.ParseAddr(raw); err != nil {
+				t.Fatalf("chain %s %s %q: %v", chain.ID, name, raw, err)
+			}
+		}
+	}
+	if configured == 0 {
+		t.Fatal("no tvm chain configures a swap router")
+	}
+}
+
+func TestIsTonAsset(t *testing.T) {
+	for asset, want := range map[string]bool{"": true, "0": true, "kQACS30DNoUQ7NfApPvzh7eBmSZ9L4ygJ-lkNWtba8TQT-Px": false} {
+		if got := isTonAsset(asset); got != want {
+			t.Fatalf("isTonAsset(%q) = %v, want %v", asset, got, want)
+		}
+	}
+}
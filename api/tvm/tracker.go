@@ -0,0 +1,447 @@
+package tvmHandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/bind"
+	"github.com/crosscall-labs/crosschain-api/pkg/events"
+	"github.com/crosscall-labs/crosschain-api/pkg/tonx"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
+	"github.com/xssnick/tonutils-go/ton/wallet"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+const (
+	MessagePending   = "pending"
+	MessageConfirmed = "confirmed"
+	MessageFailed    = "failed"
+)
+
+const (
+	trackInterval = 3 * time.Second
+	trackTimeout  = 2 * time.Minute
+	trackTxLimit  = 16 // latest wallet transactions searched for the message
+	executionHops = 2  // entrypoint -> proxy wallet -> destination
+
+	trackRetention = 30 * time.Minute // tracked messages are dropped once older
+	maxTracked     = 1024
+
+	// lifetime of the external messages the backend wallet signs, the default of tonutils
+	messageTTL = 3 * time.Minute
+)
+
+type SentMessageResponse struct {
+	Hash   string `json:"hash"` // hex hash of the external message
+	Wallet string `json:"wallet"`
+	Status string `json:"status"`
+}
+
+type LocatedTx struct {
+	Address string `json:"address"`
+	Lt      string `json:"lt"`
+	Hash    string `json:"hash"` // hex
+	Utime   int64  `json:"utime"`
+	Fee     string `json:"fee"`
+}
+
+type TrackedMessage struct {
+	Hash         string      `json:"hash"`
+	Wallet       string      `json:"wallet"`
+	Status       string      `json:"status"`
+	Transactions []LocatedTx `json:"transactions,omitempty"` // wallet transaction then one per outgoing message
+	Error        string      `json:"error,omitempty"`
+	SentAt       time.Time   `json:"sent-at"`
+}
+
+type MessageStatusRequestParams struct {
+	Hash bind.HexBytes `query:"hash" validate:"len=32"`
+}
+
+// messageEvents are published to the event bus while a message is tracked, Steps names the
+// lifecycle step of each message the backend wallet sends. The intent, if any, is moved to
+// executed or failed with the message.
+type messageEvents struct {
+	Keys   []string
+	Steps  []events.Kind
+	Intent string
+}
+
+func (n *messageEvents) withKey(key string) *messageEvents {
+	if n == nil {
+		return nil
+	}
+	return &messageEvents{Keys: append([]string{key}, n.Keys...), Steps: n.Steps, Intent: n.Intent}
+}
+
+func (n *messageEvents) step(i int) events.Kind {
+	if n == nil || i >= len(n.Steps) {
+		return ""
+	}
+	return n.Steps[i]
+}
+
+func (n *messageEvents) publish(kind events.Kind, txHash string, reason string) {
+	if n == nil || kind == "" {
+		return
+	}
+	if n.Intent != "" && (kind == events.DestinationExecuted || kind == events.Failed) {
+		executeIntent(n.Intent, txHash, reason)
+	}
+	events.Publish(events.Event{
+		Kind:   kind,
+		Keys:   n.Keys,
+		VM:     "tvm",
+		TxHash: txHash,
+		Reason: reason,
+	})
+}
+
+// messageTracker keeps the sent messages of this instance in memory for trackRetention. Tracking
+// runs in a background goroutine, so it needs the long running server of main.go, a serverless
+// instance is frozen once it responds and the status is only known to the instance that sent it.
+type messageTracker struct {
+	mu       sync.Mutex
+	messages map[string]*TrackedMessage
+}
+
+var tracker = &messageTracker{messages: map[string]*TrackedMessage{}}
+
+// walletSender serialises the sends of one backend wallet. The on-chain seqno only moves once a
+// message is processed, so a send before then continues from the seqno this instance used last.
+type walletSender struct {
+	mu     sync.Mutex
+	next   uint32
+	sentAt time.Time
+}
+
+var senders = struct {
+	mu      sync.Mutex
+	wallets map[string]*walletSender
+}{wallets: map[string]*walletSender{}}
+
+func senderOf(walletAddress *address.Address) *walletSender {
+	senders.mu.Lock()
+	defer senders.mu.Unlock()
+
+	key := walletAddress.String()
+	sender, ok := senders.wallets[key]
+	if !ok {
+		sender = &walletSender{}
+		senders.wallets[key] = sender
+	}
+	return sender
+}
+
+// seqno returns the seqno of the next message, the on-chain one unless a message sent within its
+// lifetime is still pending. Callers hold s.mu.
+func (s *walletSender) seqno(ctx context.Context, client *tonx.Client, walletAddress *address.Address) (uint32, error) {
+	info, err := client.GetWalletInformation(ctx, tonx.TonGetWalletInformation{Address: walletAddress.String()})
+	if err != nil {
+		return 0, err
+	}
+	seqno := uint32(info.Seqno)
+	if time.Since(s.sentAt) < messageTTL && s.next > seqno {
+		seqno = s.next
+	}
+	return seqno, nil
+}
+
+type seqnoSpec interface {
+	SetSeqnoFetcher(fetcher func(ctx context.Context, subWallet uint32) (uint32, error))
+}
+
+// sendExternalMessage signs the messages with the backend wallet, submits the external message
+// through tonx and tracks it in the background instead of waiting for the transaction, notify may be nil.
+// Sends of the same wallet are serialised so concurrent requests don't reuse a seqno.
+func sendExternalMessage(ctx context.Context, w *wallet.Wallet, notify *messageEvents, messages ...*wallet.Message) (SentMessageResponse, error) {
+	client, err := tonx.NewTestnetClient()
+	if err != nil {
+		return SentMessageResponse{}, utils.ErrInternal(err.Error())
+	}
+
+	spec, ok := w.GetSpec().(seqnoSpec)
+	if !ok {
+		return SentMessageResponse{}, utils.ErrInternal("backend wallet has no seqno")
+	}
+	sender := senderOf(w.WalletAddress())
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	seqno, err := sender.seqno(ctx, client, w.WalletAddress())
+	if err != nil {
+		return SentMessageResponse{}, utils.ErrInternal(fmt.Sprintf("failed to get wallet seqno: %v", err))
+	}
+	spec.SetSeqnoFetcher(func(ctx context.Context, subWallet uint32) (uint32, error) {
+		return seqno, nil
+	})
+
+	ext, err := w.BuildExternalMessageForMany(ctx, messages)
+	if err != nil {
+		return SentMessageResponse{}, utils.ErrInternal(fmt.Sprintf("failed to build external message: %v", err))
+	}
+	extCell, err := tlb.ToCell(ext)
+	if err != nil {
+		return SentMessageResponse{}, utils.ErrInternal(fmt.Sprintf("failed to serialize external message: %v", err))
+	}
+	hash := extCell.Hash()
+
+	sent, err := client.SendBocReturnHash(ctx, tonx.TonSendBocReturnHash{
+		Boc: base64.StdEncoding.EncodeToString(extCell.ToBOC()),
+	})
+	if err != nil {
+		return SentMessageResponse{}, utils.ErrInternal(fmt.Sprintf("failed to send message: %v", err))
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(sent.Hash); err != nil || !bytes.Equal(decoded, hash) {
+		utils.LogError("tonx returned a different message hash", sent.Hash)
+	}
+	sender.next, sender.sentAt = seqno+1, time.Now()
+
+	message := tracker.add(hex.EncodeToString(hash), w.WalletAddress())
+	go tracker.track(client, message.Hash, w.WalletAddress(), notify)
+
+	return SentMessageResponse{
+		Hash:   message.Hash,
+		Wallet: message.Wallet,
+		Status: message.Status,
+	}, nil
+}
+
+func MessageStatusRequest(r *http.Request, parameters ...*MessageStatusRequestParams) (interface{}, error) {
+	var params *MessageStatusRequestParams
+
+	if len(parameters) > 0 {
+		params = parameters[0]
+	} else {
+		params = &MessageStatusRequestParams{}
+	}
+
+	if r != nil {
+		if err := utils.ParseAndValidateParams(r, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	message, ok := tracker.get(params.Hash.String())
+	if !ok {
+		return nil, utils.Err(apierr.NotFound, fmt.Sprintf("message %s is not tracked by this instance or older than %s", params.Hash, trackRetention))
+	}
+	return message, nil
+}
+
+func (t *messageTracker) add(hash string, walletAddress *address.Address) TrackedMessage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.evict(time.Now())
+	message := &TrackedMessage{
+		Hash:   hash,
+		Wallet: walletAddress.String(),
+		Status: MessagePending,
+		SentAt: time.Now(),
+	}
+	t.messages[hash] = message
+	return *message
+}
+
+// evict drops the messages older than trackRetention, then the oldest ones above maxTracked.
+// Callers hold t.mu.
+func (t *messageTracker) evict(now time.Time) {
+	for hash, message := range t.messages {
+		if now.Sub(message.SentAt) > trackRetention {
+			delete(t.messages, hash)
+		}
+	}
+	for len(t.messages) >= maxTracked {
+		var oldest string
+		for hash, message := range t.messages {
+			if oldest == "" || message.SentAt.Before(t.messages[oldest].SentAt) {
+				oldest = hash
+			}
+		}
+		delete(t.messages, oldest)
+	}
+}
+
+func (t *messageTracker) get(hash string) (TrackedMessage, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	message, ok := t.messages[hash]
+	if !ok {
+		return TrackedMessage{}, false
+	}
+	return *message, true
+}
+
+func (t *messageTracker) finish(hash string, status string, transactions []LocatedTx, errMessage string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	message, ok := t.messages[hash]
+	if !ok {
+		return
+	}
+	message.Status = status
+	message.Transactions = transactions
+	message.Error = errMessage
+}
+
+// track finds the wallet transaction of the external message, then locates the transaction of
+// every message it sent with tryLocateTx. The steps of notify are published as they are located.
+func (t *messageTracker) track(client *tonx.Client, hash string, walletAddress *address.Address, notify *messageEvents) {
+	ctx, cancel := context.WithTimeout(context.Background(), trackTimeout)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	hashBytes, _ := hex.DecodeString(hash)
+	notify = notify.withKey(hash)
+
+	var walletTx *tonx.RawTransaction
+	for walletTx == nil {
+		if time.Now().After(deadline) {
+			t.fail(hash, nil, "wallet transaction not found", notify)
+			return
+		}
+		time.Sleep(trackInterval)
+
+		transactions, err := client.GetTransactions(ctx, tonx.TonGetTransactions{
+			Address: walletAddress.String(),
+			Limit:   trackTxLimit,
+		})
+		if err != nil {
+			utils.LogError("failed to get wallet transactions", err.Error())
+			continue
+		}
+		for i, tx := range *transactions {
+			inHash, err := base64.StdEncoding.DecodeString(tx.InMsg.Hash)
+			if err == nil && bytes.Equal(inHash, hashBytes) {
+				walletTx = &(*transactions)[i]
+				break
+			}
+		}
+	}
+
+	located := []LocatedTx{toLocatedTx(walletAddress.String(), walletTx)}
+	for i, out := range walletTx.OutMsgs {
+		tx, err := locateTx(ctx, client, out)
+		if err != nil {
+			t.fail(hash, located, err.Error(), notify)
+			return
+		}
+		located = append(located, toLocatedTx(out.Destination, tx))
+		if reason := txFailure(tx); reason != "" {
+			t.fail(hash, located, fmt.Sprintf("transaction of %s failed: %s", out.Destination, reason), notify)
+			return
+		}
+
+		step := notify.step(i)
+		if step == events.DestinationExecuted {
+			// the entrypoint forwards to the proxy wallet, which sends the execution message
+			for hop := 0; hop < executionHops && len(tx.OutMsgs) > 0; hop++ {
+				next := tx.OutMsgs[0]
+				if tx, err = locateTx(ctx, client, next); err != nil {
+					t.fail(hash, located, err.Error(), notify)
+					return
+				}
+				located = append(located, toLocatedTx(next.Destination, tx))
+				if reason := txFailure(tx); reason != "" {
+					t.fail(hash, located, fmt.Sprintf("transaction of %s failed: %s", next.Destination, reason), notify)
+					return
+				}
+			}
+		}
+		notify.publish(step, located[len(located)-1].Hash, "")
+	}
+
+	t.finish(hash, MessageConfirmed, located, "")
+	utils.LogInfo("Tracked message confirmed", utils.FormatKeyValueLogs([][2]string{
+		{"hash", hash},
+		{"transactions", strconv.Itoa(len(located))},
+	}))
+}
+
+func (t *messageTracker) fail(hash string, located []LocatedTx, reason string, notify *messageEvents) {
+	t.finish(hash, MessageFailed, located, reason)
+	notify.publish(events.Failed, "", reason)
+	utils.LogError("tracked message failed", utils.FormatKeyValueLogs([][2]string{
+		{"hash", hash},
+		{"reason", reason},
+	}))
+}
+
+// locateTx retries tryLocateTx for the transaction of an outgoing message until the context ends
+func locateTx(ctx context.Context, client *tonx.Client, out tonx.RawMessage) (*tonx.RawTransaction, error) {
+	createdLt, err := strconv.Atoi(out.CreatedLt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created lt %s", out.CreatedLt)
+	}
+	for {
+		tx, err := client.TryLocateTx(ctx, tonx.TonTryLocateTx{
+			Source:      out.Source,
+			Destination: out.Destination,
+			CreatedLt:   createdLt,
+		})
+		if err == nil {
+			return (*tonx.RawTransaction)(tx), nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction of %s not found", out.Destination)
+		case <-time.After(trackInterval):
+		}
+	}
+}
+
+// txFailure returns why an ordinary transaction was aborted, empty if it succeeded or the
+// transaction data could not be parsed
+func txFailure(tx *tonx.RawTransaction) string {
+	data, err := base64.StdEncoding.DecodeString(tx.Data)
+	if err != nil {
+		return ""
+	}
+	root, err := cell.FromBOC(data)
+	if err != nil {
+		return ""
+	}
+	var parsed tlb.Transaction
+	if err := tlb.LoadFromCell(&parsed, root.BeginParse()); err != nil {
+		return ""
+	}
+	description, ok := parsed.Description.Description.(tlb.TransactionDescriptionOrdinary)
+	if !ok || !description.Aborted {
+		return ""
+	}
+	switch phase := description.ComputePhase.Phase.(type) {
+	case tlb.ComputePhaseVM:
+		if !phase.Success {
+			return fmt.Sprintf("exit code %d", phase.Details.ExitCode)
+		}
+	case tlb.ComputePhaseSkipped:
+		return "compute phase skipped"
+	}
+	if description.ActionPhase != nil && !description.ActionPhase.Success {
+		return fmt.Sprintf("action phase result code %d", description.ActionPhase.ResultCode)
+	}
+	return "aborted"
+}
+
+func toLocatedTx(account string, tx *tonx.RawTransaction) LocatedTx {
+	txHash, _ := base64.StdEncoding.DecodeString(tx.TransactionId.Hash)
+	return LocatedTx{
+		Address: account,
+		Lt:      tx.TransactionId.Lt,
+		Hash:    hex.EncodeToString(txHash),
+		Utime:   tx.Utime,
+		Fee:     tx.Fee,
+	}
+}
@@ -0,0 +1,73 @@
+package tvmHandler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/tonx"
+	"github.com/crosscall-labs/crosschain-api/pkg/tonx/tonxtest"
+	"github.com/xssnick/tonutils-go/address"
+)
+
+func TestTrackerEvict(t *testing.T) {
+	now := time.Now()
+	tracker := &messageTracker{messages: map[string]*TrackedMessage{
+		"old":    {Hash: "old", SentAt: now.Add(-trackRetention - time.Second)},
+		"recent": {Hash: "recent", SentAt: now.Add(-time.Minute)},
+	}}
+	tracker.evict(now)
+	if _, ok := tracker.messages["old"]; ok {
+		t.Fatal("message older than the retention was kept")
+	}
+	if _, ok := tracker.messages["recent"]; !ok {
+		t.Fatal("recent message was evicted")
+	}
+
+	for i := 0; i < maxTracked; i++ {
+		hash := fmt.Sprint(i)
+		tracker.messages[hash] = &TrackedMessage{Hash: hash, SentAt: now.Add(time.Duration(i) * time.Millisecond)}
+	}
+	tracker.evict(now)
+	if len(tracker.messages) != maxTracked-1 {
+		t.Fatalf("tracked %d messages, want %d", len(tracker.messages), maxTracked-1)
+	}
+	if _, ok := tracker.messages["recent"]; ok {
+		t.Fatal("oldest message was kept above the limit")
+	}
+}
+
+func TestWalletSenderSeqno(t *testing.T) {
+	server := tonxtest.NewServer()
+	defer server.Close()
+	server.Result("getWalletInformation", tonx.TonGetWalletInformationResponse{Wallet: true, Seqno: 7})
+
+	walletAddress := address.MustParseAddr("EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N")
+	sender := &walletSender{}
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		next   uint32
+		sentAt time.Time
+		want   uint32
+	}{
+		{"nothing sent", 0, time.Time{}, 7},
+		{"pending message", 8, time.Now(), 8},
+		{"processed message", 7, time.Now(), 7},
+		{"expired message", 8, time.Now().Add(-messageTTL), 7},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sender.next, sender.sentAt = test.next, test.sentAt
+			seqno, err := sender.seqno(ctx, server.Client(), walletAddress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if seqno != test.want {
+				t.Fatalf("seqno %d, want %d", seqno, test.want)
+			}
+		})
+	}
+}
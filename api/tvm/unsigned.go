@@ -7,14 +7,15 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/entrypoint"
 	"github.com/crosscall-labs/crosschain-api/api/tvm/utils/proxyWallet"
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/eip712"
+	"github.com/crosscall-labs/crosschain-api/pkg/events"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/xssnick/tonutils-go/address"
 	"github.com/xssnick/tonutils-go/tlb"
-	"github.com/xssnick/tonutils-go/ton"
-	"github.com/xssnick/tonutils-go/ton/wallet"
 )
 
 func CreateUnsignedMintCall(
@@ -53,8 +54,7 @@ func CreateUnsignedMintCall(
 func SignedEntryPointRequest(r *http.Request, parameters ...*SignedEntryPointRequestParams) (interface{}, error) {
 	utils.LogNotice("SignedEntryPointRequest called!")
 	var params *SignedEntryPointRequestParams
-	var isInit bool
-	fmt.Print((isInit))
+	var needsDeploy bool
 
 	if len(parameters) > 0 {
 		params = parameters[0]
@@ -75,30 +75,28 @@ func SignedEntryPointRequest(r *http.Request, parameters ...*SignedEntryPointReq
 
 	// ##################### PARSE AND VALIDATE PARAMS ##########################
 	utils.LogNotice("Begin parse & validate parameters")
-	entrypointAddress := address.MustParseAddr("kQD-99yAg5IsTqRt2qiw1IDkEexCXcaaGmMU-MPhL74cHwCM")
-	b, err := api.GetMasterchainInfo(ctx)
+	if params.ChainId == "" {
+		params.ChainId = testnetChainId
+	}
+	entrypointAddress, err := entrypointOf(params.ChainId)
 	if err != nil {
 		return nil, err
 	}
-	if ok := common.IsHexAddress(params.EvmAddress); !ok {
-		utils.LogError("evm-address is not a valid hex", params.EvmAddress)
-		return nil, utils.ErrInternal("evm-address is not a valid hex")
+	b, err := api.GetMasterchainInfo(ctx)
+	if err != nil {
+		return nil, err
 	}
-	evmAddress := common.HexToAddress(params.EvmAddress)
+	evmAddress := params.EvmAddress
 	evmAddressBigInt := new(big.Int).SetBytes(evmAddress.Bytes())
-	if evmAddressBigInt.BitLen() > 160 {
-		utils.LogError("invalid evm-address", "exceeds 160 bits")
-		return nil, utils.ErrInternal("invalid address: exceeds 160 bits")
-	}
-	tvmAddress := address.MustParseAddr(params.TvmAddress)
+	tvmAddress := params.TvmAddress
 	initNonce := 0 // should be taking entrypoint from params by static for now
 	proxyWalletAddress, state := calculateProxyWalletAddress(uint64(initNonce), entrypointAddress, evmAddressBigInt, tvmAddress, byte(b.Workchain))
-	if _, err := getWalletInfo(proxyWalletAddress.String()); err != nil { // don't need any data from proxy wallet, just if valid
-		utils.LogInfoSimple(fmt.Sprintf("proxy wallet %+v status: INITIALIZED", proxyWalletAddress.String()))
-		isInit = false
-	} else {
+	// get_wallet_info fails until the proxy wallet is deployed, its data is not needed
+	if _, err := getWalletInfo(proxyWalletAddress.String()); err != nil {
 		utils.LogInfoSimple(fmt.Sprintf("proxy wallet %+v status: NOT INITIALIZED", proxyWalletAddress.String()))
-		isInit = true
+		needsDeploy = true
+	} else {
+		utils.LogInfoSimple(fmt.Sprintf("proxy wallet %+v status: INITIALIZED", proxyWalletAddress.String()))
 	}
 	executionData, err := ToExecutionData(params.Message.Data)
 	if err != nil {
@@ -108,33 +106,12 @@ func SignedEntryPointRequest(r *http.Request, parameters ...*SignedEntryPointReq
 
 	// ######################### VALIDATE SIGNATURE #############################
 	utils.LogNotice("Begin signature vaidation")
-	var signature proxyWallet.Signature
-	signature.V, err = strconv.ParseUint(params.Message.Signature.V, 10, 64)
+	signature, err := proxyWallet.ParseSignature(params.Message.Signature.V, params.Message.Signature.R, params.Message.Signature.S)
 	if err != nil {
-		utils.LogError("invalid sig-v value", err.Error())
-		return nil, utils.ErrInternal(fmt.Sprintf("invalid sig-v value: %v", err.Error()))
+		utils.LogError("invalid signature", err.Error())
+		return nil, utils.Err(apierr.SignatureInvalid, fmt.Sprintf("invalid signature: %v", err))
 	}
-
-	signatureR, err := hex.DecodeString(params.Message.Signature.R)
-	if err != nil {
-		utils.LogError("invalid sig-s value", err.Error())
-		return nil, utils.ErrInternal(fmt.Sprintf("invalid sig-r value: %v", err.Error()))
-	}
-	signature.R, _ = utils.BytesToUint64(signatureR)
-
-	signatureS, err := hex.DecodeString(params.Message.Signature.S)
-	if err != nil {
-		utils.LogError("invalid sig-s value", err.Error())
-		return nil, utils.ErrInternal(fmt.Sprintf("invalid sig-s value: %v", err.Error()))
-	}
-	signature.S, _ = utils.BytesToUint64(signatureR)
-
-	if signature.V >= 27 {
-		signature.V -= 27
-	}
-
-	signatureBytes := append(signatureR, signatureS...)
-	signatureBytes = append(signatureBytes, byte(signature.V))
+	signatureBytes := signature.Bytes()
 
 	utils.LogInfo("Signature details", utils.FormatKeyValueLogs([][2]string{
 		{"address", evmAddress.Hex()},
@@ -143,14 +120,29 @@ func SignedEntryPointRequest(r *http.Request, parameters ...*SignedEntryPointReq
 		{"module", "signature-validation"},
 	}))
 
-	if ok, err := ValidateEvmEcdsaSignature(messageHash, signatureBytes, evmAddress); !ok || err != nil {
+	var typedData *apitypes.TypedData
+	if params.Message.Signature.ChainId != "" {
+		data, err := executionTypedData(params.Message.Signature.ChainId, proxyWalletAddress, executionData)
 		if err != nil {
-			utils.LogError("error validating signature", err.Error())
-			return nil, utils.ErrInternal(fmt.Sprintf("error validating signature: %v", err.Error()))
-		} else {
-			utils.LogError("signature validation failed", "invaid signature")
-			return nil, utils.ErrInternal("Signature validation failed: invalid signature")
+			return nil, utils.ErrMalformedRequest(err.Error())
 		}
+		typedData = &data
+	}
+	scheme, err := ValidateEvmEcdsaSignature(messageHash, signatureBytes, evmAddress, typedData)
+	if err != nil {
+		utils.LogError("error validating signature", err.Error())
+		return nil, utils.ErrInternal(fmt.Sprintf("error validating signature: %v", err.Error()))
+	}
+	if scheme == "" {
+		utils.LogError("signature validation failed", "invaid signature")
+		return nil, utils.Err(apierr.SignatureInvalid, "signature does not recover the evm address")
+	}
+	if scheme == eip712.SchemeTypedData && !proxyWalletTypedData(params.ChainId) {
+		return nil, utils.ErrMalformedRequest(fmt.Sprintf("typed data signatures are not accepted by the proxy wallets of chain id %s, sign the hash with personal_sign", params.ChainId))
+	}
+
+	if err := signIntent(hex.EncodeToString(messageHash)); err != nil {
+		return nil, err
 	}
 
 	// ############################ CALL BUILDER ################################
@@ -161,87 +153,48 @@ func SignedEntryPointRequest(r *http.Request, parameters ...*SignedEntryPointReq
 		Data:      executionData,
 	}
 
-	proxyMessage := &tlb.InternalMessage{}
-	proxyMessage = &tlb.InternalMessage{
-		IHRDisabled: true,
-		Bounce:      false,
-		DstAddr:     proxyWalletAddress,
-		Amount:      tlb.FromNanoTONU(executionData.Value),
-		Body:        proxyWallet.ProxyWalletMessageToCell(proxyWalletMessage),
-	}
-	fmt.Print(proxyMessage)
-	if isInit {
-		proxyMessage = &tlb.InternalMessage{
-			IHRDisabled: true,
-			Bounce:      false,
-			DstAddr:     proxyWalletAddress,
-			Amount:      tlb.FromNanoTONU(executionData.Value),
-			Body:        proxyWallet.ProxyWalletMessageToCell(proxyWalletMessage),
-		}
-	} else {
-		proxyMessage = &tlb.InternalMessage{
-			IHRDisabled: true,
-			Bounce:      false,
-			DstAddr:     proxyWalletAddress,
-			Amount:      tlb.FromNanoTONU(executionData.Value),
-			Body:        proxyWallet.ProxyWalletMessageToCell(proxyWalletMessage),
-			StateInit:   state,
-		}
+	// the proxy deploy and the entrypoint call share one external message, the wallet sends them in order
+	plan := entryPointPlan{
+		Entrypoint:   entrypointAddress,
+		Proxy:        proxyWalletAddress,
+		ProxyMessage: proxyWalletMessage,
 	}
-
-	var txproxy *tlb.Transaction
-	var blockproxy *ton.BlockIDExt
-	var tx *tlb.Transaction
-	var block *ton.BlockIDExt
-	if !isInit {
-		fmt.Print(txproxy)
-		fmt.Print(blockproxy)
-		fmt.Print(tx)
-		fmt.Print(block)
-		txproxy, blockproxy, err = w.SendWaitTransaction(ctx, &wallet.Message{
-			Mode: wallet.PayGasSeparately + wallet.IgnoreErrors,
-			InternalMessage: &tlb.InternalMessage{
-				IHRDisabled: true,
-				Bounce:      false,
-				DstAddr:     proxyWalletAddress,
-				Amount:      tlb.MustFromTON("0.15"),
-				Body:        proxyWallet.ProxyWalletMessageToCell(proxyWalletMessage),
-				StateInit:   state,
-			},
-		})
-		if err != nil {
-			fmt.Printf("\nI got this error: %v", err)
-		}
+	if needsDeploy {
+		plan.State = state
+	}
+	fees, err := estimateEntryPointFees(ctx, w, plan)
+	if err != nil {
+		utils.LogError("failed to estimate fees", err.Error())
+		return nil, utils.ErrInternal(fmt.Sprintf("failed to estimate fees: %v", err))
 	}
 
-	entrypointMessage := entrypoint.EntrypointMessage{
-		Destination: proxyWalletAddress,
-		Body:        proxyWallet.ProxyWalletMessageToCell(proxyWalletMessage),
+	notify := &messageEvents{
+		Keys:   []string{hex.EncodeToString(messageHash), evmAddress.Hex(), proxyWalletAddress.String()},
+		Intent: hex.EncodeToString(messageHash),
+	}
+	if tvmAddress != nil {
+		notify.Keys = append(notify.Keys, tvmAddress.String())
 	}
-	fmt.Print(entrypointMessage)
+	if plan.State != nil {
+		notify.Steps = append(notify.Steps, events.ProxyDeployed)
+	}
+	notify.Steps = append(notify.Steps, events.DestinationExecuted)
 
-	queryId := uint64(0)
-	tx, block, _ = w.SendWaitTransaction(ctx, &wallet.Message{
-		Mode: wallet.PayGasSeparately + wallet.IgnoreErrors,
-		InternalMessage: &tlb.InternalMessage{
-			IHRDisabled: true,
-			Bounce:      false,
-			DstAddr:     entrypointAddress,
-			Amount:      tlb.MustFromTON("0.2"),
-			Body:        entrypoint.EntrypointMessageToCell(entrypointMessage, queryId),
-		},
-	})
-
-	return struct {
-		Tx []TxBlockResponse
-	}{
-		Tx: []TxBlockResponse{
-			ParseTxBlockResponse(txproxy, blockproxy),
-			ParseTxBlockResponse(tx, block),
-		},
+	sent, err := sendExternalMessage(ctx, w, notify, entryPointMessages(plan, fees)...)
+	if err != nil {
+		return nil, err
+	}
+	return SignedEntryPointResponse{
+		SentMessageResponse: sent,
+		Fees:                fees.Quote,
 	}, nil
 }
 
+type SignedEntryPointResponse struct {
+	SentMessageResponse
+	Fees FeeQuote `json:"fees"`
+}
+
 type UnsignedEntryPointRequestParams struct {
 	Header      utils.MessageHeader `query:"header"`
 	ProxyParams ProxyParams         `query:"proxy"`
@@ -277,6 +230,8 @@ type MessageOpTvm struct {
 	ProxyAddress string              `json:"proxy-address"`
 	ValueNano    string              `json:"value"`
 	MessageHash  string              `json:"hash"`
+	TypedData    *apitypes.TypedData `json:"typed-data,omitempty"` // eip-712 form of the hash, once the proxy wallet verifies it
+	Fees         FeeQuote            `json:"fees"`
 }
 
 type UnsignedMintToRequestParams struct {
@@ -448,13 +403,16 @@ func UnsignedEntryPointRequest(r *http.Request, parameters ...*UnsignedEntryPoin
 	}
 	/////////////////////////////////////////////////////////
 
-	var isInit bool
+	var needsDeploy bool
 
-	ctx, api, _, err := InitClient()
+	ctx, api, w, err := InitClient()
+	if err != nil {
+		return nil, err
+	}
+	entrypointAddress, err := entrypointOf(params.Header.ToChainId)
 	if err != nil {
 		return nil, err
 	}
-	entrypointAddress := address.MustParseAddr("EQAGJK50PW_a1ZbQWK0yldegu56FlX0nXKQIa7xzoWCzQp78")
 	b, err := api.GetMasterchainInfo(ctx)
 	if err != nil {
 		return nil, err
@@ -475,10 +433,10 @@ func UnsignedEntryPointRequest(r *http.Request, parameters ...*UnsignedEntryPoin
 	}
 
 	tvmAddress := address.MustParseAddr(params.ProxyParams.ProxyHeader.OwnerTvmAddress)
-	proxyWalletAddress, _ := calculateProxyWalletAddress(nonce, entrypointAddress, evmAddressBigInt, tvmAddress, byte(b.Workchain))
-	// call get_wallet_info, if fails, wallet is not init
+	proxyWalletAddress, state := calculateProxyWalletAddress(nonce, entrypointAddress, evmAddressBigInt, tvmAddress, byte(b.Workchain))
+	// call get_wallet_info, if fails, wallet is not init and the deploy is quoted
 	if _, err := getWalletInfo(proxyWalletAddress.String()); err != nil {
-		isInit = false
+		needsDeploy = true
 	}
 	executionData, err := ToExecutionData(params.ProxyParams.ExecutionData) // we don't want to use the body of the proxyparams
 	if err != nil {
@@ -486,29 +444,51 @@ func UnsignedEntryPointRequest(r *http.Request, parameters ...*UnsignedEntryPoin
 		return nil, utils.ErrInternal(err.Error())
 	}
 	messageHash := proxyWallet.ExecutionDataToCell(executionData).Hash()
+	var typedData *apitypes.TypedData
+	if proxyWalletTypedData(params.Header.ToChainId) {
+		data, err := executionTypedData(params.Header.FromChainId, proxyWalletAddress, executionData)
+		if err != nil {
+			return nil, utils.ErrMalformedRequest(err.Error())
+		}
+		typedData = &data
+	}
 	// messageHashEth, err := hashCellWithEthereumPrefix(messageHash)
 	// if err != nil {
 	// 	return nil, utils.ErrInternal(err.Error())
 	// } // this is used to create the exact format but already auto performed by EVM wallets
-	value := executionData.Value + tlb.MustFromTON("0.02").Nano().Uint64()
+	plan := entryPointPlan{
+		Entrypoint:   entrypointAddress,
+		Proxy:        proxyWalletAddress,
+		ProxyMessage: proxyWallet.ProxyWalletMessage{QueryId: 1, Data: executionData},
+	}
+	if needsDeploy {
+		plan.State = state
+	}
+	fees, err := estimateEntryPointFees(ctx, w, plan)
+	if err != nil {
+		return nil, utils.ErrInternal(fmt.Sprintf("failed to estimate fees: %v", err))
+	}
+	value := executionData.Value + fees.Total
 	fmt.Print(params.Header)
 	return MessageOpTvm{
 		Header: params.Header,
 		ProxyParams: ProxyParams{
 			ProxyHeader: ProxyHeaderParams{
 				Nonce:           "0",
-				EntryPoint:      entryPointAddress.String(),
+				EntryPoint:      entrypointAddress.String(),
 				PayeeAddress:    "",
 				OwnerEvmAddress: params.ProxyParams.ProxyHeader.OwnerEvmAddress,
 				OwnerTvmAddress: params.ProxyParams.ProxyHeader.OwnerTvmAddress,
 			},
 			ExecutionData:   params.ProxyParams.ExecutionData,
-			WithProxyInit:   strconv.FormatBool(isInit),
+			WithProxyInit:   strconv.FormatBool(needsDeploy),
 			ProxyWalletCode: "",
 			WorkChain:       params.ProxyParams.WorkChain,
 		},
 		ProxyAddress: proxyWalletAddress.String(),
 		ValueNano:    strconv.FormatUint(value, 10),
 		MessageHash:  hex.EncodeToString(messageHash),
+		TypedData:    typedData,
+		Fees:         fees.Quote,
 	}, nil
 }
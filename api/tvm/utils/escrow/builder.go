@@ -1 +1,63 @@
 package escrow
+
+import (
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+func EscrowConfigToCell(config EscrowConfig) *cell.Cell {
+	return cell.BeginCell().
+		MustStoreAddr(config.UserAddress).
+		MustStoreAddr(config.AdminAddress).
+		MustStoreBigUInt(config.Payee, 160).
+		MustStoreBigUInt(config.Id, 256).
+		MustStoreRef(cell.BeginCell().EndCell()).
+		MustStoreRef(cell.BeginCell().MustStoreBigCoins(config.Value.Nano()).EndCell()).
+		EndCell()
+}
+
+func EscrowStateInit(config EscrowConfig, code *cell.Cell) *tlb.StateInit {
+	return &tlb.StateInit{
+		Code: code,
+		Data: EscrowConfigToCell(config),
+	}
+}
+
+func CalculateEscrowAddress(config EscrowConfig, code *cell.Cell, workchain byte) (*address.Address, error) {
+	stateCell, err := tlb.ToCell(EscrowStateInit(config, code))
+	if err != nil {
+		return nil, err
+	}
+	return address.NewAddress(0, workchain, stateCell.Hash()), nil
+}
+
+func SignatureToCell(signature Signature) *cell.Cell {
+	return cell.BeginCell().
+		MustStoreUInt(signature.V, 8).
+		MustStoreBigUInt(signature.R, 256).
+		MustStoreBigUInt(signature.S, 256).
+		EndCell()
+}
+
+func DepositMessageToCell() *cell.Cell {
+	return cell.BeginCell().
+		MustStoreUInt(OpDeposit, 32).
+		EndCell()
+}
+
+// LockMessageToCell stores the signed lock. The escrow keeps a single lock without a deadline, there
+// is no extend op: extending a lock is sending a new lock, which replaces the stored signature
+func LockMessageToCell(message LockMessage) *cell.Cell {
+	return cell.BeginCell().
+		MustStoreUInt(OpLock, 32).
+		MustStoreRef(SignatureToCell(message.Signature)).
+		EndCell()
+}
+
+func PayoutMessageToCell(message PayoutMessage) *cell.Cell {
+	return cell.BeginCell().
+		MustStoreUInt(OpPayout, 32).
+		MustStoreAddr(message.Payee).
+		EndCell()
+}
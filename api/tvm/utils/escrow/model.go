@@ -1 +1,65 @@
 package escrow
+
+import (
+	"math/big"
+
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
+)
+
+// ops handled by the escrow code, the code also accepts 0x622117e9 and 0x0b7ba46f bodies as plain deposits
+const (
+	OpLock    = 30         // user only, stores the signed lock
+	OpPayout  = 31         // admin only, sends the whole balance to the payee address
+	OpDeposit = 0x3b307c4b // accepts the attached value
+)
+
+// signed lock bits stored by OpLock: v (8) | r (256) | s (256)
+const SignatureBits = 8 + 256 + 256
+
+// EscrowConfig is the escrow data, see escrowConfigToCell in the contract wrappers
+type EscrowConfig struct {
+	UserAddress  *address.Address
+	AdminAddress *address.Address
+	Payee        *big.Int // uint160, evm address of the payee
+	Id           *big.Int // uint256, always 0 since the escrow is unique to the user
+	Value        tlb.Coins
+}
+
+type Signature struct {
+	V uint64
+	R *big.Int
+	S *big.Int
+}
+
+type LockMessage struct {
+	Signature Signature
+}
+
+type PayoutMessage struct {
+	Payee *address.Address
+}
+
+type GetSignatureResponse struct {
+	V string `json:"v"`
+	R string `json:"r"`
+	S string `json:"s"`
+}
+
+type GetEscrowDataResponse struct {
+	Status       string `json:"status"`
+	Balance      string `json:"balance"`
+	UserAddress  string `json:"user-address"`
+	AdminAddress string `json:"admin-address"`
+	Payee        string `json:"payee"`
+	Id           string `json:"id"`
+	Value        string `json:"value"`
+	Signature    string `json:"signature"` // hex of the stored lock, empty before the first lock
+}
+
+type GetAssetInfoResponse struct {
+	Init     bool   `json:"init"`
+	Balance  string `json:"balance"`
+	Locked   string `json:"lock-balance"`
+	Deadline string `json:"lock-deadline,omitempty"` // unset, the escrow code keeps no deadline
+}
@@ -1 +1,123 @@
 package escrow
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	tvmUtils "github.com/crosscall-labs/crosschain-api/api/tvm/utils"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+// GetSignature returns the signed lock stored by the user, api is the accounts endpoint of the
+// chain, see tvmUtils.AccountsApi
+func GetSignature(api string, escrowAddressRaw string) (GetSignatureResponse, error) {
+	response, err := tvmUtils.CallViewFunction(api, escrowAddressRaw, "get_signature", []string{})
+	if err != nil {
+		return GetSignatureResponse{}, err
+	}
+
+	stack, err := tvmUtils.ParseViewResponse(response)
+	if err != nil {
+		return GetSignatureResponse{}, err
+	}
+
+	if len(stack) < 3 {
+		return GetSignatureResponse{}, fmt.Errorf("stack has insufficient items to map to GetSignatureResponse")
+	}
+
+	result := GetSignatureResponse{
+		V: stack[0].Num,
+		R: stack[1].Num,
+		S: stack[2].Num,
+	}
+
+	utils.LogInfo("Formatted get_signature response", tvmUtils.FormatKeyValueLogs(stack))
+	return result, nil
+}
+
+// GetEscrowData returns the balance and the parsed data of an escrow, an escrow that was
+// never deployed only reports its status
+func GetEscrowData(api string, escrowAddressRaw string) (GetEscrowDataResponse, error) {
+	state, err := tvmUtils.GetAccountState(api, escrowAddressRaw)
+	if err != nil {
+		return GetEscrowDataResponse{}, err
+	}
+
+	result := GetEscrowDataResponse{
+		Status:  state.Status,
+		Balance: strconv.FormatInt(state.Balance, 10),
+	}
+	if state.Status != "active" || state.Data == "" {
+		return result, nil
+	}
+
+	dataBytes, err := hex.DecodeString(state.Data)
+	if err != nil {
+		return result, fmt.Errorf("invalid escrow data: %v", err)
+	}
+	data, err := cell.FromBOC(dataBytes)
+	if err != nil {
+		return result, fmt.Errorf("invalid escrow data: %v", err)
+	}
+
+	slice := data.BeginParse()
+	user, err := slice.LoadAddr()
+	if err != nil {
+		return result, fmt.Errorf("failed to load escrow user: %v", err)
+	}
+	admin, err := slice.LoadAddr()
+	if err != nil {
+		return result, fmt.Errorf("failed to load escrow admin: %v", err)
+	}
+	payee, err := slice.LoadBigUInt(160)
+	if err != nil {
+		return result, fmt.Errorf("failed to load escrow payee: %v", err)
+	}
+	id, err := slice.LoadBigUInt(256)
+	if err != nil {
+		return result, fmt.Errorf("failed to load escrow id: %v", err)
+	}
+	signature, err := slice.LoadRef()
+	if err != nil {
+		return result, fmt.Errorf("failed to load escrow signature: %v", err)
+	}
+	valueSlice, err := slice.LoadRef()
+	if err != nil {
+		return result, fmt.Errorf("failed to load escrow value: %v", err)
+	}
+	value, err := valueSlice.LoadBigCoins()
+	if err != nil {
+		return result, fmt.Errorf("failed to load escrow value: %v", err)
+	}
+
+	result.UserAddress = user.String()
+	result.AdminAddress = admin.String()
+	result.Payee = fmt.Sprintf("0x%040x", payee)
+	result.Id = id.String()
+	result.Value = value.String()
+	if signature.BitsLeft() == SignatureBits {
+		signatureBytes, _ := signature.LoadSlice(SignatureBits)
+		result.Signature = hex.EncodeToString(signatureBytes)
+	}
+	return result, nil
+}
+
+// GetAssetInfo returns the native balance and lock of an escrow, the locked value is the staked
+// config value. The code keeps no deadline, a lock holds until the admin pays out, so none is reported
+func GetAssetInfo(api string, escrowAddressRaw string) (GetAssetInfoResponse, error) {
+	data, err := GetEscrowData(api, escrowAddressRaw)
+	if err != nil {
+		return GetAssetInfoResponse{}, err
+	}
+	result := GetAssetInfoResponse{
+		Init:    data.Status == "active",
+		Balance: data.Balance,
+		Locked:  "0",
+	}
+	if data.Value != "" {
+		result.Locked = data.Value
+	}
+	return result, nil
+}
@@ -1,16 +1,35 @@
 package tvmUtils
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tvm/cell"
 )
 
+// view calls and account reads give up after viewTimeout instead of holding the request
+const viewTimeout = 10 * time.Second
+
+var viewClient = &http.Client{Timeout: viewTimeout}
+
+// AccountsApi returns the tonapi accounts endpoint of the chain indexer
+func AccountsApi(chain *chains.Chain) (string, error) {
+	if chain.Indexer == "" {
+		return "", fmt.Errorf("no indexer configured for chain id: %s", chain.ID)
+	}
+	return strings.TrimRight(chain.Indexer, "/") + "/blockchain/accounts/", nil
+}
+
 func CallViewFunction(api string, contractAddress string, method string, args []string) ([]byte, error) {
 	baseURL := fmt.Sprintf("%s%s/methods/%s", api, contractAddress, method)
 	query := url.Values{}
@@ -18,7 +37,7 @@ func CallViewFunction(api string, contractAddress string, method string, args []
 		query.Add("args", arg)
 	}
 	fullURL := fmt.Sprintf("%s?%s", baseURL, query.Encode())
-	resp, err := http.Get(fullURL)
+	resp, err := viewClient.Get(fullURL)
 
 	utils.LogInfo("Viewcall URL", fullURL)
 	if err != nil {
@@ -72,3 +91,58 @@ func FormatKeyValueLogs(data []StackItem) string {
 
 	return builder.String()
 }
+
+// AccountState is the tonapi view of an account, code and data are hex bocs
+type AccountState struct {
+	Address string `json:"address"`
+	Balance int64  `json:"balance"`
+	Status  string `json:"status"`
+	Code    string `json:"code"`
+	Data    string `json:"data"`
+}
+
+func GetAccountState(api string, accountAddress string) (AccountState, error) {
+	fullURL := fmt.Sprintf("%s%s", api, accountAddress)
+	resp, err := viewClient.Get(fullURL)
+
+	utils.LogInfo("Account URL", fullURL)
+	if err != nil {
+		return AccountState{}, fmt.Errorf("failed to make GET request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return AccountState{}, fmt.Errorf("received status: %d", resp.StatusCode)
+	}
+	var state AccountState
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		return AccountState{}, fmt.Errorf("failed to parse account: %v", err)
+	}
+	return state, nil
+}
+
+// ParseAddressCell loads the address of a slice returned by a get method, tonapi returns it as a hex boc
+func ParseAddressCell(cellHex string) (*address.Address, error) {
+	cellBytes, err := hex.DecodeString(cellHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address cell: %v", err)
+	}
+	addressCell, err := cell.FromBOC(cellBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address cell: %v", err)
+	}
+	addr, err := addressCell.BeginParse().LoadAddr()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load address: %v", err)
+	}
+	return addr, nil
+}
+
+// ParseNum parses a num of a get method, tonapi returns them as hex
+func ParseNum(num string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(num, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid num: %s", num)
+	}
+	return value, nil
+}
@@ -1,6 +1,10 @@
 package proxyWallet
 
-import "github.com/xssnick/tonutils-go/tvm/cell"
+import (
+	"math/big"
+
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
 
 func ExecutionDataToCell(message ExecutionData) *cell.Cell {
 	return cell.BeginCell().
@@ -11,14 +15,22 @@ func ExecutionDataToCell(message ExecutionData) *cell.Cell {
 		EndCell()
 }
 
+// SignatureToCell stores a missing r or s as zero, unsigned messages are sized with an empty signature
 func SignatureToCell(signature Signature) *cell.Cell {
 	return cell.BeginCell().
 		MustStoreUInt(signature.V, 8).
-		MustStoreUInt(signature.R, 256).
-		MustStoreUInt(signature.S, 256).
+		MustStoreBigUInt(orZero(signature.R), 256).
+		MustStoreBigUInt(orZero(signature.S), 256).
 		EndCell()
 }
 
+func orZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return x
+}
+
 func ProxyWalletMessageToCell(message ProxyWalletMessage) *cell.Cell {
 	return cell.BeginCell().
 		MustStoreUInt(11, 32).
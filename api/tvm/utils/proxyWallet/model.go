@@ -1,6 +1,8 @@
 package proxyWallet
 
 import (
+	"math/big"
+
 	"github.com/xssnick/tonutils-go/address"
 	"github.com/xssnick/tonutils-go/tvm/cell"
 )
@@ -19,10 +21,11 @@ type ExecutionData struct {
 	Body        *cell.Cell
 }
 
+// Signature is a secp256k1 signature as the proxy wallet checks it, v is the 0/1 recovery id
 type Signature struct {
 	V uint64
-	R uint64
-	S uint64
+	R *big.Int // uint256
+	S *big.Int // uint256
 }
 
 type ProxyWalletMessage struct {
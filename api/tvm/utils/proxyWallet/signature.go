@@ -0,0 +1,99 @@
+package proxyWallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// NewSignature reads a 65 byte r|s|v signature as returned by evm wallets
+func NewSignature(signature []byte) (Signature, error) {
+	if len(signature) != 65 {
+		return Signature{}, fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+	v, err := NormalizeV(uint64(signature[64]))
+	if err != nil {
+		return Signature{}, err
+	}
+	return newSignature(v, new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:64]))
+}
+
+// ParseSignature reads the v, r and s of a request, v is decimal or 0x hex and r and s are hex of
+// at most 32 bytes, shorter values are left padded
+func ParseSignature(v, r, s string) (Signature, error) {
+	base := 10
+	if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+		v, base = v[2:], 16
+	}
+	vValue, err := strconv.ParseUint(v, base, 64)
+	if err != nil {
+		return Signature{}, fmt.Errorf("invalid v: %v", err)
+	}
+	if vValue, err = NormalizeV(vValue); err != nil {
+		return Signature{}, err
+	}
+
+	rValue, err := parseWord(r)
+	if err != nil {
+		return Signature{}, fmt.Errorf("invalid r: %v", err)
+	}
+	sValue, err := parseWord(s)
+	if err != nil {
+		return Signature{}, fmt.Errorf("invalid s: %v", err)
+	}
+	return newSignature(vValue, rValue, sValue)
+}
+
+// NormalizeV maps 0/1, 27/28 and eip-155 values (35 + 2 * chain id + recovery id) to the recovery id
+func NormalizeV(v uint64) (uint64, error) {
+	switch {
+	case v <= 1:
+		return v, nil
+	case v == 27 || v == 28:
+		return v - 27, nil
+	case v >= 35:
+		return (v - 35) % 2, nil
+	default:
+		return 0, fmt.Errorf("invalid v: %d", v)
+	}
+}
+
+// Bytes returns the 65 byte r|s|v form with the 0/1 recovery id, as crypto.SigToPub expects it
+func (s Signature) Bytes() []byte {
+	signature := make([]byte, 65)
+	orZero(s.R).FillBytes(signature[:32])
+	orZero(s.S).FillBytes(signature[32:64])
+	signature[64] = byte(s.V)
+	return signature
+}
+
+func newSignature(v uint64, r *big.Int, s *big.Int) (Signature, error) {
+	n := crypto.S256().Params().N
+	if r.Sign() == 0 || r.Cmp(n) >= 0 {
+		return Signature{}, errors.New("invalid r: out of the secp256k1 order")
+	}
+	if s.Sign() == 0 || s.Cmp(n) >= 0 {
+		return Signature{}, errors.New("invalid s: out of the secp256k1 order")
+	}
+	return Signature{V: v, R: r, S: s}, nil
+}
+
+func parseWord(value string) (*big.Int, error) {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if len(value)%2 != 0 {
+		value = "0" + value
+	}
+	word, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(word) > 32 {
+		return nil, fmt.Errorf("%d bytes exceed 32", len(word))
+	}
+	return new(big.Int).SetBytes(word), nil
+}
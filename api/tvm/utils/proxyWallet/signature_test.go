@@ -0,0 +1,128 @@
+package proxyWallet
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/eip712"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+func TestSignatureRoundTrip(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	data := ExecutionData{
+		Regime:      1,
+		Destination: address.MustParseAddr("EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N"),
+		Value:       1_000_000,
+		Body:        cell.BeginCell().MustStoreUInt(0xdeadbeef, 32).EndCell(),
+	}
+	hash := ExecutionDataToCell(data).Hash()
+	signed, err := crypto.Sign(eip712.PersonalHash(hash), privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recoveryId := signed[64]
+	r, s := signed[:32], signed[32:64]
+
+	// evm wallets return v as 27/28, requests may send it as 0/1, hex or eip-155
+	wallet := append(append([]byte{}, signed[:64]...), recoveryId+27)
+	fromBytes, err := NewSignature(wallet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed := map[string]Signature{"bytes": fromBytes}
+	for name, v := range map[string]string{
+		"recovery id": fmt.Sprint(recoveryId),
+		"27/28":       fmt.Sprint(recoveryId + 27),
+		"hex":         fmt.Sprintf("0x%x", recoveryId+27),
+		"eip-155":     fmt.Sprint(35 + 2*11155111 + uint64(recoveryId)),
+	} {
+		if parsed[name], err = ParseSignature(v, hexutil.Encode(r), hexutil.Encode(s)); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+
+	for name, signature := range parsed {
+		t.Run(name, func(t *testing.T) {
+			if !bytes.Equal(signature.Bytes(), signed) {
+				t.Fatalf("bytes %x, want %x", signature.Bytes(), signed)
+			}
+
+			message := ProxyWalletMessageToCell(ProxyWalletMessage{QueryId: 7, Signature: signature, Data: data})
+			slice := message.BeginParse()
+			if op := slice.MustLoadUInt(32); op != 11 {
+				t.Fatalf("op %d", op)
+			}
+			if queryId := slice.MustLoadUInt(64); queryId != 7 {
+				t.Fatalf("query id %d", queryId)
+			}
+			packed := slice.MustLoadRef()
+			if !bytes.Equal(slice.MustLoadRef().MustToCell().Hash(), hash) {
+				t.Fatal("execution data ref does not hash to the signed hash")
+			}
+
+			// v:uint8 r:uint256 s:uint256, the recovery id as the contract passes it to ecrecover
+			v := packed.MustLoadUInt(8)
+			packedR := packed.MustLoadBigUInt(256)
+			packedS := packed.MustLoadBigUInt(256)
+			if packed.BitsLeft() != 0 || packed.RefsNum() != 0 {
+				t.Fatal("signature cell has trailing data")
+			}
+			if v != uint64(recoveryId) || packedR.Cmp(new(big.Int).SetBytes(r)) != 0 || packedS.Cmp(new(big.Int).SetBytes(s)) != 0 {
+				t.Fatalf("packed v=%d r=%x s=%x", v, packedR, packedS)
+			}
+
+			recovered := make([]byte, 65)
+			packedR.FillBytes(recovered[:32])
+			packedS.FillBytes(recovered[32:64])
+			recovered[64] = byte(v)
+			publicKey, err := crypto.SigToPub(eip712.PersonalHash(hash), recovered)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if crypto.PubkeyToAddress(*publicKey) != signer {
+				t.Fatal("packed signature does not recover the signer")
+			}
+		})
+	}
+}
+
+func TestParseSignatureInvalid(t *testing.T) {
+	word := "0x" + strings.Repeat("11", 32)
+	order := hexutil.EncodeBig(crypto.S256().Params().N)
+	tests := []struct {
+		name    string
+		v, r, s string
+	}{
+		{"v 2", "2", word, word},
+		{"v 29", "29", word, word},
+		{"v not a number", "x", word, word},
+		{"r zero", "27", "0x00", word},
+		{"s zero", "27", word, "0x0"},
+		{"r above 32 bytes", "27", word + "11", word},
+		{"s at the curve order", "27", word, order},
+		{"r not hex", "27", "0xzz", word},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseSignature(test.v, test.r, test.s); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	if _, err := NewSignature(make([]byte, 64)); err == nil {
+		t.Fatal("expected an error for 64 bytes")
+	}
+}
@@ -1 +1,19 @@
 package stonfiPool
+
+type GetPoolDataResponse struct {
+	IsLocked            bool   `json:"is-locked"`
+	RouterAddress       string `json:"router-address"`
+	TotalSupply         string `json:"total-supply"`
+	Reserve0            string `json:"reserve0"`
+	Reserve1            string `json:"reserve1"`
+	Token0WalletAddress string `json:"token0-wallet-address"`
+	Token1WalletAddress string `json:"token1-wallet-address"`
+	LpFee               string `json:"lp-fee"`       // bps of the offer amount
+	ProtocolFee         string `json:"protocol-fee"` // bps of the offer amount
+}
+
+type GetExpectedOutputsResponse struct {
+	JettonsToReceive string `json:"jettons-to-receive"`
+	ProtocolFeePaid  string `json:"protocol-fee-paid"`
+	RefFeePaid       string `json:"ref-fee-paid"`
+}
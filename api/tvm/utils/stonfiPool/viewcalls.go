@@ -1 +1,93 @@
 package stonfiPool
+
+import (
+	"fmt"
+
+	tvmUtils "github.com/crosscall-labs/crosschain-api/api/tvm/utils"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+)
+
+const tonapiAccounts = "https://testnet.tonapi.io/v2/blockchain/accounts/"
+
+// GetPoolData returns the reserves of the pool, token0 and token1 are the router's jetton wallets
+func GetPoolData(poolAddressRaw string) (GetPoolDataResponse, error) {
+	response, err := tvmUtils.CallViewFunction(tonapiAccounts, poolAddressRaw, "get_pool_data", []string{})
+	if err != nil {
+		return GetPoolDataResponse{}, err
+	}
+
+	stack, err := tvmUtils.ParseViewResponse(response)
+	if err != nil {
+		return GetPoolDataResponse{}, err
+	}
+
+	if len(stack) < 9 {
+		return GetPoolDataResponse{}, fmt.Errorf("stack has insufficient items to map to GetPoolDataResponse")
+	}
+
+	nums := map[int]string{}
+	for _, i := range []int{0, 2, 3, 4, 7, 8} {
+		value, err := tvmUtils.ParseNum(stack[i].Num)
+		if err != nil {
+			return GetPoolDataResponse{}, err
+		}
+		nums[i] = value.String()
+	}
+	addresses := map[int]string{}
+	for _, i := range []int{1, 5, 6} {
+		addr, err := tvmUtils.ParseAddressCell(stack[i].Cell)
+		if err != nil {
+			return GetPoolDataResponse{}, err
+		}
+		addresses[i] = addr.String()
+	}
+
+	result := GetPoolDataResponse{
+		IsLocked:            nums[0] != "0",
+		RouterAddress:       addresses[1],
+		TotalSupply:         nums[2],
+		Reserve0:            nums[3],
+		Reserve1:            nums[4],
+		Token0WalletAddress: addresses[5],
+		Token1WalletAddress: addresses[6],
+		LpFee:               nums[7],
+		ProtocolFee:         nums[8],
+	}
+
+	utils.LogInfo("Formatted get_pool_data response", tvmUtils.FormatKeyValueLogs(stack))
+	return result, nil
+}
+
+// GetExpectedOutputs quotes a swap of amount sent through the router's tokenWallet, fees are already deducted
+func GetExpectedOutputs(poolAddressRaw string, amount string, tokenWalletRaw string) (GetExpectedOutputsResponse, error) {
+	response, err := tvmUtils.CallViewFunction(tonapiAccounts, poolAddressRaw, "get_expected_outputs", []string{amount, tokenWalletRaw})
+	if err != nil {
+		return GetExpectedOutputsResponse{}, err
+	}
+
+	stack, err := tvmUtils.ParseViewResponse(response)
+	if err != nil {
+		return GetExpectedOutputsResponse{}, err
+	}
+
+	if len(stack) < 3 {
+		return GetExpectedOutputsResponse{}, fmt.Errorf("stack has insufficient items to map to GetExpectedOutputsResponse")
+	}
+
+	var values [3]string
+	for i := range values {
+		value, err := tvmUtils.ParseNum(stack[i].Num)
+		if err != nil {
+			return GetExpectedOutputsResponse{}, err
+		}
+		values[i] = value.String()
+	}
+	result := GetExpectedOutputsResponse{
+		JettonsToReceive: values[0],
+		ProtocolFeePaid:  values[1],
+		RefFeePaid:       values[2],
+	}
+
+	utils.LogInfo("Formatted get_expected_outputs response", tvmUtils.FormatKeyValueLogs(stack))
+	return result, nil
+}
@@ -1 +1,56 @@
 package stonfiRouter
+
+import (
+	"math/big"
+
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+func SwapPayloadToCell(message SwapMessage) *cell.Cell {
+	referral := message.ReferralAddress
+	if referral == nil {
+		referral = address.NewAddressNone()
+	}
+	return cell.BeginCell().
+		MustStoreUInt(OpSwap, 32).
+		MustStoreAddr(message.AskJettonWallet).
+		MustStoreAddr(message.RefundAddress).
+		MustStoreAddr(message.RefundAddress).
+		MustStoreUInt(message.Deadline, 64).
+		MustStoreRef(cell.BeginCell().
+			MustStoreBigCoins(message.MinAskAmount).
+			MustStoreAddr(message.Receiver).
+			MustStoreCoins(0). // no custom payload
+			MustStoreMaybeRef(nil).
+			MustStoreCoins(0). // no refund payload
+			MustStoreMaybeRef(nil).
+			MustStoreUInt(DefaultReferralValue, 16).
+			MustStoreAddr(referral).
+			EndCell()).
+		EndCell()
+}
+
+func swapMessageToCell(message SwapMessage, forwardAmount *big.Int) *cell.Cell {
+	return cell.BeginCell().
+		MustStoreUInt(OpJettonTransfer, 32).
+		MustStoreUInt(message.QueryId, 64).
+		MustStoreBigCoins(message.OfferAmount).
+		MustStoreAddr(message.Router).
+		MustStoreAddr(message.RefundAddress).
+		MustStoreMaybeRef(nil).
+		MustStoreBigCoins(forwardAmount).
+		MustStoreMaybeRef(SwapPayloadToCell(message)).
+		EndCell()
+}
+
+// JettonToJettonSwapMessageToCell is sent to the offer jetton wallet with JettonToJettonGasAmount attached
+func JettonToJettonSwapMessageToCell(message SwapMessage) *cell.Cell {
+	return swapMessageToCell(message, JettonToJettonForwardAmount.Nano())
+}
+
+// JettonToTonSwapMessageToCell swaps into pton, the router unwraps it so the receiver gets TON.
+// It is sent to the offer jetton wallet with JettonToTonGasAmount attached
+func JettonToTonSwapMessageToCell(message SwapMessage) *cell.Cell {
+	return swapMessageToCell(message, JettonToTonForwardAmount.Nano())
+}
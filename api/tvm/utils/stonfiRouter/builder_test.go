@@ -0,0 +1,120 @@
+package stonfiRouter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+func testSwapMessage() SwapMessage {
+	return SwapMessage{
+		QueryId:         7,
+		OfferAmount:     big.NewInt(1_000_000_000),
+		Router:          address.MustParseAddr("kQALh-JBBIKK7gr0o4AVf9JZnEsFndqO0qTCyT-D-yBsWk0v"),
+		AskJettonWallet: address.MustParseAddr("EQAW3iupIDrCICc7SbcY_SBP6jCNO-F8v91dG9XNLHw-lE9k"),
+		MinAskAmount:    big.NewInt(990),
+		Receiver:        address.MustParseAddr("UQAzC1P9oEQcVzKIOgyVeidkJlWbHGXvbNlIute5W5XHwNgf"),
+		RefundAddress:   address.MustParseAddr("kQAGJK50PW_a1ZbQWK0yldegu56FlX0nXKQIa7xzoWCzQiV2"),
+		Deadline:        1_700_000_000,
+	}
+}
+
+func equalAddr(t *testing.T, name string, got *address.Address, want *address.Address) {
+	t.Helper()
+	if !got.Equals(want) {
+		t.Fatalf("%s: got %s, want %s", name, got, want)
+	}
+}
+
+// checkSwapPayload reads the router payload back field by field
+func checkSwapPayload(t *testing.T, payload *cell.Slice, message SwapMessage) {
+	t.Helper()
+	if op := payload.MustLoadUInt(32); op != OpSwap {
+		t.Fatalf("payload op %x, want %x", op, OpSwap)
+	}
+	equalAddr(t, "ask jetton wallet", payload.MustLoadAddr(), message.AskJettonWallet)
+	equalAddr(t, "refund address", payload.MustLoadAddr(), message.RefundAddress)
+	equalAddr(t, "excesses address", payload.MustLoadAddr(), message.RefundAddress)
+	if deadline := payload.MustLoadUInt(64); deadline != message.Deadline {
+		t.Fatalf("deadline %d, want %d", deadline, message.Deadline)
+	}
+
+	params := payload.MustLoadRef()
+	if minAsk := params.MustLoadBigCoins(); minAsk.Cmp(message.MinAskAmount) != 0 {
+		t.Fatalf("min ask amount %s, want %s", minAsk, message.MinAskAmount)
+	}
+	equalAddr(t, "receiver", params.MustLoadAddr(), message.Receiver)
+	for _, name := range []string{"custom payload", "refund payload"} {
+		if gas := params.MustLoadBigCoins(); gas.Sign() != 0 {
+			t.Fatalf("%s forward gas %s, want 0", name, gas)
+		}
+		if params.MustLoadBoolBit() {
+			t.Fatalf("unexpected %s", name)
+		}
+	}
+	if referralValue := params.MustLoadUInt(16); referralValue != DefaultReferralValue {
+		t.Fatalf("referral value %d, want %d", referralValue, DefaultReferralValue)
+	}
+	if referral := params.MustLoadAddr(); referral.Type() != address.NoneAddress {
+		t.Fatalf("referral %s, want none", referral)
+	}
+	if params.BitsLeft() != 0 || payload.BitsLeft() != 0 {
+		t.Fatalf("trailing bits: params %d, payload %d", params.BitsLeft(), payload.BitsLeft())
+	}
+}
+
+func TestSwapMessageLayout(t *testing.T) {
+	message := testSwapMessage()
+	tests := []struct {
+		name    string
+		build   func(SwapMessage) *cell.Cell
+		forward *big.Int
+	}{
+		{"jetton to jetton", JettonToJettonSwapMessageToCell, JettonToJettonForwardAmount.Nano()},
+		{"jetton to ton", JettonToTonSwapMessageToCell, JettonToTonForwardAmount.Nano()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transfer := test.build(message).BeginParse()
+			if op := transfer.MustLoadUInt(32); op != OpJettonTransfer {
+				t.Fatalf("op %x, want %x", op, OpJettonTransfer)
+			}
+			if queryId := transfer.MustLoadUInt(64); queryId != message.QueryId {
+				t.Fatalf("query id %d, want %d", queryId, message.QueryId)
+			}
+			if amount := transfer.MustLoadBigCoins(); amount.Cmp(message.OfferAmount) != 0 {
+				t.Fatalf("offer amount %s, want %s", amount, message.OfferAmount)
+			}
+			equalAddr(t, "destination", transfer.MustLoadAddr(), message.Router)
+			equalAddr(t, "response destination", transfer.MustLoadAddr(), message.RefundAddress)
+			if transfer.MustLoadBoolBit() {
+				t.Fatal("unexpected custom payload")
+			}
+			if forward := transfer.MustLoadBigCoins(); forward.Cmp(test.forward) != 0 {
+				t.Fatalf("forward amount %s, want %s", forward, test.forward)
+			}
+			payload, err := transfer.LoadMaybeRef()
+			if err != nil || payload == nil {
+				t.Fatalf("missing forward payload: %v", err)
+			}
+			checkSwapPayload(t, payload, message)
+		})
+	}
+}
+
+func TestSwapPayloadReferral(t *testing.T) {
+	message := testSwapMessage()
+	message.ReferralAddress = address.MustParseAddr("UQAzC1P9oEQcVzKIOgyVeidkJlWbHGXvbNlIute5W5XHwNgf")
+
+	params := SwapPayloadToCell(message).BeginParse().MustLoadRef()
+	params.MustLoadBigCoins()
+	params.MustLoadAddr()
+	params.MustLoadBigCoins()
+	params.MustLoadBoolBit()
+	params.MustLoadBigCoins()
+	params.MustLoadBoolBit()
+	params.MustLoadUInt(16)
+	equalAddr(t, "referral", params.MustLoadAddr(), message.ReferralAddress)
+}
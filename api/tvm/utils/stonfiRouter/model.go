@@ -1 +1,52 @@
 package stonfiRouter
+
+import (
+	"math/big"
+
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
+)
+
+// ops of the v2 router, swaps are jetton transfers to the router carrying the swap payload
+const (
+	OpJettonTransfer = 0x0f8a7ea5
+	OpSwap           = 0x6664de2a
+)
+
+// default referral fee in bps, only paid when a referral address is set
+const DefaultReferralValue = 10
+
+// gas attached to the jetton transfer and forwarded to the router, the sdk defaults
+var (
+	JettonToJettonGasAmount     = tlb.MustFromTON("0.3")
+	JettonToJettonForwardAmount = tlb.MustFromTON("0.24")
+	JettonToTonGasAmount        = tlb.MustFromTON("0.3")
+	JettonToTonForwardAmount    = tlb.MustFromTON("0.24")
+)
+
+type GetRouterDataResponse struct {
+	Id                 string `json:"id"`
+	DexType            string `json:"dex-type"`
+	IsLocked           bool   `json:"is-locked"`
+	AdminAddress       string `json:"admin-address"`
+	PoolCode           string `json:"pool-code,omitempty"`
+	JettonLpWalletCode string `json:"jetton-lp-wallet-code,omitempty"`
+	LpAccountCode      string `json:"lp-account-code,omitempty"`
+}
+
+type GetPoolAddressResponse struct {
+	PoolAddress string `json:"pool-address"`
+}
+
+// SwapMessage is a jetton transfer from the sender's offer jetton wallet to the router
+type SwapMessage struct {
+	QueryId         uint64
+	OfferAmount     *big.Int
+	Router          *address.Address
+	AskJettonWallet *address.Address // router's wallet of the ask jetton, of pton for jetton→TON
+	MinAskAmount    *big.Int
+	Receiver        *address.Address
+	RefundAddress   *address.Address // also receives the excesses
+	Deadline        uint64           // unix seconds, the router refunds the swap after it
+	ReferralAddress *address.Address // optional
+}
@@ -1 +1,79 @@
 package stonfiRouter
+
+import (
+	"fmt"
+
+	tvmUtils "github.com/crosscall-labs/crosschain-api/api/tvm/utils"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+)
+
+const tonapiAccounts = "https://testnet.tonapi.io/v2/blockchain/accounts/"
+
+func GetRouterData(routerAddressRaw string) (GetRouterDataResponse, error) {
+	response, err := tvmUtils.CallViewFunction(tonapiAccounts, routerAddressRaw, "get_router_data", []string{})
+	if err != nil {
+		return GetRouterDataResponse{}, err
+	}
+
+	stack, err := tvmUtils.ParseViewResponse(response)
+	if err != nil {
+		return GetRouterDataResponse{}, err
+	}
+
+	if len(stack) < 4 {
+		return GetRouterDataResponse{}, fmt.Errorf("stack has insufficient items to map to GetRouterDataResponse")
+	}
+
+	admin, err := tvmUtils.ParseAddressCell(stack[3].Cell)
+	if err != nil {
+		return GetRouterDataResponse{}, err
+	}
+	result := GetRouterDataResponse{
+		Id:           stack[0].Num,
+		DexType:      stack[1].Cell,
+		IsLocked:     isTrue(stack[2].Num),
+		AdminAddress: admin.String(),
+	}
+	// the codes follow the temp upgrade cell
+	if len(stack) >= 8 {
+		result.PoolCode = stack[5].Cell
+		result.JettonLpWalletCode = stack[6].Cell
+		result.LpAccountCode = stack[7].Cell
+	}
+
+	utils.LogInfo("Formatted get_router_data response", tvmUtils.FormatKeyValueLogs(stack))
+	return result, nil
+}
+
+// GetPoolAddress returns the pool of two router jetton wallets, the order does not matter
+func GetPoolAddress(routerAddressRaw string, token0WalletRaw string, token1WalletRaw string) (GetPoolAddressResponse, error) {
+	response, err := tvmUtils.CallViewFunction(tonapiAccounts, routerAddressRaw, "get_pool_address", []string{token0WalletRaw, token1WalletRaw})
+	if err != nil {
+		return GetPoolAddressResponse{}, err
+	}
+
+	stack, err := tvmUtils.ParseViewResponse(response)
+	if err != nil {
+		return GetPoolAddressResponse{}, err
+	}
+
+	if len(stack) < 1 {
+		return GetPoolAddressResponse{}, fmt.Errorf("stack has insufficient items to map to GetPoolAddressResponse")
+	}
+
+	pool, err := tvmUtils.ParseAddressCell(stack[0].Cell)
+	if err != nil {
+		return GetPoolAddressResponse{}, err
+	}
+	result := GetPoolAddressResponse{
+		PoolAddress: pool.String(),
+	}
+
+	utils.LogInfo("Formatted get_pool_address response", tvmUtils.FormatKeyValueLogs(stack))
+	return result, nil
+}
+
+func isTrue(num string) bool {
+	value, err := tvmUtils.ParseNum(num)
+	return err == nil && value.Sign() != 0
+}
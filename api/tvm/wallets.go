@@ -0,0 +1,100 @@
+package tvmHandler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
+	"github.com/xssnick/tonutils-go/ton"
+	"github.com/xssnick/tonutils-go/ton/jetton"
+)
+
+// JettonBalance is the balance of an owner's jetton wallet, uninitialized wallets hold 0
+type JettonBalance struct {
+	Owner   string
+	Jetton  string // jetton master
+	Wallet  string
+	Balance string
+}
+
+// proxyWalletAccount returns a liteserver bound context and client with the proxy wallet address
+// of the evm and tvm owners and its account state
+func proxyWalletAccount(ctx context.Context, evmOwner common.Address, tvmOwner string) (context.Context, ton.APIClientWrapped, string, *tlb.Account, error) {
+	tvmAddress, err := address.ParseAddr(tvmOwner)
+	if err != nil {
+		return ctx, nil, "", nil, fmt.Errorf("invalid tvm address: %v", err)
+	}
+
+	_, api, err := ConnectToTestnetClient()
+	if err != nil {
+		return ctx, nil, "", nil, err
+	}
+	// keep every query on one liteserver so the block is known to it
+	ctx = api.Client().StickyContext(ctx)
+	block, err := api.CurrentMasterchainInfo(ctx)
+	if err != nil {
+		return ctx, nil, "", nil, err
+	}
+
+	entrypointAddress, err := entrypointOf(testnetChainId)
+	if err != nil {
+		return ctx, nil, "", nil, err
+	}
+	// proxy wallets are deployed with nonce 0 on the masterchain workchain, see UnsignedEntryPointRequest
+	proxyWalletAddress, _ := calculateProxyWalletAddress(0, entrypointAddress, new(big.Int).SetBytes(evmOwner.Bytes()), tvmAddress, byte(block.Workchain))
+
+	account, err := api.GetAccount(ctx, block, proxyWalletAddress)
+	if err != nil {
+		return ctx, nil, proxyWalletAddress.String(), nil, fmt.Errorf("failed to get proxy wallet: %v", err)
+	}
+	return ctx, api, proxyWalletAddress.String(), account, nil
+}
+
+// ProxyWalletState returns the proxy wallet address of the evm and tvm owners and whether it is initialized
+func ProxyWalletState(ctx context.Context, evmOwner common.Address, tvmOwner string) (string, bool, error) {
+	_, _, proxyWalletAddress, account, err := proxyWalletAccount(ctx, evmOwner, tvmOwner)
+	if err != nil {
+		return proxyWalletAddress, false, err
+	}
+	return proxyWalletAddress, account.IsActive, nil
+}
+
+// JettonBalances returns the owner's wallet balance for every jetton master
+func JettonBalances(ctx context.Context, owner string, jettons []string) ([]JettonBalance, error) {
+	ownerAddress, err := address.ParseAddr(owner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tvm address: %v", err)
+	}
+
+	_, api, err := ConnectToTestnetClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx = api.Client().StickyContext(ctx)
+
+	balances := make([]JettonBalance, 0, len(jettons))
+	for _, jettonAddress := range jettons {
+		master, err := address.ParseAddr(jettonAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jetton address: %s", jettonAddress)
+		}
+		jettonWallet, err := jetton.NewJettonMasterClient(api, master).GetJettonWallet(ctx, ownerAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s jetton wallet: %v", jettonAddress, err)
+		}
+		balance, err := jettonWallet.GetBalance(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s jetton balance: %v", jettonAddress, err)
+		}
+		balances = append(balances, JettonBalance{
+			Owner:   owner,
+			Jetton:  jettonAddress,
+			Wallet:  jettonWallet.Address().String(),
+			Balance: balance.String(),
+		})
+	}
+	return balances, nil
+}
@@ -0,0 +1,108 @@
+// Package v1Handler serves the versioned json routes, e.g. POST /v1/tvm/entrypoint/signed.
+// They call the same service functions as the legacy ?query= endpoints, which stay as they are.
+package v1Handler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/db"
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	maxBodyBytes = 1 << 20
+	eventsPath   = "/v1/events" // served by api/events on the long running server only
+)
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			logrus.Errorf("Recovered from panic: %v", rec)
+			if supabaseClient, err := newSupabaseClient(); err == nil {
+				if logErr := db.LogPanic(supabaseClient, fmt.Sprintf("%v", rec), nil); logErr != nil {
+					logrus.Errorf("Failed to log panic to Supabase: %v", logErr)
+				}
+			}
+			utils.WriteError(w, utils.ErrInternal("recovered from panic"))
+		}
+	}()
+
+	handlerWithCORS := utils.EnableCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		if path == eventsPath {
+			// vercel routes /v1/(.*) here, serverless functions can't hold the event stream
+			utils.WriteError(w, utils.Err(apierr.NotFound, fmt.Sprintf("%s is only served by the long running server (main.go), not by serverless deployments", eventsPath)))
+			return
+		}
+		route, status := findRoute(r.Method, path)
+		switch status {
+		case http.StatusNotFound:
+			utils.WriteError(w, utils.Err(apierr.NotFound, fmt.Sprintf("no route %s", r.URL.Path)))
+			return
+		case http.StatusMethodNotAllowed:
+			utils.WriteError(w, utils.Err(apierr.MethodNotAllowed, fmt.Sprintf("%s expects %s", route.Path, route.Method)))
+			return
+		}
+
+		if route.Admin {
+			if !utils.AdminAuthorized(r) {
+				utils.WriteError(w, utils.Err(apierr.Unauthorized, "missing or invalid X-Admin-Key"))
+				return
+			}
+		}
+
+		supabaseClient, err := newSupabaseClient()
+		if err != nil {
+			utils.WriteError(w, utils.ErrInternal("failed to create supabase client"))
+			return
+		}
+
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+		}
+		response, err := route.Service(r, supabaseClient)
+		HandleResponse(w, r, supabaseClient, route, response, err)
+	}))
+
+	handlerWithCORS.ServeHTTP(w, r)
+}
+
+// findRoute returns the route of path, a path served for another method answers 405
+func findRoute(method, path string) (Route, int) {
+	var found *Route
+	for i, route := range Routes {
+		if route.Path != path {
+			continue
+		}
+		// GET routes also take a json POST body for clients that never send a query string
+		if route.Method == method || (route.Method == http.MethodGet && method == http.MethodPost) {
+			return route, http.StatusOK
+		}
+		found = &Routes[i]
+	}
+	if found == nil {
+		return Route{}, http.StatusNotFound
+	}
+	return *found, http.StatusMethodNotAllowed
+}
+
+func newSupabaseClient() (*supabase.Client, error) {
+	return supabase.NewClient(os.Getenv("SUPABASE_URL"), os.Getenv("SUPABASE_SERVICE_ROLE_KEY"), nil)
+}
+
+func HandleResponse(w http.ResponseWriter, r *http.Request, supabaseClient *supabase.Client, route Route, response interface{}, err error) {
+	if err != nil {
+		if logErr := db.LogError(supabaseClient, err, route.Path, response); logErr != nil {
+			fmt.Printf("Failed to log error: %v\n", logErr.Error())
+		}
+	}
+	utils.WriteResponse(w, response, err)
+}
@@ -0,0 +1,46 @@
+package v1Handler
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFindRoute(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		route  string
+	}{
+		{"post route", http.MethodPost, "/v1/tvm/entrypoint/signed", http.StatusOK, "/v1/tvm/entrypoint/signed"},
+		{"get route", http.MethodGet, "/v1/info/version", http.StatusOK, "/v1/info/version"},
+		{"get route with a post body", http.MethodPost, "/v1/info/chain", http.StatusOK, "/v1/info/chain"},
+		{"post route with get", http.MethodGet, "/v1/tvm/entrypoint/signed", http.StatusMethodNotAllowed, "/v1/tvm/entrypoint/signed"},
+		{"get route with delete", http.MethodDelete, "/v1/info/version", http.StatusMethodNotAllowed, "/v1/info/version"},
+		{"unknown path", http.MethodPost, "/v1/tvm/entrypoint", http.StatusNotFound, ""},
+		{"legacy path", http.MethodGet, "/api/tvm", http.StatusNotFound, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			route, status := findRoute(test.method, test.path)
+			if status != test.status || route.Path != test.route {
+				t.Fatalf("route %q status %d, want %q %d", route.Path, status, test.route, test.status)
+			}
+		})
+	}
+}
+
+func TestRoutesAreUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, route := range Routes {
+		key := route.Method + " " + route.Path
+		if seen[key] {
+			t.Errorf("%s is routed twice", key)
+		}
+		seen[key] = true
+		if route.Service == nil {
+			t.Errorf("%s has no service", key)
+		}
+	}
+}
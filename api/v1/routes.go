@@ -0,0 +1,84 @@
+package v1Handler
+
+import (
+	"net/http"
+
+	evmHandler "github.com/crosscall-labs/crosschain-api/api/evm"
+	infoHandler "github.com/crosscall-labs/crosschain-api/api/info"
+	handler "github.com/crosscall-labs/crosschain-api/api/main"
+	requestHandler "github.com/crosscall-labs/crosschain-api/api/request"
+	svmHandler "github.com/crosscall-labs/crosschain-api/api/svm"
+	tvmHandler "github.com/crosscall-labs/crosschain-api/api/tvm"
+	"github.com/supabase-community/supabase-go"
+)
+
+// Service is a service function of a route, params are bound from the json body or the query string
+type Service func(r *http.Request, supabaseClient *supabase.Client) (interface{}, error)
+
+type Route struct {
+	Method  string
+	Path    string
+	Query   string // query= name of the same service on the legacy endpoint
+	Admin   bool   // requires the X-Admin-Key header
+	Service Service
+}
+
+// service adapts the variadic service functions, the route always binds from the request
+func service[P any](fn func(r *http.Request, parameters ...P) (interface{}, error)) Service {
+	return func(r *http.Request, _ *supabase.Client) (interface{}, error) {
+		return fn(r)
+	}
+}
+
+var Routes = []Route{
+	// main
+	{Method: http.MethodPost, Path: "/v1/message/unsigned", Query: "unsigned-message", Service: handler.UnsignedRequest},
+	{Method: http.MethodPost, Path: "/v1/bytecode/unsigned", Query: "unsigned-bytecode", Service: func(r *http.Request, _ *supabase.Client) (interface{}, error) {
+		return handler.UnsignedBytecode(r)
+	}},
+	{Method: http.MethodPost, Path: "/v1/bytecode/signed", Query: "signed-bytecode", Service: handler.SignedBytecode},
+	{Method: http.MethodPost, Path: "/v1/escrow/payout/unsigned", Query: "unsigned-escrow-payout", Service: func(r *http.Request, _ *supabase.Client) (interface{}, error) {
+		return handler.UnsignedEscrowPayout(r)
+	}},
+	{Method: http.MethodPost, Path: "/v1/escrow/payout/signed", Query: "signed-escrow-payout", Service: handler.SignedEscrowPayout},
+
+	// info
+	{Method: http.MethodGet, Path: "/v1/info/version", Query: "version", Service: service(infoHandler.VersionRequest)},
+	{Method: http.MethodGet, Path: "/v1/info/chain", Query: "chain-info", Service: service(infoHandler.ChainInfoRequest)},
+	{Method: http.MethodPost, Path: "/v1/info/chain/reload", Query: "chain-reload", Admin: true, Service: service(infoHandler.ChainReloadRequest)},
+	{Method: http.MethodPost, Path: "/v1/info/asset", Query: "asset-info", Service: service(infoHandler.AssetInfoRequest)},
+	{Method: http.MethodPost, Path: "/v1/info/user", Query: "user-info", Service: service(infoHandler.UserInfoRequest)},
+	{Method: http.MethodPost, Path: "/v1/info/user/transactions", Query: "user-transactions", Service: func(r *http.Request, supabaseClient *supabase.Client) (interface{}, error) {
+		return infoHandler.UserTransactionsRequest(r, supabaseClient)
+	}},
+
+	// request
+	{Method: http.MethodPost, Path: "/v1/request/asset/mint", Query: "asset-mint", Service: service(requestHandler.AssetMintRequest)},
+	{Method: http.MethodPost, Path: "/v1/request/crosschain/unsigned", Query: "unsigned-crosschain-request", Service: func(r *http.Request, _ *supabase.Client) (interface{}, error) {
+		return requestHandler.UnsignedCrosschainRequest(r)
+	}},
+
+	// evm
+	{Method: http.MethodPost, Path: "/v1/evm/escrow/unsigned", Query: "unsigned-escrow-request", Service: service(evmHandler.UnsignedEscrowRequest)},
+	{Method: http.MethodPost, Path: "/v1/evm/entrypoint/unsigned", Query: "unsigned-entrypoint-request", Service: service(evmHandler.UnsignedEntryPointRequest)},
+	{Method: http.MethodPost, Path: "/v1/evm/asset/info", Query: "asset-info", Service: service(evmHandler.AssetInfoRequest)},
+	{Method: http.MethodPost, Path: "/v1/evm/asset/mint", Query: "asset-mint", Service: service(evmHandler.AssetMintRequest)},
+
+	// svm
+	{Method: http.MethodPost, Path: "/v1/svm/escrow/unsigned", Query: "unsigned-escrow-request", Service: service(svmHandler.UnsignedEscrowRequest)},
+	{Method: http.MethodPost, Path: "/v1/svm/entrypoint/unsigned", Query: "unsigned-entrypoint-request", Service: service(svmHandler.UnsignedEntryPointRequest)},
+	{Method: http.MethodPost, Path: "/v1/svm/signature/verify", Query: "verify-signature", Service: service(svmHandler.VerifySignatureRequest)},
+	{Method: http.MethodPost, Path: "/v1/svm/asset/info", Query: "asset-info", Service: service(svmHandler.AssetInfoRequest)},
+
+	// tvm
+	{Method: http.MethodPost, Path: "/v1/tvm/escrow/unsigned", Query: "unsigned-escrow-request", Service: service(tvmHandler.UnsignedEscrowRequest)},
+	{Method: http.MethodPost, Path: "/v1/tvm/entrypoint/unsigned", Query: "unsigned-entrypoint-request", Service: service(tvmHandler.UnsignedEntryPointRequest)},
+	{Method: http.MethodPost, Path: "/v1/tvm/entrypoint/signed", Query: "signed-entrypoint-request", Service: service(tvmHandler.SignedEntryPointRequest)},
+	{Method: http.MethodPost, Path: "/v1/tvm/mint-to/unsigned", Query: "swap-to-data-info", Service: service(tvmHandler.UnsignedMintToRequest)},
+	{Method: http.MethodPost, Path: "/v1/tvm/mint-from/unsigned", Query: "swap-from-data-info", Service: service(tvmHandler.UnsignedMintFromRequest)},
+	{Method: http.MethodPost, Path: "/v1/tvm/swap/quote", Query: "swap-quote", Service: service(tvmHandler.SwapQuoteRequest)},
+	{Method: http.MethodPost, Path: "/v1/tvm/swap/unsigned", Query: "unsigned-swap-request", Service: service(tvmHandler.UnsignedSwapRequest)},
+	{Method: http.MethodGet, Path: "/v1/tvm/message/status", Query: "message-status", Service: service(tvmHandler.MessageStatusRequest)},
+	{Method: http.MethodPost, Path: "/v1/tvm/asset/info", Query: "asset-info", Service: service(tvmHandler.AssetInfoRequest)},
+	{Method: http.MethodPost, Path: "/v1/tvm/asset/mint", Query: "asset-mint", Service: service(tvmHandler.AssetMintRequest)},
+}
@@ -8,6 +8,56 @@ CREATE TABLE IF NOT EXISTS debug_logs (
     context JSONB
 );
 
+-- Create the chains table for the chain registry (CHAIN_CONFIG_SOURCE=db)
+-- config holds one entry in the same format as pkg/chains/chains.json
+CREATE TABLE IF NOT EXISTS chains (
+    id VARCHAR(50) PRIMARY KEY,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    config JSONB NOT NULL
+);
+
+-- Create the escrow_payouts table, one row per relayed payout so a trace id is never paid twice
+CREATE TABLE IF NOT EXISTS escrow_payouts (
+    trace_id VARCHAR(66) PRIMARY KEY,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    origin_id VARCHAR(50) NOT NULL,
+    destination_id VARCHAR(50) NOT NULL,
+    signer VARCHAR(66) NOT NULL,
+    asset_address VARCHAR(66) NOT NULL,
+    asset_amount VARCHAR(78) NOT NULL, -- uint256 decimal, read back as a string
+    userop_hash VARCHAR(66),
+    status VARCHAR(20) NOT NULL,
+    dispatch_tx VARCHAR(66),
+    message_id VARCHAR(66),
+    gas_tx VARCHAR(66),
+    error TEXT
+);
+
+-- Create the intents table, one row per crosschain request keyed by userOpHash or tvm message hash
+-- history holds every state transition as {from, to, at, detail}
+CREATE TABLE IF NOT EXISTS intents (
+    id VARCHAR(66) PRIMARY KEY,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    vm VARCHAR(10) NOT NULL,
+    state VARCHAR(20) NOT NULL,
+    origin_id VARCHAR(50) NOT NULL,
+    destination_id VARCHAR(50) NOT NULL,
+    origin_signer VARCHAR(66) NOT NULL,
+    destination_signer VARCHAR(66) NOT NULL,
+    asset_address VARCHAR(66),
+    asset_amount VARCHAR(78), -- uint256 decimal, read back as a string
+    tx_hash VARCHAR(66),
+    payout_tx VARCHAR(66),
+    error TEXT,
+    history JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX IF NOT EXISTS intents_origin_signer_idx ON intents (origin_signer, created_at DESC);
+CREATE INDEX IF NOT EXISTS intents_destination_signer_idx ON intents (destination_signer, created_at DESC);
+
 -- Example insert to test table
 INSERT INTO debug_logs (log_level, error, message, context)
 VALUES 
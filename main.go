@@ -1,23 +1,35 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	EventsHandler "github.com/crosscall-labs/crosschain-api/api/events"
 	EvmHandler "github.com/crosscall-labs/crosschain-api/api/evm"
+	GrpcHandler "github.com/crosscall-labs/crosschain-api/api/grpc"
 	InfoHandler "github.com/crosscall-labs/crosschain-api/api/info"
 	Handler "github.com/crosscall-labs/crosschain-api/api/main"
 	RequestHandler "github.com/crosscall-labs/crosschain-api/api/request"
 	SvmHandler "github.com/crosscall-labs/crosschain-api/api/svm"
 	TvmHandler "github.com/crosscall-labs/crosschain-api/api/tvm"
+	V1Handler "github.com/crosscall-labs/crosschain-api/api/v1"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/crosscall-labs/crosschain-api/pkg/events"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 
 	"github.com/joho/godotenv"
 )
 
+const shutdownTimeout = 15 * time.Second
+
 type CustomLogFormatter struct{}
 
 func (f *CustomLogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
@@ -84,13 +96,72 @@ func main() {
 	// logrus.Fatal("This is an panic message")
 	// logrus.Panic("This is an fatal message")
 
+	stopChainWatcher, err := chains.WatchConfig()
+	if err != nil {
+		logrus.Warnf("Chain config watcher not started: %v", err)
+	} else if stopChainWatcher != nil {
+		defer stopChainWatcher()
+	}
+
 	http.HandleFunc("/api/main", Handler.Handler)
 	http.HandleFunc("/api/info", InfoHandler.Handler)
 	http.HandleFunc("/api/evm", EvmHandler.Handler)
 	http.HandleFunc("/api/svm", SvmHandler.Handler)
 	http.HandleFunc("/api/tvm", TvmHandler.Handler)
 	http.HandleFunc("/api/request", RequestHandler.Handler)
+	http.HandleFunc("/v1/", V1Handler.Handler) // json routes, the ?query= endpoints above stay for compatibility
+	http.HandleFunc("/v1/events", EventsHandler.Handler)
+
+	server := &http.Server{Addr: ":8080"}
+	// Shutdown does not cancel running requests, the event streams end when the bus closes
+	server.RegisterOnShutdown(events.Default.Close)
+
+	grpcServer := GrpcHandler.NewServer()
+	go func() {
+		if err := GrpcHandler.Serve(grpcServer, ""); err != nil {
+			logrus.Errorf("grpc server stopped: %v", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Println("Starting server on :8080")
+		serveErr <- server.ListenAndServe()
+	}()
+
+	var failed bool
+	select {
+	case err := <-serveErr:
+		logrus.Errorf("http server stopped: %v", err)
+		failed = true
+	case <-ctx.Done():
+		logrus.Info("Shutting down")
+	}
+	shutdown(server, grpcServer)
+	if failed {
+		os.Exit(1)
+	}
+}
 
-	log.Println("Starting server on :8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+// shutdown drains the http and grpc servers, requests still running after shutdownTimeout are cut off
+func shutdown(server *http.Server, grpcServer *grpc.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	if err := server.Shutdown(ctx); err != nil {
+		logrus.Errorf("http server shutdown: %v", err)
+	}
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
 }
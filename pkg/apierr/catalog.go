@@ -0,0 +1,142 @@
+// Package apierr is the error catalog of the api. Every error returned to a client is an Error of
+// one Kind, the kind sets the http status and a numeric code that stays stable across releases.
+package apierr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/bind"
+)
+
+type Kind string
+
+const (
+	Internal           Kind = "internal"
+	InvalidParam       Kind = "invalid-param"
+	UnsupportedChain   Kind = "unsupported-chain"
+	RpcFailure         Kind = "rpc-failure"
+	InsufficientEscrow Kind = "insufficient-escrow"
+	EscrowNotFound     Kind = "escrow-not-found"
+	EscrowLockExpiring Kind = "escrow-lock-expiring"
+	SignatureInvalid   Kind = "signature-invalid"
+	SimulationRevert   Kind = "simulation-revert"
+	QuoteExpired       Kind = "quote-expired"
+	Unauthorized       Kind = "unauthorized"
+	NotFound           Kind = "not-found"
+	MethodNotAllowed   Kind = "method-not-allowed"
+)
+
+type entry struct {
+	code    uint64
+	status  int
+	message string
+}
+
+// codes are part of the api, never renumber an entry, add new kinds with the next free code. The
+// catalog starts at 2000, the escrow kinds keep the codes 1000 (escrow address not exist) and 1001
+// (insufficient escrow balance) clients already handle. 2004 and 2005 are retired, do not reuse them.
+var catalog = map[Kind]entry{
+	Internal:           {2000, http.StatusInternalServerError, "Internal server error"},
+	InvalidParam:       {2001, http.StatusBadRequest, "Malformed request"},
+	UnsupportedChain:   {2002, http.StatusBadRequest, "Unsupported chain"},
+	RpcFailure:         {2003, http.StatusBadGateway, "RPC connection failed"},
+	EscrowNotFound:     {1000, http.StatusUnprocessableEntity, "Escrow address not exist"},
+	InsufficientEscrow: {1001, http.StatusUnprocessableEntity, "Insufficient escrow balance"},
+	SignatureInvalid:   {2006, http.StatusUnauthorized, "Signature validation failed"},
+	SimulationRevert:   {2007, http.StatusUnprocessableEntity, "Simulation reverted"},
+	QuoteExpired:       {2008, http.StatusGone, "Quote expired"},
+	Unauthorized:       {2009, http.StatusUnauthorized, "Unauthorized"},
+	NotFound:           {2010, http.StatusNotFound, "Not found"},
+	MethodNotAllowed:   {2011, http.StatusMethodNotAllowed, "Method not allowed"},
+	EscrowLockExpiring: {2012, http.StatusUnprocessableEntity, "Escrow lock expires too soon"},
+}
+
+func (k Kind) lookup() entry {
+	if e, ok := catalog[k]; ok {
+		return e
+	}
+	return catalog[Internal]
+}
+
+func (k Kind) Code() uint64    { return k.lookup().code }
+func (k Kind) Status() int     { return k.lookup().status }
+func (k Kind) Message() string { return k.lookup().message }
+
+type Error struct {
+	Code    uint64            `json:"code"`
+	Kind    Kind              `json:"kind"`
+	Message string            `json:"message"`
+	Details string            `json:"details"`
+	Origin  string            `json:"origin"`
+	Fields  []bind.FieldError `json:"fields,omitempty"` // every invalid param of a malformed request
+	Revert  string            `json:"revert,omitempty"` // decoded revert reason of a simulation
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("Error (Code: %d, Message: %s)", e.Code, e.Message)
+}
+
+// Status is the http status of the kind, errors built without a kind answer 500
+func (e Error) Status() int {
+	return e.Kind.Status()
+}
+
+func New(kind Kind, details string) Error {
+	return Error{
+		Code:    kind.Code(),
+		Kind:    kind,
+		Message: kind.Message(),
+		Details: details,
+		Origin:  origin(2),
+	}
+}
+
+func Newf(kind Kind, format string, args ...interface{}) Error {
+	e := New(kind, fmt.Sprintf(format, args...))
+	e.Origin = origin(2)
+	return e
+}
+
+// From converts any error into an Error, errors outside the catalog are internal with their
+// message as details so they don't encode as {}
+func From(err error) Error {
+	var apiErr Error
+	if errors.As(err, &apiErr) {
+		if apiErr.Kind == "" {
+			apiErr.Kind = Internal
+			apiErr.Code = Internal.Code()
+		}
+		return apiErr
+	}
+	var validationErr *bind.ValidationError
+	if errors.As(err, &validationErr) {
+		e := New(InvalidParam, validationErr.Error())
+		e.Fields = validationErr.Fields
+		return e
+	}
+	e := New(Internal, err.Error())
+	e.Origin = ""
+	return e
+}
+
+func Is(err error, kind Kind) bool {
+	var apiErr Error
+	return errors.As(err, &apiErr) && apiErr.Kind == kind
+}
+
+// origin is the package of the caller skip frames up
+func origin(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	parts := strings.Split(runtime.FuncForPC(pc).Name(), ".")
+	if len(parts) > 1 {
+		return strings.Join(parts[:len(parts)-1], ".")
+	}
+	return "unknown"
+}
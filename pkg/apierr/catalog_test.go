@@ -0,0 +1,73 @@
+package apierr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/bind"
+)
+
+// the codes are part of the api, a change here breaks clients
+func TestCatalogCodes(t *testing.T) {
+	codes := map[Kind]uint64{
+		Internal:           2000,
+		InvalidParam:       2001,
+		UnsupportedChain:   2002,
+		RpcFailure:         2003,
+		EscrowNotFound:     1000,
+		InsufficientEscrow: 1001,
+		SignatureInvalid:   2006,
+		SimulationRevert:   2007,
+		QuoteExpired:       2008,
+		Unauthorized:       2009,
+		NotFound:           2010,
+		MethodNotAllowed:   2011,
+		EscrowLockExpiring: 2012,
+	}
+	if len(codes) != len(catalog) {
+		t.Fatalf("%d kinds pinned, the catalog has %d", len(codes), len(catalog))
+	}
+	for kind, code := range codes {
+		if kind.Code() != code {
+			t.Errorf("%s: code %d, want %d", kind, kind.Code(), code)
+		}
+	}
+	if Kind("unknown").Code() != Internal.Code() {
+		t.Fatal("unknown kinds are not internal")
+	}
+}
+
+// clients handled 1000 and 1001 before the catalog, the escrow errors still answer with them
+func TestEscrowLegacyCodes(t *testing.T) {
+	if e := New(EscrowNotFound, "escrow 0x01 is not deployed"); e.Code != 1000 || e.Message != "Escrow address not exist" {
+		t.Fatalf("escrow not found %+v", e)
+	}
+	if e := New(InsufficientEscrow, "locked 1, required 2"); e.Code != 1001 || e.Message != "Insufficient escrow balance" {
+		t.Fatalf("insufficient escrow %+v", e)
+	}
+}
+
+func TestFrom(t *testing.T) {
+	validationErr := &bind.ValidationError{Fields: []bind.FieldError{{Field: "amount", Reason: "must be positive"}}}
+	tests := []struct {
+		name string
+		err  error
+		kind Kind
+	}{
+		{"catalog error", New(NotFound, "no route"), NotFound},
+		{"without kind", Error{Message: "legacy"}, Internal},
+		{"validation", validationErr, InvalidParam},
+		{"plain", errors.New("boom"), Internal},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := From(test.err)
+			if e.Kind != test.kind || e.Code != test.kind.Code() {
+				t.Fatalf("error %+v", e)
+			}
+		})
+	}
+	if e := From(validationErr); len(e.Fields) != 1 || e.Fields[0].Field != "amount" {
+		t.Fatalf("fields %+v", e.Fields)
+	}
+}
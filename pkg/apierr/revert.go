@@ -0,0 +1,98 @@
+package apierr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// nested reverts, e.g. FailedOpWithRevert carrying the revert of the account, are decoded this deep
+const maxRevertDepth = 4
+
+// RevertData returns the revert data of a failed eth_call, rpc errors carry it as hex in ErrorData
+func RevertData(err error) ([]byte, bool) {
+	var dataErr interface{ ErrorData() interface{} }
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	encoded, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, decodeErr := hexutil.Decode(encoded)
+	if decodeErr != nil || len(data) < 4 {
+		return nil, false
+	}
+	return data, true
+}
+
+// DecodeRevert reads Error(string), Panic(uint256) and the custom errors of abis, unknown
+// selectors are returned as hex
+func DecodeRevert(data []byte, abis ...*abi.ABI) string {
+	return decodeRevert(data, abis, 0)
+}
+
+func decodeRevert(data []byte, abis []*abi.ABI, depth int) string {
+	if len(data) < 4 {
+		if len(data) == 0 {
+			return "reverted without reason"
+		}
+		return hexutil.Encode(data)
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+
+	for _, contract := range abis {
+		if contract == nil {
+			continue
+		}
+		for _, customErr := range contract.Errors {
+			if !bytes.Equal(customErr.ID[:4], data[:4]) {
+				continue
+			}
+			values, err := customErr.Inputs.Unpack(data[4:])
+			if err != nil {
+				break
+			}
+			args := make([]string, len(values))
+			for i, value := range values {
+				args[i] = formatRevertArg(value, abis, depth)
+			}
+			return fmt.Sprintf("%s(%s)", customErr.Name, strings.Join(args, ", "))
+		}
+	}
+	return hexutil.Encode(data)
+}
+
+func formatRevertArg(value interface{}, abis []*abi.ABI, depth int) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []byte:
+		if depth < maxRevertDepth && len(v) >= 4 {
+			return decodeRevert(v, abis, depth+1)
+		}
+		return hexutil.Encode(v)
+	case common.Address:
+		return v.Hex()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// FromRevert builds a SimulationRevert with the decoded reason, false when err carries no revert data
+func FromRevert(err error, abis ...*abi.ABI) (Error, bool) {
+	data, ok := RevertData(err)
+	if !ok {
+		return Error{}, false
+	}
+	e := New(SimulationRevert, err.Error())
+	e.Origin = origin(2)
+	e.Revert = DecodeRevert(data, abis...)
+	return e, true
+}
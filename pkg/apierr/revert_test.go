@@ -0,0 +1,104 @@
+package apierr
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// errors of the v0.7 EntryPoint and an account
+const entryPointErrors = `[
+	{"type":"error","name":"FailedOp","inputs":[{"name":"opIndex","type":"uint256"},{"name":"reason","type":"string"}]},
+	{"type":"error","name":"FailedOpWithRevert","inputs":[{"name":"opIndex","type":"uint256"},{"name":"reason","type":"string"},{"name":"inner","type":"bytes"}]},
+	{"type":"error","name":"Unauthorized","inputs":[{"name":"caller","type":"address"}]}
+]`
+
+func encode(t *testing.T, signature string, types []string, values ...interface{}) []byte {
+	t.Helper()
+	var args abi.Arguments
+	for _, name := range types {
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+	packed, err := args.Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(crypto.Keccak256([]byte(signature))[:4], packed...)
+}
+
+func TestDecodeRevert(t *testing.T) {
+	contract, err := abi.JSON(strings.NewReader(entryPointErrors))
+	if err != nil {
+		t.Fatal(err)
+	}
+	caller := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	errorString := encode(t, "Error(string)", []string{"string"}, "insufficient allowance")
+	panicCode := encode(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x11))
+	unauthorized := encode(t, "Unauthorized(address)", []string{"address"}, caller)
+	nested := encode(t, "FailedOpWithRevert(uint256,string,bytes)", []string{"uint256", "string", "bytes"}, big.NewInt(0), "AA23 reverted", unauthorized)
+
+	// every level wraps the one below, deeper than maxRevertDepth the inner bytes stay hex
+	deep := errorString
+	for i := 0; i <= maxRevertDepth; i++ {
+		deep = encode(t, "FailedOpWithRevert(uint256,string,bytes)", []string{"uint256", "string", "bytes"}, big.NewInt(int64(i)), "AA23 reverted", deep)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		abis []*abi.ABI
+		want string
+	}{
+		{"Error(string)", errorString, nil, "insufficient allowance"},
+		{"Panic", panicCode, nil, "arithmetic underflow or overflow"},
+		{"custom error", unauthorized, []*abi.ABI{&contract}, "Unauthorized(" + caller.Hex() + ")"},
+		{"nested FailedOpWithRevert", nested, []*abi.ABI{&contract}, `FailedOpWithRevert(0, "AA23 reverted", Unauthorized(` + caller.Hex() + "))"},
+		{"nested Error(string)", encode(t, "FailedOpWithRevert(uint256,string,bytes)", []string{"uint256", "string", "bytes"}, big.NewInt(1), "AA33 reverted", errorString), []*abi.ABI{nil, &contract}, `FailedOpWithRevert(1, "AA33 reverted", insufficient allowance)`},
+		{"unknown selector", []byte{0xde, 0xad, 0xbe, 0xef, 0x01}, []*abi.ABI{&contract}, "0xdeadbeef01"},
+		{"custom error without abi", unauthorized[:4], nil, "0x8e4a23d6"},
+		{"short", []byte{0x01}, nil, "0x01"},
+		{"empty", nil, nil, "reverted without reason"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := DecodeRevert(test.data, test.abis...); got != test.want {
+				t.Fatalf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+
+	t.Run("depth", func(t *testing.T) {
+		got := DecodeRevert(deep, &contract)
+		if strings.Count(got, "FailedOpWithRevert(") != maxRevertDepth+1 || strings.Contains(got, "insufficient allowance") {
+			t.Fatalf("got %s", got)
+		}
+	})
+}
+
+type rpcError struct{ data interface{} }
+
+func (e rpcError) Error() string          { return "execution reverted" }
+func (e rpcError) ErrorData() interface{} { return e.data }
+
+func TestFromRevert(t *testing.T) {
+	e, ok := FromRevert(rpcError{"0x08c379a0" + "0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"6e6f000000000000000000000000000000000000000000000000000000000000"})
+	if !ok || e.Kind != SimulationRevert || e.Revert != "no" || e.Details != "execution reverted" {
+		t.Fatalf("error %+v", e)
+	}
+	for _, err := range []error{errors.New("timeout"), rpcError{nil}, rpcError{"0x01"}, rpcError{"zz"}} {
+		if _, ok := FromRevert(err); ok {
+			t.Fatalf("%v has no revert data", err)
+		}
+	}
+}
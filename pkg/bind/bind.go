@@ -0,0 +1,185 @@
+// Package bind fills request param structs from the query string or a json body.
+//
+// Fields are matched by their `query` tag and are required unless tagged `optional:"true"`.
+// Supported types are strings, bools, ints, uints, *big.Int, common.Address, ton *address.Address,
+// slices of those, and anything implementing encoding.TextUnmarshaler such as HexBytes. An optional
+// *common.Address stays nil when it is not sent, for fields where the zero address is a value.
+// `oneof:"a|b"` restricts the raw value and `validate:"positive,len=32"` runs registered validators,
+// uint=<bits> bounds a *big.Int, strings kept for their raw form are checked with uint, hex,
+// hex=<bytes> and evm-address.
+package bind
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/xssnick/tonutils-go/address"
+)
+
+var (
+	bigIntType          = reflect.TypeOf((*big.Int)(nil))
+	evmAddressType      = reflect.TypeOf(common.Address{})
+	evmAddressPtrType   = reflect.TypeOf((*common.Address)(nil))
+	tonAddressType      = reflect.TypeOf((*address.Address)(nil))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Bind fills dst, a pointer to a param struct (or a pointer to that pointer), every bad field is
+// reported at once in a *ValidationError. Values already set in dst satisfy required fields.
+func Bind(r *http.Request, dst interface{}) error {
+	val := reflect.ValueOf(dst)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return fmt.Errorf("bind: nil destination %T", dst)
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("bind: destination %s is not a struct", val.Type())
+	}
+
+	src, err := newSource(r)
+	if err != nil {
+		return &ValidationError{Fields: []FieldError{{Field: "body", Reason: err.Error()}}}
+	}
+
+	var errs []FieldError
+	bindStruct(val, src, &errs)
+	if len(errs) > 0 {
+		return &ValidationError{Fields: errs}
+	}
+	return nil
+}
+
+// bindStruct sets the fields in place, nested structs keep the values they already have
+func bindStruct(val reflect.Value, src source, errs *[]FieldError) {
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		fieldType := typ.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		tag := fieldType.Tag.Get("query")
+
+		if isNested(fieldType.Type) {
+			bindStruct(field, src.nested(tag), errs)
+			continue
+		}
+		if tag == "" {
+			continue
+		}
+
+		values := src.values(tag)
+		if len(values) == 0 {
+			if fieldType.Tag.Get("optional") != "true" && field.IsZero() {
+				*errs = append(*errs, FieldError{Field: tag, Reason: "required"})
+			}
+			continue
+		}
+
+		if err := setField(field, values); err != nil {
+			*errs = append(*errs, FieldError{Field: tag, Reason: err.Error()})
+			continue
+		}
+		if reason := check(field, values, fieldType.Tag); reason != "" {
+			*errs = append(*errs, FieldError{Field: tag, Reason: reason})
+		}
+	}
+}
+
+// isNested reports a param struct, struct values that decode themselves are regular fields
+func isNested(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && typ != evmAddressType && !reflect.PtrTo(typ).Implements(textUnmarshalerType)
+}
+
+func setField(field reflect.Value, values []string) error {
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := decode(slice.Index(i), value); err != nil {
+				return fmt.Errorf("item %d: %v", i, err)
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	return decode(field, values[0])
+}
+
+func decode(field reflect.Value, value string) error {
+	switch field.Type() {
+	case bigIntType:
+		n, ok := parseBigInt(value)
+		if !ok {
+			return fmt.Errorf("expected a decimal or 0x hex integer")
+		}
+		field.Set(reflect.ValueOf(n))
+		return nil
+	case evmAddressType:
+		if !common.IsHexAddress(value) {
+			return fmt.Errorf("expected a hex evm address")
+		}
+		field.Set(reflect.ValueOf(common.HexToAddress(value)))
+		return nil
+	case evmAddressPtrType:
+		if !common.IsHexAddress(value) {
+			return fmt.Errorf("expected a hex evm address")
+		}
+		addr := common.HexToAddress(value)
+		field.Set(reflect.ValueOf(&addr))
+		return nil
+	case tonAddressType:
+		addr, err := address.ParseAddr(value)
+		if err != nil {
+			if addr, err = address.ParseRawAddr(value); err != nil {
+				return fmt.Errorf("expected a ton address")
+			}
+		}
+		field.Set(reflect.ValueOf(addr))
+		return nil
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("expected true or false")
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected an integer of %d bits", field.Type().Bits())
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected an unsigned integer of %d bits", field.Type().Bits())
+		}
+		field.SetUint(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+func parseBigInt(value string) (*big.Int, bool) {
+	if hexValue, ok := strings.CutPrefix(strings.ToLower(value), "0x"); ok {
+		return new(big.Int).SetString(hexValue, 16)
+	}
+	return new(big.Int).SetString(value, 10)
+}
@@ -0,0 +1,256 @@
+package bind
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/xssnick/tonutils-go/address"
+)
+
+type testHeader struct {
+	Signer  string `query:"signer"`
+	ChainId uint64 `query:"chain-id"`
+}
+
+type testParams struct {
+	Header   testHeader       `query:"header"`
+	Amount   *big.Int         `query:"amount" validate:"positive"`
+	Owner    common.Address   `query:"owner" optional:"true" validate:"nonzero"`
+	Wallet   *address.Address `query:"wallet" optional:"true"`
+	Hash     HexBytes         `query:"hash" optional:"true" validate:"len=32"`
+	Ids      []uint32         `query:"id" optional:"true"`
+	Mode     string           `query:"mode" optional:"true" oneof:"fast|safe"`
+	Value    string           `query:"value" optional:"true" validate:"uint"`
+	Lock     string           `query:"lock" optional:"true" validate:"hex=32"`
+	Payload  string           `query:"payload" optional:"true" validate:"hex"`
+	Receiver string           `query:"receiver" optional:"true" validate:"evm-address"`
+	Debug    bool             `query:"debug" optional:"true"`
+	Nano     *big.Int         `query:"nano" optional:"true" validate:"uint=64"`
+	Asset    *common.Address  `query:"asset" optional:"true"`
+}
+
+const (
+	testOwner  = "0x1111111111111111111111111111111111111111"
+	testWallet = "EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N"
+	testHash   = "0x" + "ab" + "000000000000000000000000000000000000000000000000000000000000cd"
+)
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		query  string
+		body   string
+		check  func(t *testing.T, params *testParams)
+	}{
+		{
+			name:  "query string",
+			query: "signer=alice&chain-id=7&amount=0x10&owner=" + testOwner + "&wallet=" + testWallet + "&hash=" + testHash + "&id=1&id=2&debug=true",
+			check: func(t *testing.T, params *testParams) {
+				if params.Header.Signer != "alice" || params.Header.ChainId != 7 {
+					t.Fatalf("header %+v", params.Header)
+				}
+				if params.Amount.Int64() != 16 {
+					t.Fatalf("amount %s", params.Amount)
+				}
+				if params.Owner != common.HexToAddress(testOwner) {
+					t.Fatalf("owner %s", params.Owner)
+				}
+				if params.Wallet.String() != testWallet {
+					t.Fatalf("wallet %s", params.Wallet)
+				}
+				if len(params.Hash) != 32 || params.Hash[0] != 0xab || params.Hash[31] != 0xcd {
+					t.Fatalf("hash %x", params.Hash)
+				}
+				if !reflect.DeepEqual(params.Ids, []uint32{1, 2}) || !params.Debug {
+					t.Fatalf("ids %v debug %v", params.Ids, params.Debug)
+				}
+			},
+		},
+		{
+			name:   "nested json",
+			method: http.MethodPost,
+			body:   `{"header":{"signer":"bob","chain-id":11155111},"amount":"5","id":[3,4]}`,
+			check: func(t *testing.T, params *testParams) {
+				if params.Header.Signer != "bob" || params.Header.ChainId != 11155111 {
+					t.Fatalf("header %+v", params.Header)
+				}
+				if params.Amount.Int64() != 5 || !reflect.DeepEqual(params.Ids, []uint32{3, 4}) {
+					t.Fatalf("amount %s ids %v", params.Amount, params.Ids)
+				}
+			},
+		},
+		{
+			name:   "flat json",
+			method: http.MethodPost,
+			body:   `{"signer":"carol","chain-id":"1","amount":1}`,
+			check: func(t *testing.T, params *testParams) {
+				if params.Header.Signer != "carol" || params.Header.ChainId != 1 || params.Amount.Int64() != 1 {
+					t.Fatalf("params %+v", params)
+				}
+			},
+		},
+		{
+			name:   "nested json falls back to the outer object and the query",
+			method: http.MethodPost,
+			query:  "mode=safe&chain-id=9",
+			body:   `{"header":{"signer":"dave"},"amount":2,"wallet":null}`,
+			check: func(t *testing.T, params *testParams) {
+				if params.Header.Signer != "dave" || params.Header.ChainId != 9 || params.Mode != "safe" {
+					t.Fatalf("params %+v", params)
+				}
+				if params.Wallet != nil {
+					t.Fatalf("null wallet bound to %s", params.Wallet)
+				}
+			},
+		},
+		{
+			name:  "raw ton address",
+			query: "signer=e&chain-id=1&amount=1&wallet=0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8",
+			check: func(t *testing.T, params *testParams) {
+				if params.Wallet.String() != testWallet {
+					t.Fatalf("wallet %s", params.Wallet)
+				}
+			},
+		},
+		{
+			name:  "string validators",
+			query: "signer=f&chain-id=1&amount=1&value=1000000000000000000000&lock=" + testHash + "&payload=0xdead&receiver=" + testOwner,
+			check: func(t *testing.T, params *testParams) {
+				if params.Value != "1000000000000000000000" || params.Payload != "0xdead" {
+					t.Fatalf("params %+v", params)
+				}
+			},
+		},
+		{
+			name:  "optional address",
+			query: "signer=g&chain-id=1&amount=1&nano=18446744073709551615&asset=0x0000000000000000000000000000000000000000",
+			check: func(t *testing.T, params *testParams) {
+				if params.Asset == nil || *params.Asset != (common.Address{}) || params.Nano.Uint64() != 1<<64-1 {
+					t.Fatalf("asset %v nano %s", params.Asset, params.Nano)
+				}
+			},
+		},
+		{
+			name:  "optional address not sent",
+			query: "signer=h&chain-id=1&amount=1",
+			check: func(t *testing.T, params *testParams) {
+				if params.Asset != nil || params.Nano != nil {
+					t.Fatalf("asset %v nano %s", params.Asset, params.Nano)
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := &testParams{}
+			if err := Bind(newRequest(test.method, test.query, test.body), &params); err != nil {
+				t.Fatal(err)
+			}
+			test.check(t, params)
+		})
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	const valid = "signer=a&chain-id=1&amount=1"
+	tests := []struct {
+		name   string
+		method string
+		query  string
+		body   string
+		fields map[string]string // field -> part of the reason
+	}{
+		{"required", "", "", "", map[string]string{"signer": "required", "chain-id": "required", "amount": "required"}},
+		{"integer", "", "signer=a&chain-id=x&amount=1", "", map[string]string{"chain-id": "unsigned integer"}},
+		{"big int", "", "signer=a&chain-id=1&amount=1.5", "", map[string]string{"amount": "decimal or 0x hex"}},
+		{"positive", "", "signer=a&chain-id=1&amount=0", "", map[string]string{"amount": "positive"}},
+		{"nonzero", "", valid + "&owner=0x0000000000000000000000000000000000000000", "", map[string]string{"owner": "zero"}},
+		{"evm address", "", valid + "&owner=0x12", "", map[string]string{"owner": "evm address"}},
+		{"ton address", "", valid + "&wallet=EQ-bad", "", map[string]string{"wallet": "ton address"}},
+		{"hex bytes", "", valid + "&hash=0xzz", "", map[string]string{"hash": "hex bytes"}},
+		{"len", "", valid + "&hash=0xabcd", "", map[string]string{"hash": "length 32"}},
+		{"slice item", "", valid + "&id=1&id=-2", "", map[string]string{"id": "item 1"}},
+		{"oneof", "", valid + "&mode=slow", "", map[string]string{"mode": "fast, safe"}},
+		{"uint", "", valid + "&value=-1", "", map[string]string{"value": "unsigned integer"}},
+		{"uint big int", "", valid + "&nano=-1", "", map[string]string{"nano": "unsigned integer"}},
+		{"uint bits", "", valid + "&nano=18446744073709551616", "", map[string]string{"nano": "64 bits"}},
+		{"optional evm address", "", valid + "&asset=0x12", "", map[string]string{"asset": "evm address"}},
+		{"hex length", "", valid + "&lock=0xabcd", "", map[string]string{"lock": "32 hex bytes"}},
+		{"hex", "", valid + "&payload=xyz", "", map[string]string{"payload": "hex bytes"}},
+		{"evm-address", "", valid + "&receiver=alice", "", map[string]string{"receiver": "evm address"}},
+		{"bool", "", valid + "&debug=maybe", "", map[string]string{"debug": "true or false"}},
+		{"json body", http.MethodPost, "", "[1,2]", map[string]string{"body": "json object"}},
+		{"every field at once", "", "signer=a&chain-id=1&amount=0&mode=slow", "", map[string]string{"amount": "positive", "mode": "expected one of"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := &testParams{}
+			err := Bind(newRequest(test.method, test.query, test.body), &params)
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("err %v is not a ValidationError", err)
+			}
+			if len(validationErr.Fields) != len(test.fields) {
+				t.Fatalf("fields %+v, want %v", validationErr.Fields, test.fields)
+			}
+			for _, field := range validationErr.Fields {
+				if reason, ok := test.fields[field.Field]; !ok || !strings.Contains(field.Reason, reason) {
+					t.Fatalf("field %s: %s, want %q", field.Field, field.Reason, test.fields[field.Field])
+				}
+			}
+		})
+	}
+}
+
+func TestBindKeepsSetValues(t *testing.T) {
+	params := &testParams{Header: testHeader{Signer: "preset", ChainId: 3}, Amount: big.NewInt(4)}
+	if err := Bind(newRequest("", "", ""), &params); err != nil {
+		t.Fatal(err)
+	}
+	if params.Header.Signer != "preset" || params.Amount.Int64() != 4 {
+		t.Fatalf("params %+v", params)
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	RegisterValidator("even", func(field reflect.Value, _ string) error {
+		if field.Uint()%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+	type evenParams struct {
+		N uint64 `query:"n" validate:"even"`
+	}
+	if err := Bind(newRequest("", "n=4", ""), &evenParams{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Bind(newRequest("", "n=3", ""), &evenParams{}); err == nil || !strings.Contains(err.Error(), "must be even") {
+		t.Fatalf("err %v", err)
+	}
+
+	type unknownParams struct {
+		N uint64 `query:"n" validate:"odd"`
+	}
+	if err := Bind(newRequest("", "n=3", ""), &unknownParams{}); err == nil || !strings.Contains(err.Error(), "unknown validator odd") {
+		t.Fatalf("err %v", err)
+	}
+}
+
+func newRequest(method, query, body string) *http.Request {
+	if method == "" {
+		method = http.MethodGet
+	}
+	r := httptest.NewRequest(method, "/?"+query, strings.NewReader(body))
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	return r
+}
@@ -0,0 +1,111 @@
+package bind
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+// source looks up the raw values of a tag
+type source interface {
+	values(tag string) []string
+	// nested returns the source of a nested struct, flat sources return themselves
+	nested(tag string) source
+}
+
+// newSource reads json bodies of POST, PUT and PATCH requests, everything else binds the query string.
+// Keys missing from the body fall back to the query string.
+func newSource(r *http.Request) (source, error) {
+	query := querySource(r.URL.Query())
+	if r.Body == nil || (r.Method != http.MethodPost && r.Method != http.MethodPut && r.Method != http.MethodPatch) {
+		return query, nil
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		return query, nil
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %v", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data)) // later readers see the same body
+	if len(bytes.TrimSpace(data)) == 0 {
+		return query, nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("expected a json object: %v", err)
+	}
+	return jsonSource{object: object, fallback: query}, nil
+}
+
+type querySource url.Values
+
+func (q querySource) values(tag string) []string {
+	var values []string
+	for _, value := range q[tag] {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (q querySource) nested(string) source {
+	return q
+}
+
+// jsonSource reads a nested struct from the object under its tag, or from the same object when the
+// body is flat like the query string
+type jsonSource struct {
+	object   map[string]json.RawMessage
+	fallback source
+}
+
+func (j jsonSource) values(tag string) []string {
+	raw, ok := j.object[tag]
+	if !ok {
+		return j.fallback.values(tag)
+	}
+
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			if value, ok := rawString(item); ok {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	if value, ok := rawString(raw); ok {
+		return []string{value}
+	}
+	return nil
+}
+
+func (j jsonSource) nested(tag string) source {
+	var object map[string]json.RawMessage
+	if raw, ok := j.object[tag]; ok && json.Unmarshal(raw, &object) == nil {
+		return jsonSource{object: object, fallback: j}
+	}
+	return j
+}
+
+// rawString returns strings unquoted and numbers or bools as written, null is no value
+func rawString(raw json.RawMessage) (string, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return "", false
+	}
+	var value string
+	if json.Unmarshal(raw, &value) == nil {
+		return value, value != ""
+	}
+	return string(raw), true
+}
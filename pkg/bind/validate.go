@@ -0,0 +1,217 @@
+package bind
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// ValidationError lists every field that failed to bind or validate
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		fields[i] = fmt.Sprintf("%s: %s", field.Field, field.Reason)
+	}
+	return "invalid params: " + strings.Join(fields, "; ")
+}
+
+// Validator checks a decoded field, arg is the text after = in the validate tag
+type Validator func(field reflect.Value, arg string) error
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = map[string]Validator{
+		"positive":    validatePositive,
+		"nonzero":     validateNonZero,
+		"len":         validateLen,
+		"uint":        validateUint,
+		"hex":         validateHex,
+		"evm-address": validateEvmAddress,
+	}
+)
+
+// RegisterValidator adds a validator usable as `validate:"name"` or `validate:"name=arg"`
+func RegisterValidator(name string, validator Validator) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	validators[name] = validator
+}
+
+// check runs the oneof and validate tags of a decoded field, it returns the reason of the first failure
+func check(field reflect.Value, values []string, tag reflect.StructTag) string {
+	if oneof := tag.Get("oneof"); oneof != "" {
+		allowed := strings.Split(oneof, "|")
+		for _, value := range values {
+			if !contains(allowed, value) {
+				return fmt.Sprintf("expected one of %s", strings.Join(allowed, ", "))
+			}
+		}
+	}
+
+	rules := tag.Get("validate")
+	if rules == "" {
+		return ""
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		validatorsMu.RLock()
+		validator, ok := validators[name]
+		validatorsMu.RUnlock()
+		if !ok {
+			return fmt.Sprintf("unknown validator %s", name)
+		}
+		if err := validator(field, arg); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePositive(field reflect.Value, _ string) error {
+	positive := false
+	switch {
+	case field.Type() == bigIntType:
+		positive = !field.IsNil() && field.Interface().(*big.Int).Sign() > 0
+	case field.CanInt():
+		positive = field.Int() > 0
+	case field.CanUint():
+		positive = field.Uint() > 0
+	default:
+		return fmt.Errorf("positive does not apply to %s", field.Type())
+	}
+	if !positive {
+		return fmt.Errorf("must be positive")
+	}
+	return nil
+}
+
+func validateNonZero(field reflect.Value, _ string) error {
+	if field.IsZero() {
+		return fmt.Errorf("must not be zero")
+	}
+	return nil
+}
+
+// validateLen checks the length of strings, bytes and slices
+func validateLen(field reflect.Value, arg string) error {
+	want, err := strconv.Atoi(arg)
+	if err != nil {
+		return fmt.Errorf("len needs a number, got %q", arg)
+	}
+	switch field.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array:
+		if field.Len() != want {
+			return fmt.Errorf("expected length %d, got %d", want, field.Len())
+		}
+		return nil
+	}
+	return fmt.Errorf("len does not apply to %s", field.Type())
+}
+
+// validateUint checks a *big.Int or a string holding a decimal integer is unsigned, arg is the
+// maximum bit length if set
+func validateUint(field reflect.Value, arg string) error {
+	var n *big.Int
+	switch {
+	case field.Type() == bigIntType:
+		n = field.Interface().(*big.Int)
+	case field.Kind() == reflect.String:
+		var ok bool
+		if n, ok = new(big.Int).SetString(field.String(), 10); !ok {
+			return fmt.Errorf("expected a decimal unsigned integer")
+		}
+	default:
+		return fmt.Errorf("uint does not apply to %s", field.Type())
+	}
+	if n == nil || n.Sign() < 0 {
+		return fmt.Errorf("expected an unsigned integer")
+	}
+	if arg == "" {
+		return nil
+	}
+	bits, err := strconv.Atoi(arg)
+	if err != nil {
+		return fmt.Errorf("uint needs a number, got %q", arg)
+	}
+	if n.BitLen() > bits {
+		return fmt.Errorf("expected an unsigned integer of %d bits", bits)
+	}
+	return nil
+}
+
+// validateHex checks a string is hex with or without the 0x prefix, arg is the byte length if set
+func validateHex(field reflect.Value, arg string) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("hex does not apply to %s", field.Type())
+	}
+	var data HexBytes
+	if err := data.UnmarshalText([]byte(field.String())); err != nil {
+		return err
+	}
+	if arg == "" {
+		return nil
+	}
+	want, err := strconv.Atoi(arg)
+	if err != nil {
+		return fmt.Errorf("hex needs a number, got %q", arg)
+	}
+	if len(data) != want {
+		return fmt.Errorf("expected %d hex bytes, got %d", want, len(data))
+	}
+	return nil
+}
+
+// validateEvmAddress checks a string is a hex evm address, for fields kept as strings
+func validateEvmAddress(field reflect.Value, _ string) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("evm-address does not apply to %s", field.Type())
+	}
+	if !common.IsHexAddress(field.String()) {
+		return fmt.Errorf("expected a hex evm address")
+	}
+	return nil
+}
+
+// HexBytes decodes hex with or without the 0x prefix
+type HexBytes []byte
+
+func (b *HexBytes) UnmarshalText(text []byte) error {
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(string(text), "0x"), "0X"))
+	if err != nil {
+		return fmt.Errorf("expected hex bytes")
+	}
+	*b = data
+	return nil
+}
+
+func (b HexBytes) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(b)), nil
+}
+
+func (b HexBytes) String() string {
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,314 @@
+package boc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+type CellType int
+
+// exotic cell types are the first data byte, ordinary cells have no type byte
+const (
+	OrdinaryCell     CellType = -1
+	PrunedBranchCell CellType = 1
+	LibraryCell      CellType = 2
+	MerkleProofCell  CellType = 3
+	MerkleUpdateCell CellType = 4
+)
+
+const (
+	MaxLevel = 3
+	MaxBits  = 1023
+	MaxRefs  = 4
+	maxDepth = 1024
+
+	hashSize  = 32
+	depthSize = 2
+)
+
+// Cell is an immutable cell, its hashes and depths are computed once on creation
+type Cell struct {
+	exotic    bool
+	data      []byte // ceil(bitLen/8) bytes, unused trailing bits are zero
+	bitLen    int
+	refs      []*Cell
+	levelMask byte
+
+	// computed hashes and depths of the significant levels, pruned branches only compute their
+	// highest one and keep the lower ones in their data
+	hashes [][]byte
+	depths []uint16
+}
+
+// New creates a cell of the first bitLen bits of data, exotic cells are validated against their type
+func New(data []byte, bitLen int, refs []*Cell, exotic bool) (*Cell, error) {
+	if bitLen < 0 || bitLen > MaxBits {
+		return nil, fmt.Errorf("cell has %d bits, max is %d", bitLen, MaxBits)
+	}
+	if len(data)*8 < bitLen {
+		return nil, fmt.Errorf("cell data has %d bits, want %d", len(data)*8, bitLen)
+	}
+	if len(refs) > MaxRefs {
+		return nil, fmt.Errorf("cell has %d refs, max is %d", len(refs), MaxRefs)
+	}
+	for _, ref := range refs {
+		if ref == nil {
+			return nil, errors.New("cell has a nil ref")
+		}
+	}
+
+	c := &Cell{
+		exotic: exotic,
+		data:   append([]byte{}, data[:(bitLen+7)/8]...),
+		bitLen: bitLen,
+		refs:   append([]*Cell{}, refs...),
+	}
+	if bitLen%8 != 0 {
+		c.data[len(c.data)-1] &= 0xff << (8 - bitLen%8)
+	}
+
+	levelMask, err := c.computeLevelMask()
+	if err != nil {
+		return nil, err
+	}
+	c.levelMask = levelMask
+	if err := c.computeHashes(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// MustNew is New for cells known to be valid
+func MustNew(data []byte, bitLen int, refs []*Cell, exotic bool) *Cell {
+	c, err := New(data, bitLen, refs, exotic)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (c *Cell) Type() CellType {
+	if !c.exotic {
+		return OrdinaryCell
+	}
+	return CellType(c.data[0])
+}
+
+func (c *Cell) IsExotic() bool {
+	return c.exotic
+}
+
+// Data returns a copy of the cell data and its length in bits
+func (c *Cell) Data() ([]byte, int) {
+	return append([]byte{}, c.data...), c.bitLen
+}
+
+func (c *Cell) Refs() []*Cell {
+	return append([]*Cell{}, c.refs...)
+}
+
+func (c *Cell) LevelMask() byte {
+	return c.levelMask
+}
+
+func (c *Cell) Level() int {
+	return bits.Len8(c.levelMask)
+}
+
+// Hash returns the representation hash
+func (c *Cell) Hash() []byte {
+	return c.HashAt(MaxLevel)
+}
+
+// Depth returns the representation depth
+func (c *Cell) Depth() uint16 {
+	return c.DepthAt(MaxLevel)
+}
+
+// HashAt returns the hash of the cell seen from level, levels above the cell level return its highest hash
+func (c *Cell) HashAt(level int) []byte {
+	index := hashIndex(applyLevel(c.levelMask, level))
+	if c.Type() == PrunedBranchCell {
+		if own := hashIndex(c.levelMask); index != own {
+			return append([]byte{}, c.data[2+index*hashSize:2+(index+1)*hashSize]...)
+		}
+		index = 0
+	}
+	return append([]byte{}, c.hashes[index]...)
+}
+
+func (c *Cell) DepthAt(level int) uint16 {
+	index := hashIndex(applyLevel(c.levelMask, level))
+	if c.Type() == PrunedBranchCell {
+		if own := hashIndex(c.levelMask); index != own {
+			offset := 2 + own*hashSize + index*depthSize
+			return binary.BigEndian.Uint16(c.data[offset : offset+depthSize])
+		}
+		index = 0
+	}
+	return c.depths[index]
+}
+
+func (c *Cell) computeLevelMask() (byte, error) {
+	if !c.exotic {
+		var mask byte
+		for _, ref := range c.refs {
+			mask |= ref.levelMask
+		}
+		return mask, nil
+	}
+
+	if c.bitLen < 8 {
+		return 0, errors.New("exotic cell has no type byte")
+	}
+	switch c.Type() {
+	case PrunedBranchCell:
+		if len(c.refs) != 0 {
+			return 0, errors.New("pruned branch cell has refs")
+		}
+		if c.bitLen < 16 {
+			return 0, errors.New("pruned branch cell has no level mask")
+		}
+		mask := c.data[1]
+		if mask == 0 || mask > 7 {
+			return 0, fmt.Errorf("pruned branch cell has invalid level mask %d", mask)
+		}
+		if want := 16 + hashIndex(mask)*(hashSize+depthSize)*8; c.bitLen != want {
+			return 0, fmt.Errorf("pruned branch cell has %d bits, want %d", c.bitLen, want)
+		}
+		return mask, nil
+	case LibraryCell:
+		if len(c.refs) != 0 {
+			return 0, errors.New("library cell has refs")
+		}
+		if want := 8 + hashSize*8; c.bitLen != want {
+			return 0, fmt.Errorf("library cell has %d bits, want %d", c.bitLen, want)
+		}
+		return 0, nil
+	case MerkleProofCell, MerkleUpdateCell:
+		refsNum := 1
+		if c.Type() == MerkleUpdateCell {
+			refsNum = 2
+		}
+		if len(c.refs) != refsNum {
+			return 0, fmt.Errorf("merkle cell has %d refs, want %d", len(c.refs), refsNum)
+		}
+		if want := 8 + refsNum*(hashSize+depthSize)*8; c.bitLen != want {
+			return 0, fmt.Errorf("merkle cell has %d bits, want %d", c.bitLen, want)
+		}
+		// the data commits to the level 0 hash and depth of every ref
+		var mask byte
+		for i, ref := range c.refs {
+			hash := c.data[1+i*hashSize : 1+(i+1)*hashSize]
+			depthOffset := 1 + refsNum*hashSize + i*depthSize
+			if !bytes.Equal(hash, ref.HashAt(0)) {
+				return 0, fmt.Errorf("merkle cell hash %d does not match its ref", i)
+			}
+			if binary.BigEndian.Uint16(c.data[depthOffset:depthOffset+depthSize]) != ref.DepthAt(0) {
+				return 0, fmt.Errorf("merkle cell depth %d does not match its ref", i)
+			}
+			mask |= ref.levelMask
+		}
+		return mask >> 1, nil
+	default:
+		return 0, fmt.Errorf("unknown exotic cell type %d", c.data[0])
+	}
+}
+
+func (c *Cell) computeHashes() error {
+	total := hashIndex(c.levelMask) + 1
+	count := total
+	if c.Type() == PrunedBranchCell {
+		count = 1
+	}
+	offset := total - count
+	c.hashes = make([][]byte, count)
+	c.depths = make([]uint16, count)
+
+	merkle := c.Type() == MerkleProofCell || c.Type() == MerkleUpdateCell
+	index := 0
+	for level := 0; level <= c.Level(); level++ {
+		if !isSignificant(c.levelMask, level) {
+			continue
+		}
+		if index < offset {
+			index++
+			continue
+		}
+
+		hash := sha256.New()
+		hash.Write(c.descriptors(applyLevel(c.levelMask, level)))
+		// the lowest computed hash covers the data, the higher ones chain on it
+		if index == offset {
+			hash.Write(c.paddedData())
+		} else {
+			hash.Write(c.hashes[index-offset-1])
+		}
+
+		childLevel := level
+		if merkle {
+			childLevel++
+		}
+		var depth uint16
+		for _, ref := range c.refs {
+			refDepth := ref.DepthAt(childLevel)
+			hash.Write(binary.BigEndian.AppendUint16(nil, refDepth))
+			if refDepth > depth {
+				depth = refDepth
+			}
+		}
+		if len(c.refs) > 0 {
+			depth++
+			if depth > maxDepth {
+				return fmt.Errorf("cell depth exceeds %d", maxDepth)
+			}
+		}
+		for _, ref := range c.refs {
+			hash.Write(ref.HashAt(childLevel))
+		}
+
+		c.hashes[index-offset] = hash.Sum(nil)
+		c.depths[index-offset] = depth
+		index++
+	}
+	return nil
+}
+
+// descriptors are the two header bytes of the cell seen with levelMask
+func (c *Cell) descriptors(levelMask byte) []byte {
+	d1 := byte(len(c.refs)) + levelMask<<5
+	if c.exotic {
+		d1 += 8
+	}
+	d2 := byte(c.bitLen/8 + (c.bitLen+7)/8)
+	return []byte{d1, d2}
+}
+
+// paddedData completes a partial last byte with a 1 bit followed by zeros
+func (c *Cell) paddedData() []byte {
+	data := append([]byte{}, c.data...)
+	if c.bitLen%8 != 0 {
+		data[len(data)-1] |= 1 << (7 - c.bitLen%8)
+	}
+	return data
+}
+
+// hashIndex is the number of significant levels above 0 in the mask
+func hashIndex(levelMask byte) int {
+	return bits.OnesCount8(levelMask)
+}
+
+func applyLevel(levelMask byte, level int) byte {
+	if level >= 8 {
+		return levelMask
+	}
+	return levelMask & (1<<level - 1)
+}
+
+func isSignificant(levelMask byte, level int) bool {
+	return level == 0 || levelMask>>(level-1)&1 == 1
+}
@@ -0,0 +1,144 @@
+package boc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+func ordinary(t *testing.T, data []byte, bitLen int, refs ...*Cell) *Cell {
+	t.Helper()
+	c, err := New(data, bitLen, refs, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// prune replaces c by a level 1 pruned branch keeping its level 0 hash and depth
+func prune(t *testing.T, c *Cell) *Cell {
+	t.Helper()
+	data := append([]byte{byte(PrunedBranchCell), 1}, c.HashAt(0)...)
+	data = binary.BigEndian.AppendUint16(data, c.DepthAt(0))
+	pruned, err := New(data, len(data)*8, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pruned
+}
+
+func merkleProof(t *testing.T, c *Cell) *Cell {
+	t.Helper()
+	data := append([]byte{byte(MerkleProofCell)}, c.HashAt(0)...)
+	data = binary.BigEndian.AppendUint16(data, c.DepthAt(0))
+	proof, err := New(data, len(data)*8, []*Cell{c}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return proof
+}
+
+// tonutilsHash decodes the encoded cell with tonutils-go, the reference for every hash
+func tonutilsHash(t *testing.T, c *Cell) []byte {
+	t.Helper()
+	reference, err := cell.FromBOC(c.ToBOC())
+	if err != nil {
+		t.Fatalf("tonutils failed to decode: %v", err)
+	}
+	return reference.Hash()
+}
+
+func TestOrdinaryCellHash(t *testing.T) {
+	leaf := ordinary(t, []byte{0xab, 0xc0}, 10)
+	root := ordinary(t, []byte("root"), 32, leaf, leaf)
+
+	if root.Type() != OrdinaryCell || root.Level() != 0 || root.Depth() != 1 {
+		t.Fatalf("type %d level %d depth %d", root.Type(), root.Level(), root.Depth())
+	}
+	for _, c := range []*Cell{leaf, root} {
+		if want := tonutilsHash(t, c); !bytes.Equal(c.Hash(), want) {
+			t.Fatalf("hash %x, tonutils %x", c.Hash(), want)
+		}
+	}
+	// bits past bitLen are not part of the cell
+	if other := ordinary(t, []byte{0xab, 0xff}, 10); !bytes.Equal(other.Hash(), leaf.Hash()) {
+		t.Fatal("trailing bits changed the hash")
+	}
+}
+
+func TestPrunedBranchAndMerkleProof(t *testing.T) {
+	leaf := ordinary(t, []byte("leaf"), 32)
+	root := ordinary(t, []byte("root"), 32, leaf)
+
+	pruned := prune(t, leaf)
+	if pruned.Type() != PrunedBranchCell || pruned.LevelMask() != 1 {
+		t.Fatalf("pruned branch type %d level mask %d", pruned.Type(), pruned.LevelMask())
+	}
+	if !bytes.Equal(pruned.HashAt(0), leaf.Hash()) || pruned.DepthAt(0) != leaf.Depth() {
+		t.Fatal("pruned branch does not keep the hash and depth of the pruned cell")
+	}
+
+	// the level 0 hash of a tree with pruned branches is the hash of the full tree
+	partial := ordinary(t, []byte("root"), 32, pruned)
+	if partial.LevelMask() != 1 {
+		t.Fatalf("parent of a pruned branch has level mask %d", partial.LevelMask())
+	}
+	if !bytes.Equal(partial.HashAt(0), root.Hash()) {
+		t.Fatalf("level 0 hash %x, full tree %x", partial.HashAt(0), root.Hash())
+	}
+	if bytes.Equal(partial.Hash(), root.Hash()) {
+		t.Fatal("representation hash of the pruned tree equals the full tree")
+	}
+
+	proof := merkleProof(t, partial)
+	if proof.Type() != MerkleProofCell || proof.LevelMask() != 0 {
+		t.Fatalf("merkle proof type %d level mask %d", proof.Type(), proof.LevelMask())
+	}
+	if want := tonutilsHash(t, proof); !bytes.Equal(proof.Hash(), want) {
+		t.Fatalf("proof hash %x, tonutils %x", proof.Hash(), want)
+	}
+
+	decoded, err := DecodeRoot(proof.ToBOC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.Hash(), proof.Hash()) || decoded.Refs()[0].LevelMask() != 1 {
+		t.Fatal("merkle proof does not round-trip")
+	}
+}
+
+func TestInvalidExoticCells(t *testing.T) {
+	leaf := ordinary(t, []byte("leaf"), 32)
+	tests := map[string]func() error{
+		"pruned branch with refs": func() error {
+			data := append([]byte{byte(PrunedBranchCell), 1}, make([]byte, hashSize+depthSize)...)
+			_, err := New(data, len(data)*8, []*Cell{leaf}, true)
+			return err
+		},
+		"pruned branch of the wrong size": func() error {
+			data := append([]byte{byte(PrunedBranchCell), 1}, make([]byte, hashSize)...)
+			_, err := New(data, len(data)*8, nil, true)
+			return err
+		},
+		"merkle proof of another cell": func() error {
+			data := append([]byte{byte(MerkleProofCell)}, make([]byte, hashSize+depthSize)...)
+			_, err := New(data, len(data)*8, []*Cell{leaf}, true)
+			return err
+		},
+		"unknown type": func() error {
+			_, err := New([]byte{9}, 8, nil, true)
+			return err
+		},
+		"too many bits": func() error {
+			_, err := New(make([]byte, 128), MaxBits+1, nil, false)
+			return err
+		},
+	}
+	for name, build := range tests {
+		if build() == nil {
+			t.Fatalf("%s accepted", name)
+		}
+	}
+}
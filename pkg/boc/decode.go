@@ -0,0 +1,304 @@
+package boc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// magic prefixes, the indexed ones are the legacy single root formats
+const (
+	magicGeneric      = 0xb5ee9c72
+	magicIndexed      = 0x68ff65f3
+	magicIndexedCrc32 = 0xacc3a728
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+type header struct {
+	hasIndex     bool
+	hasCrc32c    bool
+	hasCacheBits bool
+	sizeBytes    int // bytes of a cell index
+	offsetBytes  int // bytes of a data offset
+	cellsNum     int
+	rootsNum     int
+	totalSize    int
+}
+
+type rawCell struct {
+	exotic    bool
+	levelMask byte
+	data      []byte
+	bitLen    int
+	refs      []int
+	end       int // offset of the end of the cell in the cell data
+}
+
+// DecodeRoot decodes a bag with a single root
+func DecodeRoot(data []byte) (*Cell, error) {
+	roots, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) != 1 {
+		return nil, fmt.Errorf("boc has %d roots, want 1", len(roots))
+	}
+	return roots[0], nil
+}
+
+// Decode decodes a bag of cells and returns its roots in order
+func Decode(data []byte) ([]*Cell, error) {
+	r := NewReader(data)
+	h, err := readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.hasCrc32c {
+		if len(data) < 4 {
+			return nil, ErrUnexpectedEnd
+		}
+		if crc32.Checksum(data[:len(data)-4], crc32cTable) != binary.LittleEndian.Uint32(data[len(data)-4:]) {
+			return nil, errors.New("boc crc32c does not match")
+		}
+	}
+
+	roots := []int{0}
+	if magic := binary.BigEndian.Uint32(data); magic == magicGeneric {
+		roots = make([]int, h.rootsNum)
+		for i := range roots {
+			if roots[i], err = r.ReadInt(h.sizeBytes); err != nil {
+				return nil, fmt.Errorf("failed to read root index: %w", err)
+			}
+			if roots[i] >= h.cellsNum {
+				return nil, fmt.Errorf("root index %d out of range", roots[i])
+			}
+		}
+	}
+
+	var index []int
+	if h.hasIndex {
+		index = make([]int, h.cellsNum)
+		for i := range index {
+			if index[i], err = r.ReadInt(h.offsetBytes); err != nil {
+				return nil, fmt.Errorf("failed to read index: %w", err)
+			}
+			// the lowest bit of a cache bits index flags cells worth caching
+			if h.hasCacheBits {
+				index[i] >>= 1
+			}
+		}
+	}
+
+	cellData, err := r.ReadBytes(h.totalSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cell data: %w", err)
+	}
+	if h.hasCrc32c {
+		if _, err := r.ReadBytes(4); err != nil {
+			return nil, err
+		}
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("boc has %d trailing bytes", r.Len())
+	}
+
+	raws, err := readCells(NewReader(cellData), h)
+	if err != nil {
+		return nil, err
+	}
+	for i, raw := range raws {
+		if index != nil && index[i] != raw.end {
+			return nil, fmt.Errorf("index of cell %d is %d, cell ends at %d", i, index[i], raw.end)
+		}
+	}
+
+	cells, err := buildCells(raws)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Cell, len(roots))
+	for i, root := range roots {
+		result[i] = cells[root]
+	}
+	return result, nil
+}
+
+func readHeader(r *Reader) (header, error) {
+	var h header
+	magic, err := r.ReadUint(4)
+	if err != nil {
+		return h, errors.New("boc is too short")
+	}
+
+	switch magic {
+	case magicGeneric:
+		// has_idx:(## 1) has_crc32c:(## 1) has_cache_bits:(## 1) flags:(## 2) size:(## 3)
+		flags, err := r.ReadByte()
+		if err != nil {
+			return h, err
+		}
+		h.hasIndex = flags&0x80 != 0
+		h.hasCrc32c = flags&0x40 != 0
+		h.hasCacheBits = flags&0x20 != 0
+		if flags&0x18 != 0 {
+			return h, fmt.Errorf("boc has unknown flags %d", flags>>3&3)
+		}
+		if h.hasCacheBits && !h.hasIndex {
+			return h, errors.New("boc has cache bits without an index")
+		}
+		h.sizeBytes = int(flags & 7)
+	case magicIndexed, magicIndexedCrc32:
+		h.hasIndex = true
+		h.hasCrc32c = magic == magicIndexedCrc32
+		size, err := r.ReadByte()
+		if err != nil {
+			return h, err
+		}
+		h.sizeBytes = int(size)
+	default:
+		return h, fmt.Errorf("invalid boc magic %08x", magic)
+	}
+	if h.sizeBytes < 1 || h.sizeBytes > 4 {
+		return h, fmt.Errorf("boc has invalid index size %d", h.sizeBytes)
+	}
+
+	offsetBytes, err := r.ReadByte()
+	if err != nil {
+		return h, err
+	}
+	h.offsetBytes = int(offsetBytes)
+	if h.offsetBytes < 1 || h.offsetBytes > 8 {
+		return h, fmt.Errorf("boc has invalid offset size %d", h.offsetBytes)
+	}
+
+	if h.cellsNum, err = r.ReadInt(h.sizeBytes); err != nil {
+		return h, err
+	}
+	if h.rootsNum, err = r.ReadInt(h.sizeBytes); err != nil {
+		return h, err
+	}
+	absentNum, err := r.ReadInt(h.sizeBytes)
+	if err != nil {
+		return h, err
+	}
+	if h.totalSize, err = r.ReadInt(h.offsetBytes); err != nil {
+		return h, err
+	}
+
+	if h.rootsNum < 1 || h.rootsNum > h.cellsNum {
+		return h, fmt.Errorf("boc has %d roots for %d cells", h.rootsNum, h.cellsNum)
+	}
+	if magic != magicGeneric && h.rootsNum != 1 {
+		return h, fmt.Errorf("indexed boc has %d roots, want 1", h.rootsNum)
+	}
+	if absentNum != 0 {
+		return h, errors.New("boc with absent cells is not supported")
+	}
+	// every cell takes at least its two descriptor bytes
+	if h.cellsNum*2 > h.totalSize {
+		return h, fmt.Errorf("boc has %d cells in %d bytes", h.cellsNum, h.totalSize)
+	}
+	return h, nil
+}
+
+func readCells(r *Reader, h header) ([]rawCell, error) {
+	raws := make([]rawCell, h.cellsNum)
+	for i := range raws {
+		raw, err := readCell(r, h.sizeBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cell %d: %w", i, err)
+		}
+		raw.end = h.totalSize - r.Len()
+		raws[i] = raw
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("cell data has %d trailing bytes", r.Len())
+	}
+	return raws, nil
+}
+
+func readCell(r *Reader, sizeBytes int) (rawCell, error) {
+	d1, err := r.ReadByte()
+	if err != nil {
+		return rawCell{}, err
+	}
+	d2, err := r.ReadByte()
+	if err != nil {
+		return rawCell{}, err
+	}
+
+	raw := rawCell{
+		exotic:    d1&8 != 0,
+		levelMask: d1 >> 5,
+	}
+	refsNum := int(d1 & 7)
+	if refsNum > MaxRefs {
+		return rawCell{}, fmt.Errorf("cell has %d refs, max is %d", refsNum, MaxRefs)
+	}
+
+	// stored hashes and depths are recomputed, they are only skipped
+	if d1&16 != 0 {
+		hashesNum := hashIndex(raw.levelMask) + 1
+		if _, err := r.ReadBytes(hashesNum * (hashSize + depthSize)); err != nil {
+			return rawCell{}, err
+		}
+	}
+
+	if raw.data, err = r.ReadBytes(int(d2/2 + d2%2)); err != nil {
+		return rawCell{}, err
+	}
+	raw.bitLen = int(d2/2) * 8
+	if d2%2 != 0 {
+		// a partial last byte ends with a 1 bit followed by zeros
+		last := raw.data[len(raw.data)-1]
+		if last == 0 {
+			return rawCell{}, errors.New("cell padding has no end bit")
+		}
+		raw.bitLen += 7 - bitsTrailingZeros(last)
+	}
+
+	raw.refs = make([]int, refsNum)
+	for i := range raw.refs {
+		if raw.refs[i], err = r.ReadInt(sizeBytes); err != nil {
+			return rawCell{}, err
+		}
+	}
+	return raw, nil
+}
+
+// buildCells creates the cells from the last one since refs always point forward
+func buildCells(raws []rawCell) ([]*Cell, error) {
+	cells := make([]*Cell, len(raws))
+	for i := len(raws) - 1; i >= 0; i-- {
+		raw := raws[i]
+		refs := make([]*Cell, len(raw.refs))
+		for j, ref := range raw.refs {
+			if ref <= i || ref >= len(raws) {
+				return nil, fmt.Errorf("cell %d has invalid ref %d", i, ref)
+			}
+			refs[j] = cells[ref]
+		}
+
+		c, err := New(raw.data, raw.bitLen, refs, raw.exotic)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cell %d: %w", i, err)
+		}
+		if c.levelMask != raw.levelMask {
+			return nil, fmt.Errorf("cell %d has level mask %d, computed %d", i, raw.levelMask, c.levelMask)
+		}
+		cells[i] = c
+	}
+	return cells, nil
+}
+
+func bitsTrailingZeros(b byte) int {
+	n := 0
+	for b&1 == 0 {
+		b >>= 1
+		n++
+	}
+	return n
+}
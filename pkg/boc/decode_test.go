@@ -0,0 +1,94 @@
+package boc
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestEncodeOptionsRoundTrip(t *testing.T) {
+	shared := ordinary(t, []byte("shared"), 48)
+	first := ordinary(t, []byte{1}, 8, shared)
+	second := ordinary(t, []byte{2}, 7, shared, first)
+
+	for _, options := range []EncodeOptions{{}, {Crc32c: true}, {Index: true}, {Index: true, Crc32c: true}} {
+		data, err := Encode([]*Cell{first, second}, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		flags := data[4]
+		if (flags&0x80 != 0) != options.Index || (flags&0x40 != 0) != options.Crc32c {
+			t.Fatalf("%+v: flags %08b", options, flags)
+		}
+		// identical cells are stored once
+		if cells := data[6]; cells != 3 {
+			t.Fatalf("%+v: %d cells stored, want 3", options, cells)
+		}
+
+		roots, err := Decode(data)
+		if err != nil {
+			t.Fatalf("%+v: %v", options, err)
+		}
+		if len(roots) != 2 || !bytes.Equal(roots[0].Hash(), first.Hash()) || !bytes.Equal(roots[1].Hash(), second.Hash()) {
+			t.Fatalf("%+v: roots do not round-trip", options)
+		}
+		if reencoded, _ := Encode(roots, options); !bytes.Equal(reencoded, data) {
+			t.Fatalf("%+v: re-encoded bag differs", options)
+		}
+		if _, err := DecodeRoot(data); err == nil {
+			t.Fatalf("%+v: DecodeRoot accepted two roots", options)
+		}
+	}
+}
+
+func TestDecodeRejectsCorruptBags(t *testing.T) {
+	root := ordinary(t, []byte("root"), 32, ordinary(t, []byte("leaf"), 32))
+	valid, err := Encode([]*Cell{root}, EncodeOptions{Index: true, Crc32c: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupt := func(change func([]byte) []byte) []byte {
+		return change(append([]byte{}, valid...))
+	}
+
+	tests := map[string][]byte{
+		"bad magic":      corrupt(func(b []byte) []byte { b[0] ^= 0xff; return b }),
+		"bad crc32c":     corrupt(func(b []byte) []byte { b[len(b)-1] ^= 0xff; return b }),
+		"truncated":      valid[:len(valid)-6],
+		"trailing bytes": append(append([]byte{}, valid...), 0),
+		"empty":          nil,
+	}
+	for name, data := range tests {
+		if _, err := Decode(data); err == nil {
+			t.Fatalf("%s accepted", name)
+		}
+	}
+
+	// without a checksum the index still has to match the cells
+	noCrc, _ := Encode([]*Cell{root}, EncodeOptions{Index: true})
+	// magic (4) | flags | offset size | cells | roots | absent | total size | root index, one byte each here
+	const indexOffset = 4 + 1 + 1 + 3 + 1 + 1
+	noCrc[indexOffset]++
+	if _, err := Decode(noCrc); err == nil {
+		t.Fatal("wrong index accepted")
+	}
+}
+
+func TestReader(t *testing.T) {
+	r := NewReader([]byte{0x01, 0x02, 0x03, 0x04, 0x05})
+	if v, err := r.ReadUint(3); err != nil || v != 0x010203 {
+		t.Fatalf("ReadUint: %x %v", v, err)
+	}
+	if b, err := r.ReadByte(); err != nil || b != 0x04 {
+		t.Fatalf("ReadByte: %x %v", b, err)
+	}
+	if r.Len() != 1 {
+		t.Fatalf("%d bytes left, want 1", r.Len())
+	}
+	if _, err := r.ReadBytes(2); !errors.Is(err, ErrUnexpectedEnd) {
+		t.Fatalf("read past the end: %v", err)
+	}
+	if _, err := NewReader(make([]byte, 9)).ReadUint(9); err == nil {
+		t.Fatal("uint wider than 8 bytes accepted")
+	}
+}
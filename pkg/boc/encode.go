@@ -0,0 +1,146 @@
+package boc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math/bits"
+
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+type EncodeOptions struct {
+	Index  bool // store the offset of every cell
+	Crc32c bool // append the crc32c of the bag
+}
+
+// ToBOC encodes the cell the way @ton/core toBoc() does by default, with a checksum and no index
+func (c *Cell) ToBOC() []byte {
+	data, _ := Encode([]*Cell{c}, EncodeOptions{Crc32c: true})
+	return data
+}
+
+// ToCell converts the cell for the tonutils builders
+func (c *Cell) ToCell() (*cell.Cell, error) {
+	return cell.FromBOC(c.ToBOC())
+}
+
+// FromCell converts a tonutils cell
+func FromCell(c *cell.Cell) (*Cell, error) {
+	return DecodeRoot(c.ToBOC())
+}
+
+// Encode serializes the roots into a generic bag, identical cells are stored once
+func Encode(roots []*Cell, options EncodeOptions) ([]byte, error) {
+	if len(roots) == 0 {
+		return nil, errors.New("boc needs at least one root")
+	}
+	cells, indexes := sortCells(roots)
+
+	sizeBytes := bytesForNumber(len(cells))
+	totalSize := 0
+	ends := make([]int, len(cells))
+	for i, c := range cells {
+		totalSize += 2 + len(c.data) + len(c.refs)*sizeBytes
+		ends[i] = totalSize
+	}
+	offsetBytes := bytesForNumber(totalSize)
+	if sizeBytes > 4 {
+		return nil, fmt.Errorf("boc of %d cells is too large", len(cells))
+	}
+
+	flags := byte(sizeBytes)
+	if options.Index {
+		flags |= 0x80
+	}
+	if options.Crc32c {
+		flags |= 0x40
+	}
+
+	data := binary.BigEndian.AppendUint32(nil, magicGeneric)
+	data = append(data, flags, byte(offsetBytes))
+	data = appendUint(data, uint64(len(cells)), sizeBytes)
+	data = appendUint(data, uint64(len(roots)), sizeBytes)
+	data = appendUint(data, 0, sizeBytes) // absent cells
+	data = appendUint(data, uint64(totalSize), offsetBytes)
+	for _, root := range roots {
+		data = appendUint(data, uint64(indexes[string(root.Hash())]), sizeBytes)
+	}
+	if options.Index {
+		for _, end := range ends {
+			data = appendUint(data, uint64(end), offsetBytes)
+		}
+	}
+	for _, c := range cells {
+		data = append(data, c.descriptors(c.levelMask)...)
+		data = append(data, c.paddedData()...)
+		for _, ref := range c.refs {
+			data = appendUint(data, uint64(indexes[string(ref.Hash())]), sizeBytes)
+		}
+	}
+	if options.Crc32c {
+		data = binary.LittleEndian.AppendUint32(data, crc32.Checksum(data, crc32cTable))
+	}
+	return data, nil
+}
+
+// sortCells orders the cells parents first with the topological sort of @ton/core, so bags
+// round-trip byte for byte with the js sdk
+func sortCells(roots []*Cell) ([]*Cell, map[string]int) {
+	all := map[string]*Cell{}
+	var pending []string // not yet sorted, in discovery order
+	queue := roots
+	for len(queue) > 0 {
+		var next []*Cell
+		for _, c := range queue {
+			hash := string(c.Hash())
+			if _, found := all[hash]; found {
+				continue
+			}
+			all[hash] = c
+			pending = append(pending, hash)
+			next = append(next, c.refs...)
+		}
+		queue = next
+	}
+
+	sorted := make([]string, 0, len(all))
+	done := map[string]bool{}
+	var visit func(hash string)
+	visit = func(hash string) {
+		if done[hash] {
+			return
+		}
+		refs := all[hash].refs
+		for i := len(refs) - 1; i >= 0; i-- {
+			visit(string(refs[i].Hash()))
+		}
+		sorted = append(sorted, hash)
+		done[hash] = true
+	}
+	for _, hash := range pending {
+		visit(hash)
+	}
+
+	cells := make([]*Cell, len(sorted))
+	indexes := make(map[string]int, len(sorted))
+	for i, hash := range sorted {
+		position := len(sorted) - 1 - i
+		cells[position] = all[hash]
+		indexes[hash] = position
+	}
+	return cells, indexes
+}
+
+// bytesForNumber is the bytes needed to store n, at least 1
+func bytesForNumber(n int) int {
+	return max(1, (bits.Len(uint(n))+7)/8)
+}
+
+func appendUint(data []byte, v uint64, n int) []byte {
+	for i := n - 1; i >= 0; i-- {
+		data = append(data, byte(v>>(8*i)))
+	}
+	return data
+}
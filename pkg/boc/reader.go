@@ -0,0 +1,71 @@
+package boc
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnexpectedEnd = errors.New("unexpected end of data")
+
+// Reader consumes a byte slice from the front, callers never track offsets
+type Reader struct {
+	data []byte
+}
+
+func NewReader(data []byte) *Reader {
+	return &Reader{data: data}
+}
+
+// Len returns the number of unread bytes
+func (r *Reader) Len() int {
+	return len(r.data)
+}
+
+func (r *Reader) ReadByte() (byte, error) {
+	if len(r.data) < 1 {
+		return 0, ErrUnexpectedEnd
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b, nil
+}
+
+// ReadBytes returns the next n bytes, the result shares memory with the reader
+func (r *Reader) ReadBytes(n int) ([]byte, error) {
+	if n < 0 || len(r.data) < n {
+		return nil, ErrUnexpectedEnd
+	}
+	b := r.data[:n:n]
+	r.data = r.data[n:]
+	return b, nil
+}
+
+// ReadUint reads an n byte big endian unsigned integer, n is at most 8
+func (r *Reader) ReadUint(n int) (uint64, error) {
+	if n > 8 {
+		return 0, fmt.Errorf("uint of %d bytes overflows uint64", n)
+	}
+	b, err := r.ReadBytes(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, x := range b {
+		v = v<<8 | uint64(x)
+	}
+	return v, nil
+}
+
+// ReadInt is ReadUint for sizes and indexes that must fit an int
+func (r *Reader) ReadInt(n int) (int, error) {
+	v, err := r.ReadUint(n)
+	if err != nil {
+		return 0, err
+	}
+	if v > uint64(maxInt) {
+		return 0, fmt.Errorf("value %d overflows int", v)
+	}
+	return int(v), nil
+}
+
+const maxInt = int(^uint(0) >> 1)
@@ -0,0 +1,89 @@
+package chains
+
+import (
+	_ "embed"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/supabase-community/supabase-go"
+)
+
+//go:embed chains.json
+var defaultConfig []byte
+
+var (
+	defaultOnce     sync.Once
+	defaultRegistry atomic.Pointer[Registry]
+	reloadMu        sync.Mutex
+)
+
+// Default returns the process wide registry
+// CHAIN_CONFIG_SOURCE=db loads the chains table, CHAIN_CONFIG_PATH loads a file,
+// otherwise (or on failure) the embedded chains.json is used
+func Default() *Registry {
+	defaultOnce.Do(func() {
+		registry, err := loadConfigured()
+		if err != nil {
+			log.Printf("\nFailed to load chain config, using embedded defaults: %v", err)
+			registry = mustLoadEmbedded()
+		}
+		defaultRegistry.Store(registry)
+	})
+	return defaultRegistry.Load()
+}
+
+// Reload reloads the configured source and swaps the default registry,
+// the current registry is kept if the new config fails to load or validate
+func Reload() (*Registry, error) {
+	Default()
+
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	registry, err := loadConfigured()
+	if err != nil {
+		return defaultRegistry.Load(), err
+	}
+	defaultRegistry.Store(registry)
+	log.Printf("\nChain registry reloaded from %s (%d chains)", registry.Source(), len(registry.order))
+	return registry, nil
+}
+
+func loadConfigured() (*Registry, error) {
+	if os.Getenv("CHAIN_CONFIG_SOURCE") == "db" {
+		client, err := supabase.NewClient(os.Getenv("SUPABASE_URL"), os.Getenv("SUPABASE_SERVICE_ROLE_KEY"), nil)
+		if err != nil {
+			return nil, err
+		}
+		return LoadFromDB(client)
+	}
+	if path := os.Getenv("CHAIN_CONFIG_PATH"); path != "" {
+		return LoadFile(path)
+	}
+	return mustLoadEmbedded(), nil
+}
+
+func mustLoadEmbedded() *Registry {
+	chains, err := Parse(defaultConfig, "json")
+	if err != nil {
+		panic(err)
+	}
+	registry, err := NewRegistry(chains)
+	if err != nil {
+		panic(err)
+	}
+	registry.source = "embedded"
+	return registry
+}
+
+// Get resolves a chain id or alias through the default registry
+func Get(chainId string) (*Chain, error) {
+	return Default().Get(chainId)
+}
+
+// Lookup resolves a chain id or alias through the default registry, including disabled chains
+func Lookup(chainId string) (*Chain, bool) {
+	return Default().Lookup(chainId)
+}
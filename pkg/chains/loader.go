@@ -0,0 +1,71 @@
+package chains
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/supabase-community/supabase-go"
+	"gopkg.in/yaml.v3"
+)
+
+// Parse decodes a registry config, format is "json" or "yaml"
+func Parse(data []byte, format string) ([]Chain, error) {
+	var config Config
+	switch strings.ToLower(format) {
+	case "json":
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse chain config: %v", err)
+		}
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse chain config: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported chain config format: %s", format)
+	}
+	return config.Chains, nil
+}
+
+// LoadFile builds a registry from a .json, .yaml or .yml file
+func LoadFile(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain config: %v", err)
+	}
+
+	chains, err := Parse(data, strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return nil, err
+	}
+	registry, err := NewRegistry(chains)
+	if err != nil {
+		return nil, err
+	}
+	registry.source = "file:" + path
+	return registry, nil
+}
+
+// LoadFromDB builds a registry from the chains table (see init.sql)
+func LoadFromDB(client *supabase.Client) (*Registry, error) {
+	var rows []struct {
+		Config Chain `json:"config"`
+	}
+
+	if _, err := client.From("chains").Select("config", "", false).ExecuteTo(&rows); err != nil {
+		return nil, fmt.Errorf("failed to query chains: %v", err)
+	}
+
+	chains := make([]Chain, 0, len(rows))
+	for _, row := range rows {
+		chains = append(chains, row.Config)
+	}
+	registry, err := NewRegistry(chains)
+	if err != nil {
+		return nil, err
+	}
+	registry.source = "db"
+	return registry, nil
+}
@@ -0,0 +1,130 @@
+package chains
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Chain is a single network entry of the registry, tags match the config file and db json
+type Chain struct {
+	ID              string    `json:"id" yaml:"id"`
+	Aliases         []string  `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	VM              string    `json:"vm" yaml:"vm"`
+	Name            string    `json:"name" yaml:"name"`
+	Enabled         bool      `json:"enabled" yaml:"enabled"`
+	Domain          uint32    `json:"domain,omitempty" yaml:"domain,omitempty"`
+	EscrowTypes     []int     `json:"escrow-types,omitempty" yaml:"escrow-types,omitempty"`
+	EntrypointTypes []int     `json:"entrypoint-types,omitempty" yaml:"entrypoint-types,omitempty"`
+	Rpcs            []string  `json:"rpcs,omitempty" yaml:"rpcs,omitempty"`
+	RpcRateLimit    float64   `json:"rpc-rate-limit,omitempty" yaml:"rpc-rate-limit,omitempty"` // requests per second per rpc, 0 is unlimited
+	Explorer        string    `json:"explorer,omitempty" yaml:"explorer,omitempty"`             // block explorer base url
+	Indexer         string    `json:"indexer,omitempty" yaml:"indexer,omitempty"`               // indexer http api, tonapi v2 for tvm chains
+	TypedData       bool      `json:"typed-data,omitempty" yaml:"typed-data,omitempty"`         // the escrow (evm) or proxy wallet (tvm) checks eip-712 digests
+	Contracts       Contracts `json:"contracts" yaml:"contracts"`
+}
+
+// Contracts is the address book for a chain, unused entries are left empty
+type Contracts struct {
+	Entrypoint            string `json:"entrypoint,omitempty" yaml:"entrypoint,omitempty"`
+	EntrypointSimulations string `json:"entrypoint-simulations,omitempty" yaml:"entrypoint-simulations,omitempty"`
+	SimpleAccountFactory  string `json:"simple-account-factory,omitempty" yaml:"simple-account-factory,omitempty"`
+	SimpleAccount         string `json:"simple-account,omitempty" yaml:"simple-account,omitempty"`
+	Multicall             string `json:"multicall,omitempty" yaml:"multicall,omitempty"`
+	HyperlaneMailbox      string `json:"hyperlane-mailbox,omitempty" yaml:"hyperlane-mailbox,omitempty"`
+	HyperlaneIgp          string `json:"hyperlane-igp,omitempty" yaml:"hyperlane-igp,omitempty"`
+	Paymaster             string `json:"paymaster,omitempty" yaml:"paymaster,omitempty"`
+	Escrow                string `json:"escrow,omitempty" yaml:"escrow,omitempty"`
+	EscrowFactory         string `json:"escrow-factory,omitempty" yaml:"escrow-factory,omitempty"`
+	SwapRouter            string `json:"swap-router,omitempty" yaml:"swap-router,omitempty"`       // stonfi v2 router
+	SwapProxyTon          string `json:"swap-proxy-ton,omitempty" yaml:"swap-proxy-ton,omitempty"` // stonfi v2 pton minter of the router
+}
+
+// Config is the root of a registry file
+type Config struct {
+	Chains []Chain `json:"chains" yaml:"chains"`
+}
+
+// Rpc returns the primary rpc endpoint of the chain
+func (c *Chain) Rpc() (string, error) {
+	if len(c.Rpcs) == 0 {
+		return "", fmt.Errorf("no rpc configured for chain id: %s", c.ID)
+	}
+	return c.Rpcs[0], nil
+}
+
+// TxUrl returns the explorer link of a transaction, empty when no explorer is configured
+func (c *Chain) TxUrl(hash string) string {
+	if c.Explorer == "" || hash == "" {
+		return ""
+	}
+	if c.VM == "tvm" {
+		return c.explorerUrl("/transaction/" + hash)
+	}
+	return c.explorerUrl("/tx/" + hash)
+}
+
+// AddressUrl returns the explorer link of an account, empty when no explorer is configured
+func (c *Chain) AddressUrl(addr string) string {
+	if c.Explorer == "" || addr == "" {
+		return ""
+	}
+	if c.VM == "tvm" {
+		return c.explorerUrl("/" + addr)
+	}
+	return c.explorerUrl("/address/" + addr)
+}
+
+// explorerUrl appends the path to the explorer, before its query when it selects a cluster (?cluster=devnet)
+func (c *Chain) explorerUrl(path string) string {
+	base, query, found := strings.Cut(c.Explorer, "?")
+	url := strings.TrimRight(base, "/") + path
+	if found {
+		url += "?" + query
+	}
+	return url
+}
+
+// Types returns the tx types enabled for "escrow" or "entrypoint"
+func (c *Chain) Types(partialType string) ([]int, error) {
+	switch partialType {
+	case "escrow":
+		return c.EscrowTypes, nil
+	case "entrypoint":
+		return c.EntrypointTypes, nil
+	default:
+		return nil, fmt.Errorf("partialType not set")
+	}
+}
+
+// HasType checks if the tx type is enabled for the partial type
+func (c *Chain) HasType(partialType string, txType int) bool {
+	types, err := c.Types(partialType)
+	if err != nil {
+		return false
+	}
+	for _, t := range types {
+		if t == txType {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Chain) validate() error {
+	if c.ID == "" {
+		return fmt.Errorf("chain entry missing id")
+	}
+	switch c.VM {
+	case "evm", "tvm", "svm":
+	default:
+		return fmt.Errorf("chain %s has unknown vm: %s", c.ID, c.VM)
+	}
+	if c.Domain == 0 {
+		// hyperlane domains default to the chain id when it fits
+		if domain, err := strconv.ParseUint(c.ID, 10, 32); err == nil {
+			c.Domain = uint32(domain)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package chains
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Registry resolves chain ids and hex aliases to a Chain entry
+// returned chains are shared and should be treated as read only
+type Registry struct {
+	chains   map[string]*Chain
+	aliases  map[string]string
+	order    []string
+	source   string
+	loadedAt time.Time
+}
+
+func NewRegistry(chains []Chain) (*Registry, error) {
+	r := &Registry{
+		chains:   make(map[string]*Chain, len(chains)),
+		aliases:  make(map[string]string, len(chains)*2),
+		loadedAt: time.Now(),
+	}
+
+	for i := range chains {
+		chain := chains[i]
+		if err := chain.validate(); err != nil {
+			return nil, err
+		}
+		if _, found := r.chains[chain.ID]; found {
+			return nil, fmt.Errorf("duplicate chain id: %s", chain.ID)
+		}
+		r.chains[chain.ID] = &chain
+		r.order = append(r.order, chain.ID)
+
+		for _, alias := range append([]string{chain.ID}, chain.Aliases...) {
+			key := strings.ToLower(alias)
+			if existing, found := r.aliases[key]; found && existing != chain.ID {
+				return nil, fmt.Errorf("alias %s used by chains %s and %s", alias, existing, chain.ID)
+			}
+			r.aliases[key] = chain.ID
+		}
+	}
+
+	return r, nil
+}
+
+// Lookup returns the chain for an id or alias, including disabled chains
+func (r *Registry) Lookup(chainId string) (*Chain, bool) {
+	id, found := r.aliases[strings.ToLower(chainId)]
+	if !found {
+		return nil, false
+	}
+	return r.chains[id], true
+}
+
+// Get returns the chain for an id or alias, disabled chains are reported as unsupported
+func (r *Registry) Get(chainId string) (*Chain, error) {
+	chain, found := r.Lookup(chainId)
+	if !found || !chain.Enabled {
+		return nil, fmt.Errorf("unsupported chain ID: %s", chainId)
+	}
+	return chain, nil
+}
+
+// Chains returns every entry in config order
+func (r *Registry) Chains() []*Chain {
+	out := make([]*Chain, 0, len(r.order))
+	for _, id := range r.order {
+		out = append(out, r.chains[id])
+	}
+	return out
+}
+
+// Source describes where the registry was loaded from (embedded, file path or db)
+func (r *Registry) Source() string {
+	return r.source
+}
+
+func (r *Registry) LoadedAt() time.Time {
+	return r.loadedAt
+}
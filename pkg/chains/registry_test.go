@@ -0,0 +1,218 @@
+package chains
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testConfig = `{"chains": [
+	{"id": "11155111", "aliases": ["0xAA36A7"], "vm": "evm", "name": "sepolia", "enabled": true, "entrypoint-types": [0, 1],
+		"contracts": {"entrypoint": "0x317bBdFbAe7845648864348A0C304392d0F2925F"}},
+	{"id": "1667471769", "aliases": ["0x63639999"], "vm": "tvm", "name": "tonTestnet", "enabled": true, "escrow-types": [2]},
+	{"id": "84532", "vm": "evm", "name": "baseSepolia", "enabled": false, "domain": 7}
+]}`
+
+const testYaml = `chains:
+  - id: "97"
+    aliases: ["0x61"]
+    vm: evm
+    name: bscTestnet
+    enabled: true
+    typed-data: true
+    contracts:
+      paymaster: "0x01"
+`
+
+func TestNewRegistry(t *testing.T) {
+	tests := []struct {
+		name   string
+		chains []Chain
+		err    string
+	}{
+		{"valid", []Chain{{ID: "1", VM: "evm"}, {ID: "2", Aliases: []string{"0x02"}, VM: "svm"}}, ""},
+		{"missing id", []Chain{{VM: "evm"}}, "missing id"},
+		{"unknown vm", []Chain{{ID: "1", VM: "move"}}, "unknown vm"},
+		{"duplicate id", []Chain{{ID: "1", VM: "evm"}, {ID: "1", VM: "evm"}}, "duplicate chain id"},
+		{"duplicate alias", []Chain{{ID: "1", Aliases: []string{"0xA"}, VM: "evm"}, {ID: "2", Aliases: []string{"0xa"}, VM: "evm"}}, "alias 0xa used by chains 1 and 2"},
+		{"alias of another id", []Chain{{ID: "1", VM: "evm"}, {ID: "2", Aliases: []string{"1"}, VM: "evm"}}, "alias 1 used by chains 1 and 2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewRegistry(test.chains)
+			if test.err == "" && err != nil {
+				t.Fatal(err)
+			}
+			if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Fatalf("err %v, want %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	chains, err := Parse([]byte(testConfig), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := NewRegistry(chains)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		chainId string
+		id      string // empty when not found
+		enabled bool
+	}{
+		{"11155111", "11155111", true},
+		{"0xAA36A7", "11155111", true},
+		{"0xaa36a7", "11155111", true},
+		{"0X63639999", "1667471769", true},
+		{"84532", "84532", false},
+		{"0x14A34", "", false},
+		{"", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.chainId, func(t *testing.T) {
+			chain, found := registry.Lookup(test.chainId)
+			if found != (test.id != "") || (found && chain.ID != test.id) {
+				t.Fatalf("lookup %v %v", chain, found)
+			}
+			chain, err := registry.Get(test.chainId)
+			if test.enabled != (err == nil) || (err == nil && chain.ID != test.id) {
+				t.Fatalf("get %v %v", chain, err)
+			}
+		})
+	}
+
+	sepolia, _ := registry.Lookup("11155111")
+	if sepolia.Domain != 11155111 || !sepolia.HasType("entrypoint", 1) || sepolia.HasType("escrow", 0) {
+		t.Fatalf("sepolia %+v", sepolia)
+	}
+	if base, _ := registry.Lookup("84532"); base.Domain != 7 {
+		t.Fatalf("configured domain %d", base.Domain)
+	}
+	var order []string
+	for _, chain := range registry.Chains() {
+		order = append(order, chain.ID)
+	}
+	if strings.Join(order, ",") != "11155111,1667471769,84532" {
+		t.Fatalf("order %v", order)
+	}
+}
+
+func TestParse(t *testing.T) {
+	chains, err := Parse([]byte(testYaml), "YML")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chains) != 1 || chains[0].ID != "97" || chains[0].Aliases[0] != "0x61" || chains[0].Contracts.Paymaster != "0x01" || !chains[0].TypedData {
+		t.Fatalf("chains %+v", chains)
+	}
+	if _, err := Parse([]byte(testConfig), "toml"); err == nil {
+		t.Fatal("expected an error for toml")
+	}
+	if _, err := Parse([]byte("{"), "json"); err == nil {
+		t.Fatal("expected an error for broken json")
+	}
+	embedded := mustParseEmbedded(t)
+	if _, err := NewRegistry(embedded); err != nil {
+		t.Fatalf("embedded chains.json: %v", err)
+	}
+	// no deployed escrow or proxy wallet checks eip-712 digests yet
+	for _, chain := range embedded {
+		if chain.TypedData {
+			t.Errorf("chain %s has typed-data set", chain.ID)
+		}
+	}
+}
+
+func mustParseEmbedded(t *testing.T) []Chain {
+	chains, err := Parse(defaultConfig, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chains
+}
+
+// useConfigFile points the default registry at a config file for the test
+func useConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "chains.json")
+	writeConfig(t, path, content)
+	t.Setenv("CHAIN_CONFIG_SOURCE", "")
+	t.Setenv("CHAIN_CONFIG_PATH", path)
+	Default()
+	t.Cleanup(func() { defaultRegistry.Store(mustLoadEmbedded()) })
+	return path
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloadKeepsRegistryOnError(t *testing.T) {
+	path := useConfigFile(t, testConfig)
+	registry, err := Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registry.Source() != "file:"+path || Default() != registry {
+		t.Fatalf("registry from %s", registry.Source())
+	}
+
+	for name, content := range map[string]string{
+		"broken json":  `{"chains": [`,
+		"invalid vm":   `{"chains": [{"id": "1", "vm": "move"}]}`,
+		"duplicate id": `{"chains": [{"id": "1", "vm": "evm"}, {"id": "1", "vm": "evm"}]}`,
+	} {
+		writeConfig(t, path, content)
+		kept, err := Reload()
+		if err == nil {
+			t.Fatalf("%s: reloaded", name)
+		}
+		if kept != registry || Default() != registry {
+			t.Fatalf("%s: the previous registry was replaced", name)
+		}
+		if _, err := Get("0xaa36a7"); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestWatchFileDebounce(t *testing.T) {
+	path := useConfigFile(t, testConfig)
+	if _, err := Reload(); err != nil {
+		t.Fatal(err)
+	}
+	stop, err := WatchFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	// a burst of writes ends in one reload of the last content
+	start := Default()
+	for i := 0; i < 5; i++ {
+		writeConfig(t, path, strings.Replace(testConfig, `"name": "sepolia"`, `"name": "sepolia`+string(rune('0'+i))+`"`, 1))
+		time.Sleep(20 * time.Millisecond)
+	}
+	seen := map[*Registry]struct{}{}
+	deadline := time.Now().Add(watchDebounce * 4)
+	for time.Now().Before(deadline) {
+		if registry := Default(); registry != start {
+			seen[registry] = struct{}{}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(seen) != 1 {
+		t.Fatalf("%d reloads, want 1", len(seen))
+	}
+	if chain, _ := Lookup("11155111"); chain.Name != "sepolia4" {
+		t.Fatalf("name %s", chain.Name)
+	}
+}
@@ -0,0 +1,68 @@
+package chains
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reload events from editors and config management come in bursts
+const watchDebounce = 500 * time.Millisecond
+
+// WatchConfig reloads the default registry whenever CHAIN_CONFIG_PATH changes
+// returns a nil stop func when no file source is configured
+func WatchConfig() (func(), error) {
+	path := os.Getenv("CHAIN_CONFIG_PATH")
+	if path == "" || os.Getenv("CHAIN_CONFIG_SOURCE") == "db" {
+		return nil, nil
+	}
+	return WatchFile(path)
+}
+
+// WatchFile reloads the default registry when the file at path is written or replaced
+func WatchFile(path string) (func(), error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// watch the directory so atomic replaces (write tmp + rename) are picked up
+	target := filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(target)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	go func() {
+		var timer *time.Timer
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != target || event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(watchDebounce, func() {
+					if _, err := Reload(); err != nil {
+						log.Printf("\nFailed to reload chain config %s: %v", target, err)
+					}
+				})
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("\nChain config watcher error: %v", err)
+			}
+		}
+	}()
+
+	return func() { watcher.Close() }, nil
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"log"
 
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
 	"github.com/crosscall-labs/crosschain-api/pkg/utils"
 	"github.com/supabase-community/supabase-go"
 )
@@ -10,7 +11,7 @@ import (
 func LogError(client *supabase.Client, err error, message string, context interface{}) error {
 	logData := map[string]interface{}{
 		"log_level": "ERROR",
-		"error":     err,
+		"error":     apierr.From(err), // bare errors would be stored as {}
 		"message":   message,
 		"context":   context,
 	}
@@ -24,12 +25,9 @@ func LogError(client *supabase.Client, err error, message string, context interf
 }
 
 func LogPanic(client *supabase.Client, message string, context interface{}) error {
-	panicErr := utils.Error{
-		Code:    500,
-		Message: "Server exited",
-		Details: message,
-		Origin:  utils.GetOrigin(),
-	}
+	panicErr := apierr.New(apierr.Internal, message)
+	panicErr.Message = "Server exited"
+	panicErr.Origin = utils.GetOrigin()
 
 	if err := LogError(client, panicErr, message, context); err != nil {
 		log.Printf("Failed to log panic: %v", err)
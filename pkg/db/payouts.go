@@ -0,0 +1,112 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	PayoutPending    = "pending"
+	PayoutDispatched = "dispatched"
+	PayoutGasUnpaid  = "gas-unpaid" // dispatched or dispatching, the igp payment may be retried
+	PayoutFailed     = "failed"     // nothing was sent, the payout may be retried
+)
+
+// PayoutPendingTimeout is how long a payout stays pending before a retry takes it over, well
+// above the receipt waits of the dispatch and the igp payment
+const PayoutPendingTimeout = 10 * time.Minute
+
+// EscrowPayout is a row of escrow_payouts, trace_id is the destination handleOps tx hash
+type EscrowPayout struct {
+	TraceId       string     `json:"trace_id"`
+	OriginId      string     `json:"origin_id"`
+	DestinationId string     `json:"destination_id"`
+	Signer        string     `json:"signer"`
+	AssetAddress  string     `json:"asset_address"`
+	AssetAmount   string     `json:"asset_amount"`
+	UserOpHash    string     `json:"userop_hash,omitempty"`
+	Status        string     `json:"status"`
+	DispatchTx    string     `json:"dispatch_tx,omitempty"`
+	MessageId     string     `json:"message_id,omitempty"`
+	GasTx         string     `json:"gas_tx,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// ReserveEscrowPayout claims trace_id before anything is sent, the primary key makes
+// the claim atomic. Returns false with the existing row when the payout is already
+// claimed. Failed and gas-unpaid payouts are claimed again, as are pending payouts left
+// by a crash, the returned row keeps the updated_at of the claimed row.
+func ReserveEscrowPayout(client *supabase.Client, payout EscrowPayout) (EscrowPayout, bool, error) {
+	payout.Status = PayoutPending
+	_, _, insertErr := client.From("escrow_payouts").Insert(payout, false, "", "minimal", "").Execute()
+	if insertErr == nil {
+		return payout, true, nil
+	}
+
+	existing, err := GetEscrowPayout(client, payout.TraceId)
+	if err != nil {
+		return EscrowPayout{}, false, fmt.Errorf("failed to reserve payout: %v", insertErr)
+	}
+
+	update := map[string]interface{}{"status": PayoutPending, "error": "", "updated_at": time.Now().UTC()}
+	switch existing.Status {
+	case PayoutFailed:
+		// a failed dispatch never reached the chain
+		update["dispatch_tx"] = nil
+		existing.DispatchTx = ""
+	case PayoutGasUnpaid:
+	case PayoutPending:
+		if existing.UpdatedAt == nil || time.Since(*existing.UpdatedAt) < PayoutPendingTimeout {
+			return existing, false, nil
+		}
+	default:
+		return existing, false, nil
+	}
+
+	// only one retry wins the update of the claimed row
+	query := client.From("escrow_payouts").
+		Update(update, "representation", "").
+		Eq("trace_id", payout.TraceId).
+		Eq("status", existing.Status)
+	if existing.Status == PayoutPending {
+		query = query.Lt("updated_at", time.Now().UTC().Add(-PayoutPendingTimeout).Format(time.RFC3339))
+	}
+	data, _, err := query.Execute()
+	if err != nil {
+		return EscrowPayout{}, false, err
+	}
+	var updated []EscrowPayout
+	if err := json.Unmarshal(data, &updated); err != nil || len(updated) == 0 {
+		return existing, false, nil
+	}
+	existing.Status = PayoutPending
+	existing.Error = ""
+	return existing, true, nil
+}
+
+func GetEscrowPayout(client *supabase.Client, traceId string) (EscrowPayout, error) {
+	data, _, err := client.From("escrow_payouts").Select("*", "", false).Eq("trace_id", traceId).Execute()
+	if err != nil {
+		return EscrowPayout{}, err
+	}
+	var payouts []EscrowPayout
+	if err := json.Unmarshal(data, &payouts); err != nil {
+		return EscrowPayout{}, err
+	}
+	if len(payouts) == 0 {
+		return EscrowPayout{}, fmt.Errorf("payout not found: %s", traceId)
+	}
+	return payouts[0], nil
+}
+
+// UpdateEscrowPayout stores the outcome of a reserved payout
+func UpdateEscrowPayout(client *supabase.Client, payout EscrowPayout) error {
+	now := time.Now().UTC()
+	payout.UpdatedAt = &now
+	_, _, err := client.From("escrow_payouts").Update(payout, "minimal", "").Eq("trace_id", payout.TraceId).Execute()
+	return err
+}
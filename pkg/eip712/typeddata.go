@@ -0,0 +1,172 @@
+// Package eip712 builds the crosscall typed data users sign instead of a raw hash
+package eip712
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	DomainName    = "crosscall"
+	DomainVersion = "1"
+)
+
+// Scheme is how a user signed a message
+type Scheme string
+
+const (
+	SchemePersonalSign Scheme = "personal_sign"        // raw hash behind the eth signed message prefix
+	SchemeTypedData    Scheme = "eth_signTypedData_v4" // eip-712 digest of the typed data
+)
+
+var executionDataType = []apitypes.Type{
+	{Name: "regime", Type: "uint8"},
+	{Name: "destination", Type: "string"},
+	{Name: "value", Type: "uint256"},
+	{Name: "bodyHash", Type: "bytes32"},
+}
+
+var extendLockType = []apitypes.Type{
+	{Name: "sec", Type: "uint256"},
+	{Name: "asset", Type: "address"},
+	{Name: "nonce", Type: "uint256"},
+	{Name: "chainId", Type: "uint256"},
+}
+
+// Domain is the crosscall domain of an evm contract on the chain of the signer, wallets refuse a
+// chain id they are not on and the contract keeps a signature from being replayed on another one
+func Domain(chainId *big.Int, verifyingContract common.Address) apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:              DomainName,
+		Version:           DomainVersion,
+		ChainId:           (*math.HexOrDecimal256)(chainId),
+		VerifyingContract: verifyingContract.Hex(),
+	}
+}
+
+// TonDomain is the crosscall domain of a ton contract, verifyingContract only holds evm addresses
+// so the 32 byte account id of the contract is the salt
+func TonDomain(chainId *big.Int, account []byte) apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:    DomainName,
+		Version: DomainVersion,
+		ChainId: (*math.HexOrDecimal256)(chainId),
+		Salt:    "0x" + hex.EncodeToString(account),
+	}
+}
+
+// domainType lists the fields domain sets, in the order of the eip-712 spec
+func domainType(domain apitypes.TypedDataDomain) []apitypes.Type {
+	fields := []apitypes.Type{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+	}
+	if domain.VerifyingContract != "" {
+		fields = append(fields, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	if domain.Salt != "" {
+		fields = append(fields, apitypes.Type{Name: "salt", Type: "bytes32"})
+	}
+	return fields
+}
+
+// ExecutionData describes the proxy wallet execution data, the body is shown by its cell hash
+func ExecutionData(domain apitypes.TypedDataDomain, regime uint8, destination string, value uint64, bodyHash []byte) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain":  domainType(domain),
+			"ExecutionData": executionDataType,
+		},
+		PrimaryType: "ExecutionData",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"regime":      fmt.Sprint(regime),
+			"destination": destination,
+			"value":       fmt.Sprint(value),
+			"bodyHash":    "0x" + hex.EncodeToString(bodyHash),
+		},
+	}
+}
+
+// ExtendLock describes the escrow extendLock arguments, the same fields EncodeAndHash packs
+func ExtendLock(domain apitypes.TypedDataDomain, sec *big.Int, asset common.Address, nonce *big.Int) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": domainType(domain),
+			"ExtendLock":   extendLockType,
+		},
+		PrimaryType: "ExtendLock",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"sec":     sec.String(),
+			"asset":   asset.Hex(),
+			"nonce":   nonce.String(),
+			"chainId": (*big.Int)(domain.ChainId).String(),
+		},
+	}
+}
+
+// Hash returns the eip-712 digest a wallet signs for typedData
+func Hash(typedData apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %v", err)
+	}
+	return digest, nil
+}
+
+// PersonalHash returns the digest personal_sign signs for a 32 byte hash
+func PersonalHash(hash []byte) []byte {
+	return crypto.Keccak256(append(append([]byte{}, utils.EthDomainHeader...), hash...))
+}
+
+// Recover returns the signer of digest, v may be 0/1 or 27/28
+func Recover(digest []byte, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+	sig := append([]byte{}, signature...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	publicKey, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover public key: %v", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// VerifySigner returns the scheme signer used for the personal_sign hash or the typed data,
+// the scheme is empty when neither recovers signer. typedData may be nil to allow personal_sign only.
+func VerifySigner(signer common.Address, signature []byte, hash []byte, typedData *apitypes.TypedData) (Scheme, error) {
+	recovered, err := Recover(PersonalHash(hash), signature)
+	if err != nil {
+		return "", err
+	}
+	if recovered == signer {
+		return SchemePersonalSign, nil
+	}
+	if typedData == nil {
+		return "", nil
+	}
+
+	digest, err := Hash(*typedData)
+	if err != nil {
+		return "", err
+	}
+	if recovered, err = Recover(digest, signature); err != nil {
+		return "", err
+	}
+	if recovered == signer {
+		return SchemeTypedData, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,118 @@
+package eip712
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestDomainBindsContract(t *testing.T) {
+	chainId := big.NewInt(11155111)
+	escrow := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	other := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	sec, nonce := big.NewInt(3600), big.NewInt(4)
+
+	hash := func(domainChain *big.Int, contract common.Address) []byte {
+		digest, err := Hash(ExtendLock(Domain(domainChain, contract), sec, common.Address{}, nonce))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return digest
+	}
+	digest := hash(chainId, escrow)
+	if bytes.Equal(digest, hash(chainId, other)) {
+		t.Fatal("the digest does not depend on the verifying contract")
+	}
+	if bytes.Equal(digest, hash(big.NewInt(1), escrow)) {
+		t.Fatal("the digest does not depend on the chain id")
+	}
+
+	typedData := ExtendLock(Domain(chainId, escrow), sec, common.Address{}, nonce)
+	if fields := typedData.Types["EIP712Domain"]; len(fields) != 4 || fields[3].Name != "verifyingContract" {
+		t.Fatalf("domain type %+v", fields)
+	}
+	if typedData.Message["chainId"] != chainId.String() {
+		t.Fatalf("message chain id %v", typedData.Message["chainId"])
+	}
+}
+
+func TestTonDomainSalt(t *testing.T) {
+	chainId := big.NewInt(11155111)
+	bodyHash := crypto.Keccak256([]byte("body"))
+
+	hash := func(account []byte) []byte {
+		digest, err := Hash(ExecutionData(TonDomain(chainId, account), 1, "EQ-destination", 100, bodyHash))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return digest
+	}
+	proxy := bytes.Repeat([]byte{0xaa}, 32)
+	if bytes.Equal(hash(proxy), hash(bytes.Repeat([]byte{0xbb}, 32))) {
+		t.Fatal("the digest does not depend on the proxy wallet")
+	}
+
+	fields := ExecutionData(TonDomain(chainId, proxy), 1, "", 0, bodyHash).Types["EIP712Domain"]
+	if len(fields) != 4 || fields[3].Name != "salt" || fields[3].Type != "bytes32" {
+		t.Fatalf("domain type %+v", fields)
+	}
+}
+
+func TestVerifySigner(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+	hash := crypto.Keccak256([]byte("execution data"))
+	typedData := ExecutionData(TonDomain(big.NewInt(1), bytes.Repeat([]byte{1}, 32)), 0, "EQ-destination", 1, hash)
+	digest, err := Hash(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(digest []byte) []byte {
+		signature, err := crypto.Sign(digest, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature[64] += 27
+		return signature
+	}
+	personal, typed := sign(PersonalHash(hash)), sign(digest)
+
+	tests := []struct {
+		name      string
+		signer    common.Address
+		signature []byte
+		typed     bool
+		want      Scheme
+	}{
+		{"personal_sign", signer, personal, true, SchemePersonalSign},
+		{"typed data", signer, typed, true, SchemeTypedData},
+		{"typed data without typed data", signer, typed, false, ""},
+		{"other signer", common.HexToAddress("0x01"), personal, true, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var data = &typedData
+			if !test.typed {
+				data = nil
+			}
+			scheme, err := VerifySigner(test.signer, test.signature, hash, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if scheme != test.want {
+				t.Fatalf("scheme %q, want %q", scheme, test.want)
+			}
+		})
+	}
+
+	if _, err := VerifySigner(signer, personal[:64], hash, nil); err == nil {
+		t.Fatal("expected an error for a 64 byte signature")
+	}
+}
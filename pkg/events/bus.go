@@ -0,0 +1,213 @@
+// Package events is an in-process bus of transaction lifecycle events, the evm relay and the tvm
+// tracker publish to it and the frontend subscribes by userOpHash, message hash or signer.
+package events
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xssnick/tonutils-go/address"
+)
+
+// Kind is a lifecycle step of a crosschain request
+type Kind string
+
+const (
+	EscrowLocked        Kind = "escrow-locked"
+	ProxyDeployed       Kind = "proxy-deployed"
+	DestinationExecuted Kind = "destination-executed"
+	PayoutSent          Kind = "payout-sent"
+	Failed              Kind = "failed"
+)
+
+const (
+	subscriberBuffer = 32
+	recentSize       = 512              // events kept for subscribers that connect late
+	recentMaxAge     = 15 * time.Minute // older events are not replayed
+)
+
+type Event struct {
+	Epoch  string    `json:"epoch"` // bus the seq belongs to, seqs restart with every process
+	Seq    uint64    `json:"seq"`
+	Kind   Kind      `json:"kind"`
+	Keys   []string  `json:"keys"` // normalized userOpHash, message hash and signers of the request
+	VM     string    `json:"vm,omitempty"`
+	TxHash string    `json:"tx-hash,omitempty"`
+	Reason string    `json:"reason,omitempty"` // set for failed
+	At     time.Time `json:"at"`
+}
+
+// Id is the sse event id, epoch-seq
+func (e Event) Id() string {
+	return fmt.Sprintf("%s-%d", e.Epoch, e.Seq)
+}
+
+// Terminal reports whether no further events follow for the request
+func (e Event) Terminal() bool {
+	return e.Kind == Failed || e.Kind == PayoutSent
+}
+
+type subscription struct {
+	keys map[string]struct{}
+	ch   chan Event
+}
+
+func (s *subscription) matches(e Event) bool {
+	for _, key := range e.Keys {
+		if _, ok := s.keys[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Bus only lives in this process, subscribers of another instance don't see its events
+type Bus struct {
+	mu     sync.Mutex
+	epoch  string
+	seq    uint64
+	subs   map[*subscription]struct{}
+	recent []Event
+	closed bool
+}
+
+func NewBus() *Bus {
+	return &Bus{epoch: strconv.FormatInt(time.Now().UnixNano(), 36), subs: map[*subscription]struct{}{}}
+}
+
+var Default = NewBus()
+
+func Publish(e Event) { Default.Publish(e) }
+
+func Subscribe(after uint64, keys ...string) (<-chan Event, func()) {
+	return Default.Subscribe(after, keys...)
+}
+
+func After(lastId string) uint64 { return Default.After(lastId) }
+
+// Publish never blocks, a subscriber with a full buffer misses the event
+func (b *Bus) Publish(e Event) {
+	keys := make([]string, 0, len(e.Keys))
+	for _, key := range e.Keys {
+		if key = NormalizeKey(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	e.Keys = keys
+	if e.At.IsZero() {
+		e.At = time.Now().UTC()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	e.Epoch, e.Seq = b.epoch, b.seq
+	b.recent = append(b.recent, e)
+	if len(b.recent) > recentSize {
+		b.recent = b.recent[len(b.recent)-recentSize:]
+	}
+
+	for sub := range b.subs {
+		if !sub.matches(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe delivers the events of any of keys, recent events after seq are replayed first.
+// cancel closes the channel.
+func (b *Bus) Subscribe(after uint64, keys ...string) (<-chan Event, func()) {
+	sub := &subscription{keys: map[string]struct{}{}, ch: make(chan Event, subscriberBuffer)}
+	for _, key := range keys {
+		if key = NormalizeKey(key); key != "" {
+			sub.keys[key] = struct{}{}
+		}
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	cutoff := time.Now().Add(-recentMaxAge)
+	for _, e := range b.recent {
+		if e.Seq > after && e.At.After(cutoff) && sub.matches(e) {
+			select {
+			case sub.ch <- e:
+			default:
+			}
+		}
+	}
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subs[sub]; ok {
+				delete(b.subs, sub)
+				close(sub.ch)
+			}
+		})
+	}
+}
+
+// After returns the seq to subscribe after for the Last-Event-ID of a reconnect. An id of another
+// epoch was sent before a restart, its seq means nothing here and every recent event is replayed.
+func (b *Bus) After(lastId string) uint64 {
+	epoch, seq, ok := strings.Cut(lastId, "-")
+	if !ok || epoch != b.epoch {
+		return 0
+	}
+	after, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return after
+}
+
+// Close ends every subscription, streams return once their channel is closed. Events published
+// afterwards are kept for replay but not delivered.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// NormalizeKey lowercases hex keys without 0x and converts ton addresses to their raw form, so
+// bounceable, non-bounceable and raw addresses subscribe to the same events
+func NormalizeKey(key string) string {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return ""
+	}
+	addr, err := address.ParseAddr(key)
+	if err != nil {
+		addr, err = address.ParseRawAddr(key)
+	}
+	if err == nil {
+		return fmt.Sprintf("%d:%x", addr.Workchain(), addr.Data())
+	}
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(key, "0x"), "0X")
+	if _, err := hex.DecodeString(trimmed); err == nil {
+		return strings.ToLower(trimmed)
+	}
+	return key
+}
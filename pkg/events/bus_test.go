@@ -0,0 +1,84 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	bus := NewBus()
+	bus.Publish(Event{Kind: EscrowLocked, Keys: []string{"0xAB"}})
+	bus.Publish(Event{Kind: EscrowLocked, Keys: []string{"cd"}})
+
+	ch, cancel := bus.Subscribe(0, "ab")
+	defer cancel()
+	if e := <-ch; e.Seq != 1 || e.Keys[0] != "ab" {
+		t.Fatalf("replayed %+v", e)
+	}
+	bus.Publish(Event{Kind: PayoutSent, Keys: []string{"0xab"}})
+	select {
+	case e := <-ch:
+		if e.Seq != 3 || !e.Terminal() {
+			t.Fatalf("published %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("event not delivered")
+	}
+
+	late, cancelLate := bus.Subscribe(1, "ab")
+	defer cancelLate()
+	if e := <-late; e.Seq != 3 {
+		t.Fatalf("replayed after 1 %+v", e)
+	}
+}
+
+func TestClose(t *testing.T) {
+	bus := NewBus()
+	ch, cancel := bus.Subscribe(0, "ab")
+	bus.Close()
+	if _, ok := <-ch; ok {
+		t.Fatal("subscription not closed")
+	}
+	// cancel after close must not close the channel twice
+	cancel()
+
+	bus.Publish(Event{Kind: EscrowLocked, Keys: []string{"ab"}})
+	after, _ := bus.Subscribe(0, "ab")
+	if _, ok := <-after; ok {
+		t.Fatal("subscription of a closed bus is open")
+	}
+}
+
+func TestAfter(t *testing.T) {
+	bus := NewBus()
+	bus.Publish(Event{Kind: EscrowLocked, Keys: []string{"ab"}})
+	bus.Publish(Event{Kind: PayoutSent, Keys: []string{"ab"}})
+	ch, cancel := bus.Subscribe(0, "ab")
+	defer cancel()
+	first := <-ch
+	if first.Id() != bus.epoch+"-1" {
+		t.Fatalf("id %s", first.Id())
+	}
+
+	restarted := NewBus()
+	restarted.epoch = bus.epoch + "0"
+	tests := []struct {
+		name   string
+		bus    *Bus
+		lastId string
+		want   uint64
+	}{
+		{"same epoch", bus, first.Id(), 1},
+		{"restarted", restarted, first.Id(), 0},
+		{"seq only", bus, "1", 0},
+		{"empty", bus, "", 0},
+		{"bad seq", bus, bus.epoch + "-x", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if after := test.bus.After(test.lastId); after != test.want {
+				t.Fatalf("after %d, want %d", after, test.want)
+			}
+		})
+	}
+}
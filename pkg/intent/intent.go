@@ -0,0 +1,105 @@
+package intent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// State is a step of a crosschain request lifecycle
+type State string
+
+const (
+	Quoted       State = "quoted"        // unsigned message returned to the user
+	Signed       State = "signed"        // signed op received by the relay
+	EscrowLocked State = "escrow-locked" // origin escrow verified to cover the op
+	Executed     State = "executed"      // destination op executed
+	PaidOut      State = "paid-out"      // escrow payout dispatched on the origin chain
+	Expired      State = "expired"       // quote or lock ran out before execution
+	Failed       State = "failed"
+)
+
+// QuoteTTL is how long a quote stays valid before it is expired
+const QuoteTTL = 30 * time.Minute
+
+var transitions = map[State][]State{
+	Quoted:       {Signed, Expired, Failed},
+	Signed:       {EscrowLocked, Executed, Expired, Failed}, // tvm requests execute without an escrow lock
+	EscrowLocked: {Executed, Expired, Failed},
+	Executed:     {PaidOut, Failed},
+	PaidOut:      {},
+	Expired:      {},
+	Failed:       {},
+}
+
+var (
+	ErrNotFound          = errors.New("intent not found")
+	ErrInvalidTransition = errors.New("invalid intent transition")
+	ErrConflict          = errors.New("intent was updated concurrently")
+	ErrInvalidSigner     = errors.New("signer is not an evm or ton address")
+)
+
+// Intent is a crosschain request keyed by its userOpHash (evm) or message hash (tvm)
+type Intent struct {
+	Id                string       `json:"id"`
+	VM                string       `json:"vm"` // vm of the destination chain
+	State             State        `json:"state"`
+	OriginId          string       `json:"origin_id"`
+	DestinationId     string       `json:"destination_id"`
+	OriginSigner      string       `json:"origin_signer"`
+	DestinationSigner string       `json:"destination_signer"`
+	AssetAddress      string       `json:"asset_address,omitempty"`
+	AssetAmount       string       `json:"asset_amount,omitempty"`
+	TxHash            string       `json:"tx_hash,omitempty"`   // destination execution
+	PayoutTx          string       `json:"payout_tx,omitempty"` // origin payout dispatch
+	Error             string       `json:"error,omitempty"`
+	ExpiresAt         *time.Time   `json:"expires_at,omitempty"`
+	CreatedAt         *time.Time   `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time   `json:"updated_at,omitempty"`
+	History           []Transition `json:"history"`
+}
+
+// Transition is an entry of the intent history
+type Transition struct {
+	From   State     `json:"from,omitempty"`
+	To     State     `json:"to"`
+	At     time.Time `json:"at"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// CanTransition reports whether an intent may move from one state to another
+func CanTransition(from, to State) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Terminal reports whether no transition leaves the state
+func (s State) Terminal() bool {
+	return len(transitions[s]) == 0
+}
+
+// Valid reports whether the state is known
+func (s State) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// advance moves the intent to the next state and records it in the history
+func (i *Intent) advance(to State, detail string, now time.Time) error {
+	if !CanTransition(i.State, to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, i.State, to)
+	}
+	i.History = append(i.History, Transition{From: i.State, To: to, At: now, Detail: detail})
+	i.State = to
+	i.UpdatedAt = &now
+	return nil
+}
+
+// IsExpired reports whether a non terminal intent ran past its expiry
+func (i *Intent) IsExpired(now time.Time) bool {
+	return i.ExpiresAt != nil && now.After(*i.ExpiresAt) && CanTransition(i.State, Expired)
+}
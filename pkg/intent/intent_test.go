@@ -0,0 +1,104 @@
+package intent
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var states = []State{Quoted, Signed, EscrowLocked, Executed, PaidOut, Expired, Failed}
+
+func TestCanTransition(t *testing.T) {
+	allowed := map[State]map[State]bool{
+		Quoted:       {Signed: true, Expired: true, Failed: true},
+		Signed:       {EscrowLocked: true, Executed: true, Expired: true, Failed: true},
+		EscrowLocked: {Executed: true, Expired: true, Failed: true},
+		Executed:     {PaidOut: true, Failed: true},
+	}
+	for _, from := range states {
+		for _, to := range states {
+			if got := CanTransition(from, to); got != allowed[from][to] {
+				t.Errorf("%s -> %s: %v, want %v", from, to, got, allowed[from][to])
+			}
+		}
+		if CanTransition(from, "unknown") || CanTransition("unknown", from) {
+			t.Errorf("%s transitions with an unknown state", from)
+		}
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	terminal := map[State]bool{PaidOut: true, Expired: true, Failed: true}
+	for _, state := range states {
+		if state.Terminal() != terminal[state] {
+			t.Errorf("%s: terminal %v", state, state.Terminal())
+		}
+		if !state.Valid() {
+			t.Errorf("%s is not valid", state)
+		}
+	}
+	if State("unknown").Valid() {
+		t.Error("unknown state is valid")
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(time.Minute)
+
+	for _, from := range states {
+		for _, to := range states {
+			i := Intent{State: from, UpdatedAt: &created, History: []Transition{{To: from, At: created}}}
+			err := i.advance(to, "detail", now)
+
+			if !CanTransition(from, to) {
+				if !errors.Is(err, ErrInvalidTransition) {
+					t.Errorf("%s -> %s: err %v", from, to, err)
+				}
+				if i.State != from || len(i.History) != 1 || !i.UpdatedAt.Equal(created) {
+					t.Errorf("%s -> %s: rejected transition changed the intent", from, to)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s -> %s: %v", from, to, err)
+				continue
+			}
+			want := Transition{From: from, To: to, At: now, Detail: "detail"}
+			if i.State != to || len(i.History) != 2 || i.History[1] != want || !i.UpdatedAt.Equal(now) {
+				t.Errorf("%s -> %s: intent %+v", from, to, i)
+			}
+		}
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		state     State
+		expiresAt *time.Time
+		now       time.Time
+		want      bool
+	}{
+		{"before expiry", Quoted, &expiresAt, expiresAt.Add(-time.Second), false},
+		{"at expiry", Quoted, &expiresAt, expiresAt, false},
+		{"after expiry", Quoted, &expiresAt, expiresAt.Add(time.Nanosecond), true},
+		{"signed", Signed, &expiresAt, expiresAt.Add(time.Hour), true},
+		{"escrow locked", EscrowLocked, &expiresAt, expiresAt.Add(time.Hour), true},
+		{"no expiry", Quoted, nil, expiresAt.Add(time.Hour), false},
+		{"executed", Executed, &expiresAt, expiresAt.Add(time.Hour), false},
+		{"paid out", PaidOut, &expiresAt, expiresAt.Add(time.Hour), false},
+		{"expired", Expired, &expiresAt, expiresAt.Add(time.Hour), false},
+		{"failed", Failed, &expiresAt, expiresAt.Add(time.Hour), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := Intent{State: test.state, ExpiresAt: test.expiresAt}
+			if got := i.IsExpired(test.now); got != test.want {
+				t.Fatalf("expired %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package intent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+	"github.com/xssnick/tonutils-go/address"
+)
+
+const table = "intents"
+
+// Create stores a new quoted intent, quoting the same id again refreshes the quote
+// as long as it was not signed yet
+func Create(client *supabase.Client, intent Intent) (Intent, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(QuoteTTL)
+	normalizeSigners(&intent)
+	intent.State = Quoted
+	intent.ExpiresAt = &expiresAt
+	intent.CreatedAt = &now
+	intent.UpdatedAt = &now
+	intent.History = []Transition{{To: Quoted, At: now}}
+
+	_, _, insertErr := client.From(table).Insert(intent, false, "", "minimal", "").Execute()
+	if insertErr == nil {
+		return intent, nil
+	}
+
+	existing, err := Get(client, intent.Id)
+	if err != nil {
+		return Intent{}, fmt.Errorf("failed to create intent: %v", insertErr)
+	}
+	if existing.State != Quoted {
+		return existing, nil
+	}
+
+	existing.ExpiresAt = &expiresAt
+	existing.UpdatedAt = &now
+	_, _, err = client.From(table).
+		Update(map[string]interface{}{"expires_at": expiresAt, "updated_at": now}, "minimal", "").
+		Eq("id", intent.Id).
+		Eq("state", string(Quoted)).
+		Execute()
+	if err != nil {
+		return Intent{}, err
+	}
+	return existing, nil
+}
+
+func Get(client *supabase.Client, id string) (Intent, error) {
+	data, _, err := client.From(table).Select("*", "", false).Eq("id", id).Execute()
+	if err != nil {
+		return Intent{}, err
+	}
+	var intents []Intent
+	if err := json.Unmarshal(data, &intents); err != nil {
+		return Intent{}, err
+	}
+	if len(intents) == 0 {
+		return Intent{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return intents[0], nil
+}
+
+// Advance moves the stored intent to the next state, update may set the fields that
+// come with the new state. The write only applies if the state was not changed since
+// it was read, otherwise ErrConflict is returned.
+func Advance(client *supabase.Client, id string, to State, detail string, update func(*Intent)) (Intent, error) {
+	current, err := Get(client, id)
+	if err != nil {
+		return Intent{}, err
+	}
+	from := current.State
+
+	if err := current.advance(to, detail, time.Now().UTC()); err != nil {
+		return Intent{}, err
+	}
+	if update != nil {
+		update(&current)
+	}
+
+	data, _, err := client.From(table).
+		Update(current, "representation", "").
+		Eq("id", id).
+		Eq("state", string(from)).
+		Execute()
+	if err != nil {
+		return Intent{}, err
+	}
+	var updated []Intent
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return Intent{}, err
+	}
+	if len(updated) == 0 {
+		return Intent{}, fmt.Errorf("%w: %s", ErrConflict, id)
+	}
+	return updated[0], nil
+}
+
+// Fail moves the intent to failed with the reason
+func Fail(client *supabase.Client, id string, reason string) (Intent, error) {
+	return Advance(client, id, Failed, reason, func(i *Intent) {
+		i.Error = reason
+	})
+}
+
+// ExpireStale moves every quoted or signed intent past its expiry to expired,
+// returns the number of expired intents
+func ExpireStale(client *supabase.Client) (int, error) {
+	now := time.Now().UTC()
+	data, _, err := client.From(table).
+		Select("*", "", false).
+		In("state", []string{string(Quoted), string(Signed)}).
+		Lt("expires_at", now.Format(time.RFC3339)).
+		Execute()
+	if err != nil {
+		return 0, err
+	}
+	var stale []Intent
+	if err := json.Unmarshal(data, &stale); err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, intent := range stale {
+		if !intent.IsExpired(now) {
+			continue
+		}
+		if _, err := Advance(client, intent.Id, Expired, "quote expired", nil); err != nil {
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// ListBySigner returns the intents where signer is the origin or destination signer, newest
+// first, along with the total number of matching intents
+func ListBySigner(client *supabase.Client, signer string, offset, limit int) ([]Intent, int64, error) {
+	// the signer goes into a postgrest filter, only a parsed address may reach it
+	normalized, err := NormalizeSigner(signer)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, count, err := client.From(table).
+		Select("*", "exact", false).
+		Or(fmt.Sprintf("origin_signer.eq.%s,destination_signer.eq.%s", normalized, normalized), "").
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Range(offset, offset+limit-1, "").
+		Execute()
+	if err != nil {
+		return nil, 0, err
+	}
+	var intents []Intent
+	if err := json.Unmarshal(data, &intents); err != nil {
+		return nil, 0, err
+	}
+	return intents, count, nil
+}
+
+// NormalizeSigner returns the stored form of a signer, checksummed evm addresses and raw
+// workchain:hex ton addresses
+func NormalizeSigner(signer string) (string, error) {
+	signer = strings.TrimSpace(signer)
+	if common.IsHexAddress(signer) {
+		return common.HexToAddress(signer).Hex(), nil
+	}
+	addr, err := address.ParseAddr(signer)
+	if err != nil {
+		addr, err = address.ParseRawAddr(signer)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSigner, signer)
+	}
+	return fmt.Sprintf("%d:%x", addr.Workchain(), addr.Data()), nil
+}
+
+// normalizeSigners keeps signers that don't parse as they are, svm keys are stored verbatim
+func normalizeSigners(intent *Intent) {
+	if normalized, err := NormalizeSigner(intent.OriginSigner); err == nil {
+		intent.OriginSigner = normalized
+	}
+	if normalized, err := NormalizeSigner(intent.DestinationSigner); err == nil {
+		intent.DestinationSigner = normalized
+	}
+}
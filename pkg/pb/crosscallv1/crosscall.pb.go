@@ -0,0 +1,2614 @@
+// crosscall api over grpc, served next to the http api by api/grpc.
+//
+// Request fields carry the json_name of the matching http query param, the server binds them with the
+// same param structs as the http routes. Responses are the json the http api returns, as a Struct.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: crosscall/v1/crosscall.proto
+
+package crosscallv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *structpb.Struct `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Response) GetResult() *structpb.Struct {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type MessageHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxType          string `protobuf:"bytes,1,opt,name=tx_type,json=txtype,proto3" json:"tx_type,omitempty"`
+	FromChainName   string `protobuf:"bytes,2,opt,name=from_chain_name,json=fname,proto3" json:"from_chain_name,omitempty"`
+	FromChainType   string `protobuf:"bytes,3,opt,name=from_chain_type,json=ftype,proto3" json:"from_chain_type,omitempty"`
+	FromChainId     string `protobuf:"bytes,4,opt,name=from_chain_id,json=fid,proto3" json:"from_chain_id,omitempty"`
+	FromChainSigner string `protobuf:"bytes,5,opt,name=from_chain_signer,json=fsigner,proto3" json:"from_chain_signer,omitempty"`
+	ToChainName     string `protobuf:"bytes,6,opt,name=to_chain_name,json=tname,proto3" json:"to_chain_name,omitempty"`
+	ToChainType     string `protobuf:"bytes,7,opt,name=to_chain_type,json=ttype,proto3" json:"to_chain_type,omitempty"`
+	ToChainId       string `protobuf:"bytes,8,opt,name=to_chain_id,json=tid,proto3" json:"to_chain_id,omitempty"`
+	ToChainSigner   string `protobuf:"bytes,9,opt,name=to_chain_signer,json=tsigner,proto3" json:"to_chain_signer,omitempty"`
+	Testnet         string `protobuf:"bytes,10,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	ExtraData       string `protobuf:"bytes,11,opt,name=extra_data,json=extra-data,proto3" json:"extra_data,omitempty"`
+}
+
+func (x *MessageHeader) Reset() {
+	*x = MessageHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageHeader) ProtoMessage() {}
+
+func (x *MessageHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageHeader.ProtoReflect.Descriptor instead.
+func (*MessageHeader) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MessageHeader) GetTxType() string {
+	if x != nil {
+		return x.TxType
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetFromChainName() string {
+	if x != nil {
+		return x.FromChainName
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetFromChainType() string {
+	if x != nil {
+		return x.FromChainType
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetFromChainId() string {
+	if x != nil {
+		return x.FromChainId
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetFromChainSigner() string {
+	if x != nil {
+		return x.FromChainSigner
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetToChainName() string {
+	if x != nil {
+		return x.ToChainName
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetToChainType() string {
+	if x != nil {
+		return x.ToChainType
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetToChainId() string {
+	if x != nil {
+		return x.ToChainId
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetToChainSigner() string {
+	if x != nil {
+		return x.ToChainSigner
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetTestnet() string {
+	if x != nil {
+		return x.Testnet
+	}
+	return ""
+}
+
+func (x *MessageHeader) GetExtraData() string {
+	if x != nil {
+		return x.ExtraData
+	}
+	return ""
+}
+
+type PartialHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxType      string `protobuf:"bytes,1,opt,name=tx_type,json=txtype,proto3" json:"tx_type,omitempty"`
+	ChainName   string `protobuf:"bytes,2,opt,name=chain_name,json=name,proto3" json:"chain_name,omitempty"`
+	ChainType   string `protobuf:"bytes,3,opt,name=chain_type,json=type,proto3" json:"chain_type,omitempty"`
+	ChainId     string `protobuf:"bytes,4,opt,name=chain_id,json=id,proto3" json:"chain_id,omitempty"`
+	ChainSigner string `protobuf:"bytes,5,opt,name=chain_signer,json=signer,proto3" json:"chain_signer,omitempty"`
+}
+
+func (x *PartialHeader) Reset() {
+	*x = PartialHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PartialHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartialHeader) ProtoMessage() {}
+
+func (x *PartialHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartialHeader.ProtoReflect.Descriptor instead.
+func (*PartialHeader) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PartialHeader) GetTxType() string {
+	if x != nil {
+		return x.TxType
+	}
+	return ""
+}
+
+func (x *PartialHeader) GetChainName() string {
+	if x != nil {
+		return x.ChainName
+	}
+	return ""
+}
+
+func (x *PartialHeader) GetChainType() string {
+	if x != nil {
+		return x.ChainType
+	}
+	return ""
+}
+
+func (x *PartialHeader) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *PartialHeader) GetChainSigner() string {
+	if x != nil {
+		return x.ChainSigner
+	}
+	return ""
+}
+
+type VersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VersionRequest) Reset() {
+	*x = VersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionRequest) ProtoMessage() {}
+
+func (x *VersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionRequest.ProtoReflect.Descriptor instead.
+func (*VersionRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{3}
+}
+
+type UserInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EvmSigner string `protobuf:"bytes,1,opt,name=evm_signer,json=evm-signer,proto3" json:"evm_signer,omitempty"`
+	TvmSigner string `protobuf:"bytes,2,opt,name=tvm_signer,json=tvm-signer,proto3" json:"tvm_signer,omitempty"`
+	Assets    string `protobuf:"bytes,3,opt,name=assets,proto3" json:"assets,omitempty"`   // comma separated evm escrow assets
+	Jettons   string `protobuf:"bytes,4,opt,name=jettons,proto3" json:"jettons,omitempty"` // comma separated jetton masters
+}
+
+func (x *UserInfoRequest) Reset() {
+	*x = UserInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserInfoRequest) ProtoMessage() {}
+
+func (x *UserInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserInfoRequest.ProtoReflect.Descriptor instead.
+func (*UserInfoRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UserInfoRequest) GetEvmSigner() string {
+	if x != nil {
+		return x.EvmSigner
+	}
+	return ""
+}
+
+func (x *UserInfoRequest) GetTvmSigner() string {
+	if x != nil {
+		return x.TvmSigner
+	}
+	return ""
+}
+
+func (x *UserInfoRequest) GetAssets() string {
+	if x != nil {
+		return x.Assets
+	}
+	return ""
+}
+
+func (x *UserInfoRequest) GetJettons() string {
+	if x != nil {
+		return x.Jettons
+	}
+	return ""
+}
+
+type AssetInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainId        string `protobuf:"bytes,1,opt,name=chain_id,json=chain-id,proto3" json:"chain_id,omitempty"`
+	Vm             string `protobuf:"bytes,2,opt,name=vm,proto3" json:"vm,omitempty"`
+	UserAddress    string `protobuf:"bytes,3,opt,name=user_address,json=user-address,proto3" json:"user_address,omitempty"`
+	EscrowAddress  string `protobuf:"bytes,4,opt,name=escrow_address,json=escrow-address,proto3" json:"escrow_address,omitempty"`
+	AccountAddress string `protobuf:"bytes,5,opt,name=account_address,json=account-address,proto3" json:"account_address,omitempty"`
+	AssetAddress   string `protobuf:"bytes,6,opt,name=asset_address,json=asset-address,proto3" json:"asset_address,omitempty"`
+}
+
+func (x *AssetInfoRequest) Reset() {
+	*x = AssetInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetInfoRequest) ProtoMessage() {}
+
+func (x *AssetInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetInfoRequest.ProtoReflect.Descriptor instead.
+func (*AssetInfoRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AssetInfoRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *AssetInfoRequest) GetVm() string {
+	if x != nil {
+		return x.Vm
+	}
+	return ""
+}
+
+func (x *AssetInfoRequest) GetUserAddress() string {
+	if x != nil {
+		return x.UserAddress
+	}
+	return ""
+}
+
+func (x *AssetInfoRequest) GetEscrowAddress() string {
+	if x != nil {
+		return x.EscrowAddress
+	}
+	return ""
+}
+
+func (x *AssetInfoRequest) GetAccountAddress() string {
+	if x != nil {
+		return x.AccountAddress
+	}
+	return ""
+}
+
+func (x *AssetInfoRequest) GetAssetAddress() string {
+	if x != nil {
+		return x.AssetAddress
+	}
+	return ""
+}
+
+type AssetMintRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainId      string `protobuf:"bytes,1,opt,name=chain_id,json=chain-id,proto3" json:"chain_id,omitempty"`
+	Vm           string `protobuf:"bytes,2,opt,name=vm,proto3" json:"vm,omitempty"`
+	UserAddress  string `protobuf:"bytes,3,opt,name=user_address,json=user-address,proto3" json:"user_address,omitempty"`
+	AssetAddress string `protobuf:"bytes,4,opt,name=asset_address,json=asset-address,proto3" json:"asset_address,omitempty"`
+	AssetAmount  string `protobuf:"bytes,5,opt,name=asset_amount,json=asset-amount,proto3" json:"asset_amount,omitempty"`
+}
+
+func (x *AssetMintRequest) Reset() {
+	*x = AssetMintRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetMintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetMintRequest) ProtoMessage() {}
+
+func (x *AssetMintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetMintRequest.ProtoReflect.Descriptor instead.
+func (*AssetMintRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AssetMintRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *AssetMintRequest) GetVm() string {
+	if x != nil {
+		return x.Vm
+	}
+	return ""
+}
+
+func (x *AssetMintRequest) GetUserAddress() string {
+	if x != nil {
+		return x.UserAddress
+	}
+	return ""
+}
+
+func (x *AssetMintRequest) GetAssetAddress() string {
+	if x != nil {
+		return x.AssetAddress
+	}
+	return ""
+}
+
+func (x *AssetMintRequest) GetAssetAmount() string {
+	if x != nil {
+		return x.AssetAmount
+	}
+	return ""
+}
+
+type UnsignedMessageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header      *MessageHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Target      string         `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Value       string         `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Payload     string         `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	EscrowValue string         `protobuf:"bytes,5,opt,name=escrow_value,json=escrow-value,proto3" json:"escrow_value,omitempty"` // nano deposited and locked by a tvm origin
+}
+
+func (x *UnsignedMessageRequest) Reset() {
+	*x = UnsignedMessageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsignedMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsignedMessageRequest) ProtoMessage() {}
+
+func (x *UnsignedMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsignedMessageRequest.ProtoReflect.Descriptor instead.
+func (*UnsignedMessageRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UnsignedMessageRequest) GetHeader() *MessageHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UnsignedMessageRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *UnsignedMessageRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *UnsignedMessageRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *UnsignedMessageRequest) GetEscrowValue() string {
+	if x != nil {
+		return x.EscrowValue
+	}
+	return ""
+}
+
+type UnsignedEvmEntryPointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header  *MessageHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Target  string         `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`   // comma separated for executeBatch
+	Value   string         `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`     // wei, comma separated
+	Payload string         `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"` // hex calldata, comma separated
+	Salt    string         `protobuf:"bytes,5,opt,name=salt,proto3" json:"salt,omitempty"`
+	Asset   string         `protobuf:"bytes,6,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount  string         `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *UnsignedEvmEntryPointRequest) Reset() {
+	*x = UnsignedEvmEntryPointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsignedEvmEntryPointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsignedEvmEntryPointRequest) ProtoMessage() {}
+
+func (x *UnsignedEvmEntryPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsignedEvmEntryPointRequest.ProtoReflect.Descriptor instead.
+func (*UnsignedEvmEntryPointRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UnsignedEvmEntryPointRequest) GetHeader() *MessageHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UnsignedEvmEntryPointRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *UnsignedEvmEntryPointRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *UnsignedEvmEntryPointRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *UnsignedEvmEntryPointRequest) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *UnsignedEvmEntryPointRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *UnsignedEvmEntryPointRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+type UnsignedSvmEntryPointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header    *MessageHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Payload   string         `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Accounts  string         `protobuf:"bytes,3,opt,name=accounts,proto3" json:"accounts,omitempty"`
+	Nonce     string         `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Signature string         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Relayer   string         `protobuf:"bytes,6,opt,name=relayer,proto3" json:"relayer,omitempty"`
+}
+
+func (x *UnsignedSvmEntryPointRequest) Reset() {
+	*x = UnsignedSvmEntryPointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsignedSvmEntryPointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsignedSvmEntryPointRequest) ProtoMessage() {}
+
+func (x *UnsignedSvmEntryPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsignedSvmEntryPointRequest.ProtoReflect.Descriptor instead.
+func (*UnsignedSvmEntryPointRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UnsignedSvmEntryPointRequest) GetHeader() *MessageHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UnsignedSvmEntryPointRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *UnsignedSvmEntryPointRequest) GetAccounts() string {
+	if x != nil {
+		return x.Accounts
+	}
+	return ""
+}
+
+func (x *UnsignedSvmEntryPointRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *UnsignedSvmEntryPointRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *UnsignedSvmEntryPointRequest) GetRelayer() string {
+	if x != nil {
+		return x.Relayer
+	}
+	return ""
+}
+
+type TvmExecutionData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Regime      string `protobuf:"bytes,1,opt,name=regime,json=exe-regime,proto3" json:"regime,omitempty"`
+	Destination string `protobuf:"bytes,2,opt,name=destination,json=exe-target,proto3" json:"destination,omitempty"`
+	Value       string `protobuf:"bytes,3,opt,name=value,json=exe-value,proto3" json:"value,omitempty"`
+	Body        string `protobuf:"bytes,4,opt,name=body,json=exe-body,proto3" json:"body,omitempty"` // hex boc
+}
+
+func (x *TvmExecutionData) Reset() {
+	*x = TvmExecutionData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TvmExecutionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TvmExecutionData) ProtoMessage() {}
+
+func (x *TvmExecutionData) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TvmExecutionData.ProtoReflect.Descriptor instead.
+func (*TvmExecutionData) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TvmExecutionData) GetRegime() string {
+	if x != nil {
+		return x.Regime
+	}
+	return ""
+}
+
+func (x *TvmExecutionData) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *TvmExecutionData) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TvmExecutionData) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type TvmProxyHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nonce           string `protobuf:"bytes,1,opt,name=nonce,json=p-nonce,proto3" json:"nonce,omitempty"`
+	EntryPoint      string `protobuf:"bytes,2,opt,name=entry_point,json=p-entrypoint,proto3" json:"entry_point,omitempty"`
+	PayeeAddress    string `protobuf:"bytes,3,opt,name=payee_address,json=p-payee,proto3" json:"payee_address,omitempty"`
+	OwnerEvmAddress string `protobuf:"bytes,4,opt,name=owner_evm_address,json=p-evm,proto3" json:"owner_evm_address,omitempty"`
+	OwnerTvmAddress string `protobuf:"bytes,5,opt,name=owner_tvm_address,json=p-tvm,proto3" json:"owner_tvm_address,omitempty"`
+}
+
+func (x *TvmProxyHeader) Reset() {
+	*x = TvmProxyHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TvmProxyHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TvmProxyHeader) ProtoMessage() {}
+
+func (x *TvmProxyHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TvmProxyHeader.ProtoReflect.Descriptor instead.
+func (*TvmProxyHeader) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TvmProxyHeader) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *TvmProxyHeader) GetEntryPoint() string {
+	if x != nil {
+		return x.EntryPoint
+	}
+	return ""
+}
+
+func (x *TvmProxyHeader) GetPayeeAddress() string {
+	if x != nil {
+		return x.PayeeAddress
+	}
+	return ""
+}
+
+func (x *TvmProxyHeader) GetOwnerEvmAddress() string {
+	if x != nil {
+		return x.OwnerEvmAddress
+	}
+	return ""
+}
+
+func (x *TvmProxyHeader) GetOwnerTvmAddress() string {
+	if x != nil {
+		return x.OwnerTvmAddress
+	}
+	return ""
+}
+
+type TvmProxy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header          *TvmProxyHeader   `protobuf:"bytes,1,opt,name=header,json=p-header,proto3" json:"header,omitempty"`
+	ExecutionData   *TvmExecutionData `protobuf:"bytes,2,opt,name=execution_data,json=p-exe,proto3" json:"execution_data,omitempty"`
+	WithProxyInit   string            `protobuf:"bytes,3,opt,name=with_proxy_init,json=p-init,proto3" json:"with_proxy_init,omitempty"`
+	ProxyWalletCode string            `protobuf:"bytes,4,opt,name=proxy_wallet_code,json=p-code,proto3" json:"proxy_wallet_code,omitempty"`
+	WorkChain       string            `protobuf:"bytes,5,opt,name=work_chain,json=p-workchain,proto3" json:"work_chain,omitempty"`
+}
+
+func (x *TvmProxy) Reset() {
+	*x = TvmProxy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TvmProxy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TvmProxy) ProtoMessage() {}
+
+func (x *TvmProxy) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TvmProxy.ProtoReflect.Descriptor instead.
+func (*TvmProxy) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TvmProxy) GetHeader() *TvmProxyHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *TvmProxy) GetExecutionData() *TvmExecutionData {
+	if x != nil {
+		return x.ExecutionData
+	}
+	return nil
+}
+
+func (x *TvmProxy) GetWithProxyInit() string {
+	if x != nil {
+		return x.WithProxyInit
+	}
+	return ""
+}
+
+func (x *TvmProxy) GetProxyWalletCode() string {
+	if x != nil {
+		return x.ProxyWalletCode
+	}
+	return ""
+}
+
+func (x *TvmProxy) GetWorkChain() string {
+	if x != nil {
+		return x.WorkChain
+	}
+	return ""
+}
+
+type UnsignedTvmEntryPointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header *MessageHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Proxy  *TvmProxy      `protobuf:"bytes,2,opt,name=proxy,proto3" json:"proxy,omitempty"`
+}
+
+func (x *UnsignedTvmEntryPointRequest) Reset() {
+	*x = UnsignedTvmEntryPointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsignedTvmEntryPointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsignedTvmEntryPointRequest) ProtoMessage() {}
+
+func (x *UnsignedTvmEntryPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsignedTvmEntryPointRequest.ProtoReflect.Descriptor instead.
+func (*UnsignedTvmEntryPointRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnsignedTvmEntryPointRequest) GetHeader() *MessageHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UnsignedTvmEntryPointRequest) GetProxy() *TvmProxy {
+	if x != nil {
+		return x.Proxy
+	}
+	return nil
+}
+
+type TvmSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	V       string `protobuf:"bytes,1,opt,name=v,json=sig-v,proto3" json:"v,omitempty"`
+	R       string `protobuf:"bytes,2,opt,name=r,json=sig-r,proto3" json:"r,omitempty"`
+	S       string `protobuf:"bytes,3,opt,name=s,json=sig-s,proto3" json:"s,omitempty"`
+	ChainId string `protobuf:"bytes,4,opt,name=chain_id,json=sig-chain-id,proto3" json:"chain_id,omitempty"` // evm chain of the eip-712 domain
+}
+
+func (x *TvmSignature) Reset() {
+	*x = TvmSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TvmSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TvmSignature) ProtoMessage() {}
+
+func (x *TvmSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TvmSignature.ProtoReflect.Descriptor instead.
+func (*TvmSignature) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TvmSignature) GetV() string {
+	if x != nil {
+		return x.V
+	}
+	return ""
+}
+
+func (x *TvmSignature) GetR() string {
+	if x != nil {
+		return x.R
+	}
+	return ""
+}
+
+func (x *TvmSignature) GetS() string {
+	if x != nil {
+		return x.S
+	}
+	return ""
+}
+
+func (x *TvmSignature) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+type TvmSignedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId   string            `protobuf:"bytes,1,opt,name=query_id,json=msg-query-id,proto3" json:"query_id,omitempty"`
+	Signature *TvmSignature     `protobuf:"bytes,2,opt,name=signature,json=msg-signature,proto3" json:"signature,omitempty"`
+	Data      *TvmExecutionData `protobuf:"bytes,3,opt,name=data,json=msg-data,proto3" json:"data,omitempty"`
+}
+
+func (x *TvmSignedMessage) Reset() {
+	*x = TvmSignedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TvmSignedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TvmSignedMessage) ProtoMessage() {}
+
+func (x *TvmSignedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TvmSignedMessage.ProtoReflect.Descriptor instead.
+func (*TvmSignedMessage) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TvmSignedMessage) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *TvmSignedMessage) GetSignature() *TvmSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *TvmSignedMessage) GetData() *TvmExecutionData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SignedTvmEntryPointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EvmAddress   string            `protobuf:"bytes,1,opt,name=evm_address,json=evm-address,proto3" json:"evm_address,omitempty"`
+	TvmAddress   string            `protobuf:"bytes,2,opt,name=tvm_address,json=tvm-address,proto3" json:"tvm_address,omitempty"`
+	AssetAddress string            `protobuf:"bytes,3,opt,name=asset_address,json=asset-address,proto3" json:"asset_address,omitempty"`
+	AssetAmount  string            `protobuf:"bytes,4,opt,name=asset_amount,json=asset-amount,proto3" json:"asset_amount,omitempty"`
+	Message      *TvmSignedMessage `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *SignedTvmEntryPointRequest) Reset() {
+	*x = SignedTvmEntryPointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignedTvmEntryPointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedTvmEntryPointRequest) ProtoMessage() {}
+
+func (x *SignedTvmEntryPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedTvmEntryPointRequest.ProtoReflect.Descriptor instead.
+func (*SignedTvmEntryPointRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SignedTvmEntryPointRequest) GetEvmAddress() string {
+	if x != nil {
+		return x.EvmAddress
+	}
+	return ""
+}
+
+func (x *SignedTvmEntryPointRequest) GetTvmAddress() string {
+	if x != nil {
+		return x.TvmAddress
+	}
+	return ""
+}
+
+func (x *SignedTvmEntryPointRequest) GetAssetAddress() string {
+	if x != nil {
+		return x.AssetAddress
+	}
+	return ""
+}
+
+func (x *SignedTvmEntryPointRequest) GetAssetAmount() string {
+	if x != nil {
+		return x.AssetAmount
+	}
+	return ""
+}
+
+func (x *SignedTvmEntryPointRequest) GetMessage() *TvmSignedMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type UnsignedEvmEscrowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header *PartialHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Amount string         `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"` // gwei
+}
+
+func (x *UnsignedEvmEscrowRequest) Reset() {
+	*x = UnsignedEvmEscrowRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsignedEvmEscrowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsignedEvmEscrowRequest) ProtoMessage() {}
+
+func (x *UnsignedEvmEscrowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsignedEvmEscrowRequest.ProtoReflect.Descriptor instead.
+func (*UnsignedEvmEscrowRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnsignedEvmEscrowRequest) GetHeader() *PartialHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UnsignedEvmEscrowRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+type UnsignedSvmEscrowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header      *PartialHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Asset       string         `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount      string         `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	LockSeconds string         `protobuf:"bytes,4,opt,name=lock_seconds,json=lock-seconds,proto3" json:"lock_seconds,omitempty"`
+}
+
+func (x *UnsignedSvmEscrowRequest) Reset() {
+	*x = UnsignedSvmEscrowRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsignedSvmEscrowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsignedSvmEscrowRequest) ProtoMessage() {}
+
+func (x *UnsignedSvmEscrowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsignedSvmEscrowRequest.ProtoReflect.Descriptor instead.
+func (*UnsignedSvmEscrowRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UnsignedSvmEscrowRequest) GetHeader() *PartialHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UnsignedSvmEscrowRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *UnsignedSvmEscrowRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *UnsignedSvmEscrowRequest) GetLockSeconds() string {
+	if x != nil {
+		return x.LockSeconds
+	}
+	return ""
+}
+
+type TvmEscrowLock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SignerAddress string `protobuf:"bytes,1,opt,name=signer_address,json=signer-address,proto3" json:"signer_address,omitempty"`
+	AdminAddress  string `protobuf:"bytes,2,opt,name=admin_address,json=admin-address,proto3" json:"admin_address,omitempty"`
+	PayeeAddress  string `protobuf:"bytes,3,opt,name=payee_address,json=payee-address,proto3" json:"payee_address,omitempty"`
+	Id            string `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	Value         string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	LockHash      string `protobuf:"bytes,6,opt,name=lock_hash,json=lock-hash,proto3" json:"lock_hash,omitempty"`
+	LockSigner    string `protobuf:"bytes,7,opt,name=lock_signer,json=lock-signer,proto3" json:"lock_signer,omitempty"`
+	LockSignature string `protobuf:"bytes,8,opt,name=lock_signature,json=lock-signature,proto3" json:"lock_signature,omitempty"` // personal_sign of lock_hash by lock_signer
+}
+
+func (x *TvmEscrowLock) Reset() {
+	*x = TvmEscrowLock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TvmEscrowLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TvmEscrowLock) ProtoMessage() {}
+
+func (x *TvmEscrowLock) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TvmEscrowLock.ProtoReflect.Descriptor instead.
+func (*TvmEscrowLock) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TvmEscrowLock) GetSignerAddress() string {
+	if x != nil {
+		return x.SignerAddress
+	}
+	return ""
+}
+
+func (x *TvmEscrowLock) GetAdminAddress() string {
+	if x != nil {
+		return x.AdminAddress
+	}
+	return ""
+}
+
+func (x *TvmEscrowLock) GetPayeeAddress() string {
+	if x != nil {
+		return x.PayeeAddress
+	}
+	return ""
+}
+
+func (x *TvmEscrowLock) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TvmEscrowLock) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TvmEscrowLock) GetLockHash() string {
+	if x != nil {
+		return x.LockHash
+	}
+	return ""
+}
+
+func (x *TvmEscrowLock) GetLockSigner() string {
+	if x != nil {
+		return x.LockSigner
+	}
+	return ""
+}
+
+func (x *TvmEscrowLock) GetLockSignature() string {
+	if x != nil {
+		return x.LockSignature
+	}
+	return ""
+}
+
+type UnsignedTvmEscrowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header *PartialHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Escrow *TvmEscrowLock `protobuf:"bytes,2,opt,name=escrow,proto3" json:"escrow,omitempty"`
+}
+
+func (x *UnsignedTvmEscrowRequest) Reset() {
+	*x = UnsignedTvmEscrowRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsignedTvmEscrowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsignedTvmEscrowRequest) ProtoMessage() {}
+
+func (x *UnsignedTvmEscrowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsignedTvmEscrowRequest.ProtoReflect.Descriptor instead.
+func (*UnsignedTvmEscrowRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UnsignedTvmEscrowRequest) GetHeader() *PartialHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UnsignedTvmEscrowRequest) GetEscrow() *TvmEscrowLock {
+	if x != nil {
+		return x.Escrow
+	}
+	return nil
+}
+
+type WatchIntentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // userOpHash (evm) or message hash (tvm)
+}
+
+func (x *WatchIntentRequest) Reset() {
+	*x = WatchIntentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchIntentRequest) ProtoMessage() {}
+
+func (x *WatchIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchIntentRequest.ProtoReflect.Descriptor instead.
+func (*WatchIntentRequest) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchIntentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type IntentTransition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From   string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To     string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	At     string `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"` // rfc3339
+	Detail string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *IntentTransition) Reset() {
+	*x = IntentTransition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntentTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntentTransition) ProtoMessage() {}
+
+func (x *IntentTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntentTransition.ProtoReflect.Descriptor instead.
+func (*IntentTransition) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *IntentTransition) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *IntentTransition) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *IntentTransition) GetAt() string {
+	if x != nil {
+		return x.At
+	}
+	return ""
+}
+
+func (x *IntentTransition) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type Intent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Vm                string              `protobuf:"bytes,2,opt,name=vm,proto3" json:"vm,omitempty"`
+	State             string              `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	OriginId          string              `protobuf:"bytes,4,opt,name=origin_id,json=originId,proto3" json:"origin_id,omitempty"`
+	DestinationId     string              `protobuf:"bytes,5,opt,name=destination_id,json=destinationId,proto3" json:"destination_id,omitempty"`
+	OriginSigner      string              `protobuf:"bytes,6,opt,name=origin_signer,json=originSigner,proto3" json:"origin_signer,omitempty"`
+	DestinationSigner string              `protobuf:"bytes,7,opt,name=destination_signer,json=destinationSigner,proto3" json:"destination_signer,omitempty"`
+	AssetAddress      string              `protobuf:"bytes,8,opt,name=asset_address,json=assetAddress,proto3" json:"asset_address,omitempty"`
+	AssetAmount       string              `protobuf:"bytes,9,opt,name=asset_amount,json=assetAmount,proto3" json:"asset_amount,omitempty"`
+	TxHash            string              `protobuf:"bytes,10,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	PayoutTx          string              `protobuf:"bytes,11,opt,name=payout_tx,json=payoutTx,proto3" json:"payout_tx,omitempty"`
+	Error             string              `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	UpdatedAt         string              `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // rfc3339
+	History           []*IntentTransition `protobuf:"bytes,14,rep,name=history,proto3" json:"history,omitempty"`
+}
+
+func (x *Intent) Reset() {
+	*x = Intent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crosscall_v1_crosscall_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Intent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Intent) ProtoMessage() {}
+
+func (x *Intent) ProtoReflect() protoreflect.Message {
+	mi := &file_crosscall_v1_crosscall_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Intent.ProtoReflect.Descriptor instead.
+func (*Intent) Descriptor() ([]byte, []int) {
+	return file_crosscall_v1_crosscall_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Intent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Intent) GetVm() string {
+	if x != nil {
+		return x.Vm
+	}
+	return ""
+}
+
+func (x *Intent) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Intent) GetOriginId() string {
+	if x != nil {
+		return x.OriginId
+	}
+	return ""
+}
+
+func (x *Intent) GetDestinationId() string {
+	if x != nil {
+		return x.DestinationId
+	}
+	return ""
+}
+
+func (x *Intent) GetOriginSigner() string {
+	if x != nil {
+		return x.OriginSigner
+	}
+	return ""
+}
+
+func (x *Intent) GetDestinationSigner() string {
+	if x != nil {
+		return x.DestinationSigner
+	}
+	return ""
+}
+
+func (x *Intent) GetAssetAddress() string {
+	if x != nil {
+		return x.AssetAddress
+	}
+	return ""
+}
+
+func (x *Intent) GetAssetAmount() string {
+	if x != nil {
+		return x.AssetAmount
+	}
+	return ""
+}
+
+func (x *Intent) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Intent) GetPayoutTx() string {
+	if x != nil {
+		return x.PayoutTx
+	}
+	return ""
+}
+
+func (x *Intent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Intent) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Intent) GetHistory() []*IntentTransition {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+var File_crosscall_v1_crosscall_proto protoreflect.FileDescriptor
+
+var file_crosscall_v1_crosscall_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2f, 0x76, 0x31, 0x2f, 0x63,
+	0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c,
+	0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3b, 0x0a, 0x08, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0xda, 0x02, 0x0a, 0x0d, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1a, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x69, 0x64, 0x12, 0x22,
+	0x0a, 0x11, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x66, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x12, 0x1c, 0x0a, 0x0d, 0x74, 0x6f, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1c, 0x0a, 0x0d, 0x74, 0x6f, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18,
+	0x0a, 0x0b, 0x74, 0x6f, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0f, 0x74, 0x6f, 0x5f, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x74, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x65,
+	0x73, 0x74, 0x6e, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x65, 0x73,
+	0x74, 0x6e, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x61, 0x2d,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x90, 0x01, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x18, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x0a, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x0c, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x22, 0x10, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x83, 0x01, 0x0a, 0x0f, 0x55, 0x73,
+	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x65, 0x76, 0x6d, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x65, 0x76, 0x6d, 0x2d, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a,
+	0x0a, 0x74, 0x76, 0x6d, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x76, 0x6d, 0x2d, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6a, 0x65, 0x74, 0x74, 0x6f, 0x6e, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x65, 0x74, 0x74, 0x6f, 0x6e, 0x73, 0x22,
+	0xda, 0x01, 0x0a, 0x10, 0x41, 0x73, 0x73, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2d, 0x69, 0x64,
+	0x12, 0x0e, 0x0a, 0x02, 0x76, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x76, 0x6d,
+	0x12, 0x22, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x2d, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x73,
+	0x63, 0x72, 0x6f, 0x77, 0x2d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x28, 0x0a, 0x0f,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2d, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x2d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xac, 0x01, 0x0a,
+	0x10, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2d, 0x69, 0x64, 0x12, 0x0e, 0x0a,
+	0x02, 0x76, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x76, 0x6d, 0x12, 0x22, 0x0a,
+	0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x2d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2d,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x2d, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xb9, 0x01, 0x0a, 0x16,
+	0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61,
+	0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x73, 0x63, 0x72, 0x6f,
+	0x77, 0x2d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xdd, 0x01, 0x0a, 0x1c, 0x55, 0x6e, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x45, 0x76, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73,
+	0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xd7, 0x01, 0x0a, 0x1c, 0x55, 0x6e, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x53, 0x76, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73,
+	0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x22, 0x81, 0x01, 0x0a, 0x10, 0x54, 0x76, 0x6d, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x2d, 0x72, 0x65, 0x67, 0x69,
+	0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x2d, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x65, 0x2d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a,
+	0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65,
+	0x2d, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xaf, 0x01, 0x0a, 0x0e, 0x54, 0x76, 0x6d, 0x50, 0x72, 0x6f,
+	0x78, 0x79, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x2d, 0x6e, 0x6f, 0x6e, 0x63, 0x65,
+	0x12, 0x21, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x2d, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0d, 0x70, 0x61, 0x79, 0x65, 0x65, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x2d, 0x70, 0x61,
+	0x79, 0x65, 0x65, 0x12, 0x20, 0x0a, 0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x65, 0x76, 0x6d,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x70, 0x2d, 0x65, 0x76, 0x6d, 0x12, 0x20, 0x0a, 0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x74,
+	0x76, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x70, 0x2d, 0x74, 0x76, 0x6d, 0x22, 0xe6, 0x01, 0x0a, 0x08, 0x54, 0x76, 0x6d, 0x50,
+	0x72, 0x6f, 0x78, 0x79, 0x12, 0x36, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x76, 0x6d, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x52, 0x08, 0x70, 0x2d, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x0e,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x76, 0x6d, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x05, 0x70, 0x2d, 0x65, 0x78, 0x65, 0x12, 0x1f, 0x0a, 0x0f, 0x77,
+	0x69, 0x74, 0x68, 0x5f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x2d, 0x69, 0x6e, 0x69, 0x74, 0x12, 0x21, 0x0a, 0x11,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x2d, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x1f, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x2d, 0x77, 0x6f, 0x72, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x22, 0x81, 0x01, 0x0a, 0x1c, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x76, 0x6d,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x33, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c,
+	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x76, 0x6d, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x52, 0x05, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x22, 0x64, 0x0a, 0x0c, 0x54, 0x76, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x01, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x69, 0x67, 0x2d, 0x76, 0x12, 0x10, 0x0a, 0x01, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x73, 0x69, 0x67, 0x2d, 0x72, 0x12, 0x10, 0x0a, 0x01, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x69, 0x67, 0x2d, 0x73, 0x12, 0x1e, 0x0a, 0x08, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69,
+	0x67, 0x2d, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2d, 0x69, 0x64, 0x22, 0xa8, 0x01, 0x0a, 0x10, 0x54,
+	0x76, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1e, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x6d, 0x73, 0x67, 0x2d, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2d, 0x69, 0x64, 0x12,
+	0x3c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x76, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0d,
+	0x6d, 0x73, 0x67, 0x2d, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x36, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x72,
+	0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x76, 0x6d, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x73, 0x67,
+	0x2d, 0x64, 0x61, 0x74, 0x61, 0x22, 0xe4, 0x01, 0x0a, 0x1a, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x54, 0x76, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x76, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x76, 0x6d, 0x2d, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x76, 0x6d, 0x5f, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x76, 0x6d,
+	0x2d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2d, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x76, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x67, 0x0a, 0x18,
+	0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x45, 0x76, 0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73,
+	0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xa1, 0x01, 0x0a, 0x18, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x53, 0x76, 0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52,
+	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x6f, 0x63,
+	0x6b, 0x2d, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x91, 0x02, 0x0a, 0x0d, 0x54, 0x76,
+	0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x26, 0x0a, 0x0e, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2d, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x61, 0x79,
+	0x65, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x70, 0x61, 0x79, 0x65, 0x65, 0x2d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x6b, 0x2d, 0x68,
+	0x61, 0x73, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x6f, 0x63, 0x6b, 0x2d, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x2d, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x84, 0x01,
+	0x0a, 0x18, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x76, 0x6d, 0x45, 0x73, 0x63,
+	0x72, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x72, 0x6f,
+	0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61,
+	0x6c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x33, 0x0a, 0x06, 0x65, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x76, 0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x65, 0x73,
+	0x63, 0x72, 0x6f, 0x77, 0x22, 0x24, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x5e, 0x0a, 0x10, 0x49, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x74, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0xc3, 0x03, 0x0a, 0x06, 0x49,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x76, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x76, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x67,
+	0x6e, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x74,
+	0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x5f, 0x74,
+	0x78, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x54,
+	0x78, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63,
+	0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x32, 0xa9, 0x08, 0x0a, 0x10, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x1d, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x41, 0x73, 0x73,
+	0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61,
+	0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61,
+	0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43,
+	0x0a, 0x09, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x63, 0x72,
+	0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x4d, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x72,
+	0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0f, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x24, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61,
+	0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63,
+	0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x15, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x45, 0x76, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2a, 0x2e,
+	0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x45, 0x76, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x72, 0x6f, 0x73,
+	0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x5b, 0x0a, 0x15, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x53, 0x76, 0x6d,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2a, 0x2e, 0x63, 0x72, 0x6f,
+	0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x53, 0x76, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61,
+	0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b,
+	0x0a, 0x15, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x76, 0x6d, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2a, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63,
+	0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54,
+	0x76, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x13, 0x53,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x76, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x28, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x76, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63,
+	0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x11, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x45, 0x76, 0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x12, 0x26, 0x2e, 0x63, 0x72, 0x6f, 0x73,
+	0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x45, 0x76, 0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x11, 0x55, 0x6e, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x53, 0x76, 0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x12, 0x26,
+	0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x53, 0x76, 0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61,
+	0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53,
+	0x0a, 0x11, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x76, 0x6d, 0x45, 0x73, 0x63,
+	0x72, 0x6f, 0x77, 0x12, 0x26, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x76, 0x6d, 0x45, 0x73,
+	0x63, 0x72, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x72,
+	0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x12, 0x20, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x49, 0x5a, 0x47,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x6f, 0x73, 0x73,
+	0x63, 0x61, 0x6c, 0x6c, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f,
+	0x63, 0x72, 0x6f, 0x73, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x76, 0x31, 0x3b, 0x63, 0x72, 0x6f, 0x73,
+	0x73, 0x63, 0x61, 0x6c, 0x6c, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_crosscall_v1_crosscall_proto_rawDescOnce sync.Once
+	file_crosscall_v1_crosscall_proto_rawDescData = file_crosscall_v1_crosscall_proto_rawDesc
+)
+
+func file_crosscall_v1_crosscall_proto_rawDescGZIP() []byte {
+	file_crosscall_v1_crosscall_proto_rawDescOnce.Do(func() {
+		file_crosscall_v1_crosscall_proto_rawDescData = protoimpl.X.CompressGZIP(file_crosscall_v1_crosscall_proto_rawDescData)
+	})
+	return file_crosscall_v1_crosscall_proto_rawDescData
+}
+
+var file_crosscall_v1_crosscall_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_crosscall_v1_crosscall_proto_goTypes = []any{
+	(*Response)(nil),                     // 0: crosscall.v1.Response
+	(*MessageHeader)(nil),                // 1: crosscall.v1.MessageHeader
+	(*PartialHeader)(nil),                // 2: crosscall.v1.PartialHeader
+	(*VersionRequest)(nil),               // 3: crosscall.v1.VersionRequest
+	(*UserInfoRequest)(nil),              // 4: crosscall.v1.UserInfoRequest
+	(*AssetInfoRequest)(nil),             // 5: crosscall.v1.AssetInfoRequest
+	(*AssetMintRequest)(nil),             // 6: crosscall.v1.AssetMintRequest
+	(*UnsignedMessageRequest)(nil),       // 7: crosscall.v1.UnsignedMessageRequest
+	(*UnsignedEvmEntryPointRequest)(nil), // 8: crosscall.v1.UnsignedEvmEntryPointRequest
+	(*UnsignedSvmEntryPointRequest)(nil), // 9: crosscall.v1.UnsignedSvmEntryPointRequest
+	(*TvmExecutionData)(nil),             // 10: crosscall.v1.TvmExecutionData
+	(*TvmProxyHeader)(nil),               // 11: crosscall.v1.TvmProxyHeader
+	(*TvmProxy)(nil),                     // 12: crosscall.v1.TvmProxy
+	(*UnsignedTvmEntryPointRequest)(nil), // 13: crosscall.v1.UnsignedTvmEntryPointRequest
+	(*TvmSignature)(nil),                 // 14: crosscall.v1.TvmSignature
+	(*TvmSignedMessage)(nil),             // 15: crosscall.v1.TvmSignedMessage
+	(*SignedTvmEntryPointRequest)(nil),   // 16: crosscall.v1.SignedTvmEntryPointRequest
+	(*UnsignedEvmEscrowRequest)(nil),     // 17: crosscall.v1.UnsignedEvmEscrowRequest
+	(*UnsignedSvmEscrowRequest)(nil),     // 18: crosscall.v1.UnsignedSvmEscrowRequest
+	(*TvmEscrowLock)(nil),                // 19: crosscall.v1.TvmEscrowLock
+	(*UnsignedTvmEscrowRequest)(nil),     // 20: crosscall.v1.UnsignedTvmEscrowRequest
+	(*WatchIntentRequest)(nil),           // 21: crosscall.v1.WatchIntentRequest
+	(*IntentTransition)(nil),             // 22: crosscall.v1.IntentTransition
+	(*Intent)(nil),                       // 23: crosscall.v1.Intent
+	(*structpb.Struct)(nil),              // 24: google.protobuf.Struct
+}
+var file_crosscall_v1_crosscall_proto_depIdxs = []int32{
+	24, // 0: crosscall.v1.Response.result:type_name -> google.protobuf.Struct
+	1,  // 1: crosscall.v1.UnsignedMessageRequest.header:type_name -> crosscall.v1.MessageHeader
+	1,  // 2: crosscall.v1.UnsignedEvmEntryPointRequest.header:type_name -> crosscall.v1.MessageHeader
+	1,  // 3: crosscall.v1.UnsignedSvmEntryPointRequest.header:type_name -> crosscall.v1.MessageHeader
+	11, // 4: crosscall.v1.TvmProxy.header:type_name -> crosscall.v1.TvmProxyHeader
+	10, // 5: crosscall.v1.TvmProxy.execution_data:type_name -> crosscall.v1.TvmExecutionData
+	1,  // 6: crosscall.v1.UnsignedTvmEntryPointRequest.header:type_name -> crosscall.v1.MessageHeader
+	12, // 7: crosscall.v1.UnsignedTvmEntryPointRequest.proxy:type_name -> crosscall.v1.TvmProxy
+	14, // 8: crosscall.v1.TvmSignedMessage.signature:type_name -> crosscall.v1.TvmSignature
+	10, // 9: crosscall.v1.TvmSignedMessage.data:type_name -> crosscall.v1.TvmExecutionData
+	15, // 10: crosscall.v1.SignedTvmEntryPointRequest.message:type_name -> crosscall.v1.TvmSignedMessage
+	2,  // 11: crosscall.v1.UnsignedEvmEscrowRequest.header:type_name -> crosscall.v1.PartialHeader
+	2,  // 12: crosscall.v1.UnsignedSvmEscrowRequest.header:type_name -> crosscall.v1.PartialHeader
+	2,  // 13: crosscall.v1.UnsignedTvmEscrowRequest.header:type_name -> crosscall.v1.PartialHeader
+	19, // 14: crosscall.v1.UnsignedTvmEscrowRequest.escrow:type_name -> crosscall.v1.TvmEscrowLock
+	22, // 15: crosscall.v1.Intent.history:type_name -> crosscall.v1.IntentTransition
+	3,  // 16: crosscall.v1.CrosscallService.Version:input_type -> crosscall.v1.VersionRequest
+	4,  // 17: crosscall.v1.CrosscallService.UserInfo:input_type -> crosscall.v1.UserInfoRequest
+	5,  // 18: crosscall.v1.CrosscallService.AssetInfo:input_type -> crosscall.v1.AssetInfoRequest
+	6,  // 19: crosscall.v1.CrosscallService.AssetMint:input_type -> crosscall.v1.AssetMintRequest
+	7,  // 20: crosscall.v1.CrosscallService.UnsignedMessage:input_type -> crosscall.v1.UnsignedMessageRequest
+	8,  // 21: crosscall.v1.CrosscallService.UnsignedEvmEntryPoint:input_type -> crosscall.v1.UnsignedEvmEntryPointRequest
+	9,  // 22: crosscall.v1.CrosscallService.UnsignedSvmEntryPoint:input_type -> crosscall.v1.UnsignedSvmEntryPointRequest
+	13, // 23: crosscall.v1.CrosscallService.UnsignedTvmEntryPoint:input_type -> crosscall.v1.UnsignedTvmEntryPointRequest
+	16, // 24: crosscall.v1.CrosscallService.SignedTvmEntryPoint:input_type -> crosscall.v1.SignedTvmEntryPointRequest
+	17, // 25: crosscall.v1.CrosscallService.UnsignedEvmEscrow:input_type -> crosscall.v1.UnsignedEvmEscrowRequest
+	18, // 26: crosscall.v1.CrosscallService.UnsignedSvmEscrow:input_type -> crosscall.v1.UnsignedSvmEscrowRequest
+	20, // 27: crosscall.v1.CrosscallService.UnsignedTvmEscrow:input_type -> crosscall.v1.UnsignedTvmEscrowRequest
+	21, // 28: crosscall.v1.CrosscallService.WatchIntent:input_type -> crosscall.v1.WatchIntentRequest
+	0,  // 29: crosscall.v1.CrosscallService.Version:output_type -> crosscall.v1.Response
+	0,  // 30: crosscall.v1.CrosscallService.UserInfo:output_type -> crosscall.v1.Response
+	0,  // 31: crosscall.v1.CrosscallService.AssetInfo:output_type -> crosscall.v1.Response
+	0,  // 32: crosscall.v1.CrosscallService.AssetMint:output_type -> crosscall.v1.Response
+	0,  // 33: crosscall.v1.CrosscallService.UnsignedMessage:output_type -> crosscall.v1.Response
+	0,  // 34: crosscall.v1.CrosscallService.UnsignedEvmEntryPoint:output_type -> crosscall.v1.Response
+	0,  // 35: crosscall.v1.CrosscallService.UnsignedSvmEntryPoint:output_type -> crosscall.v1.Response
+	0,  // 36: crosscall.v1.CrosscallService.UnsignedTvmEntryPoint:output_type -> crosscall.v1.Response
+	0,  // 37: crosscall.v1.CrosscallService.SignedTvmEntryPoint:output_type -> crosscall.v1.Response
+	0,  // 38: crosscall.v1.CrosscallService.UnsignedEvmEscrow:output_type -> crosscall.v1.Response
+	0,  // 39: crosscall.v1.CrosscallService.UnsignedSvmEscrow:output_type -> crosscall.v1.Response
+	0,  // 40: crosscall.v1.CrosscallService.UnsignedTvmEscrow:output_type -> crosscall.v1.Response
+	23, // 41: crosscall.v1.CrosscallService.WatchIntent:output_type -> crosscall.v1.Intent
+	29, // [29:42] is the sub-list for method output_type
+	16, // [16:29] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_crosscall_v1_crosscall_proto_init() }
+func file_crosscall_v1_crosscall_proto_init() {
+	if File_crosscall_v1_crosscall_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_crosscall_v1_crosscall_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*MessageHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*PartialHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*VersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*UserInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*AssetInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*AssetMintRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*UnsignedMessageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*UnsignedEvmEntryPointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*UnsignedSvmEntryPointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*TvmExecutionData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*TvmProxyHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*TvmProxy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*UnsignedTvmEntryPointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*TvmSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*TvmSignedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*SignedTvmEntryPointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*UnsignedEvmEscrowRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*UnsignedSvmEscrowRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*TvmEscrowLock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*UnsignedTvmEscrowRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchIntentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*IntentTransition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crosscall_v1_crosscall_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*Intent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_crosscall_v1_crosscall_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_crosscall_v1_crosscall_proto_goTypes,
+		DependencyIndexes: file_crosscall_v1_crosscall_proto_depIdxs,
+		MessageInfos:      file_crosscall_v1_crosscall_proto_msgTypes,
+	}.Build()
+	File_crosscall_v1_crosscall_proto = out.File
+	file_crosscall_v1_crosscall_proto_rawDesc = nil
+	file_crosscall_v1_crosscall_proto_goTypes = nil
+	file_crosscall_v1_crosscall_proto_depIdxs = nil
+}
@@ -0,0 +1,588 @@
+// crosscall api over grpc, served next to the http api by api/grpc.
+//
+// Request fields carry the json_name of the matching http query param, the server binds them with the
+// same param structs as the http routes. Responses are the json the http api returns, as a Struct.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: crosscall/v1/crosscall.proto
+
+package crosscallv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CrosscallService_Version_FullMethodName               = "/crosscall.v1.CrosscallService/Version"
+	CrosscallService_UserInfo_FullMethodName              = "/crosscall.v1.CrosscallService/UserInfo"
+	CrosscallService_AssetInfo_FullMethodName             = "/crosscall.v1.CrosscallService/AssetInfo"
+	CrosscallService_AssetMint_FullMethodName             = "/crosscall.v1.CrosscallService/AssetMint"
+	CrosscallService_UnsignedMessage_FullMethodName       = "/crosscall.v1.CrosscallService/UnsignedMessage"
+	CrosscallService_UnsignedEvmEntryPoint_FullMethodName = "/crosscall.v1.CrosscallService/UnsignedEvmEntryPoint"
+	CrosscallService_UnsignedSvmEntryPoint_FullMethodName = "/crosscall.v1.CrosscallService/UnsignedSvmEntryPoint"
+	CrosscallService_UnsignedTvmEntryPoint_FullMethodName = "/crosscall.v1.CrosscallService/UnsignedTvmEntryPoint"
+	CrosscallService_SignedTvmEntryPoint_FullMethodName   = "/crosscall.v1.CrosscallService/SignedTvmEntryPoint"
+	CrosscallService_UnsignedEvmEscrow_FullMethodName     = "/crosscall.v1.CrosscallService/UnsignedEvmEscrow"
+	CrosscallService_UnsignedSvmEscrow_FullMethodName     = "/crosscall.v1.CrosscallService/UnsignedSvmEscrow"
+	CrosscallService_UnsignedTvmEscrow_FullMethodName     = "/crosscall.v1.CrosscallService/UnsignedTvmEscrow"
+	CrosscallService_WatchIntent_FullMethodName           = "/crosscall.v1.CrosscallService/WatchIntent"
+)
+
+// CrosscallServiceClient is the client API for CrosscallService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CrosscallServiceClient interface {
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*Response, error)
+	UserInfo(ctx context.Context, in *UserInfoRequest, opts ...grpc.CallOption) (*Response, error)
+	AssetInfo(ctx context.Context, in *AssetInfoRequest, opts ...grpc.CallOption) (*Response, error)
+	AssetMint(ctx context.Context, in *AssetMintRequest, opts ...grpc.CallOption) (*Response, error)
+	UnsignedMessage(ctx context.Context, in *UnsignedMessageRequest, opts ...grpc.CallOption) (*Response, error)
+	UnsignedEvmEntryPoint(ctx context.Context, in *UnsignedEvmEntryPointRequest, opts ...grpc.CallOption) (*Response, error)
+	UnsignedSvmEntryPoint(ctx context.Context, in *UnsignedSvmEntryPointRequest, opts ...grpc.CallOption) (*Response, error)
+	UnsignedTvmEntryPoint(ctx context.Context, in *UnsignedTvmEntryPointRequest, opts ...grpc.CallOption) (*Response, error)
+	SignedTvmEntryPoint(ctx context.Context, in *SignedTvmEntryPointRequest, opts ...grpc.CallOption) (*Response, error)
+	UnsignedEvmEscrow(ctx context.Context, in *UnsignedEvmEscrowRequest, opts ...grpc.CallOption) (*Response, error)
+	UnsignedSvmEscrow(ctx context.Context, in *UnsignedSvmEscrowRequest, opts ...grpc.CallOption) (*Response, error)
+	UnsignedTvmEscrow(ctx context.Context, in *UnsignedTvmEscrowRequest, opts ...grpc.CallOption) (*Response, error)
+	// streams the intent every time its state changes, ends after a terminal state
+	WatchIntent(ctx context.Context, in *WatchIntentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Intent], error)
+}
+
+type crosscallServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCrosscallServiceClient(cc grpc.ClientConnInterface) CrosscallServiceClient {
+	return &crosscallServiceClient{cc}
+}
+
+func (c *crosscallServiceClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_Version_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) UserInfo(ctx context.Context, in *UserInfoRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_UserInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) AssetInfo(ctx context.Context, in *AssetInfoRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_AssetInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) AssetMint(ctx context.Context, in *AssetMintRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_AssetMint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) UnsignedMessage(ctx context.Context, in *UnsignedMessageRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_UnsignedMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) UnsignedEvmEntryPoint(ctx context.Context, in *UnsignedEvmEntryPointRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_UnsignedEvmEntryPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) UnsignedSvmEntryPoint(ctx context.Context, in *UnsignedSvmEntryPointRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_UnsignedSvmEntryPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) UnsignedTvmEntryPoint(ctx context.Context, in *UnsignedTvmEntryPointRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_UnsignedTvmEntryPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) SignedTvmEntryPoint(ctx context.Context, in *SignedTvmEntryPointRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_SignedTvmEntryPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) UnsignedEvmEscrow(ctx context.Context, in *UnsignedEvmEscrowRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_UnsignedEvmEscrow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) UnsignedSvmEscrow(ctx context.Context, in *UnsignedSvmEscrowRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_UnsignedSvmEscrow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) UnsignedTvmEscrow(ctx context.Context, in *UnsignedTvmEscrowRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CrosscallService_UnsignedTvmEscrow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crosscallServiceClient) WatchIntent(ctx context.Context, in *WatchIntentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Intent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CrosscallService_ServiceDesc.Streams[0], CrosscallService_WatchIntent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchIntentRequest, Intent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CrosscallService_WatchIntentClient = grpc.ServerStreamingClient[Intent]
+
+// CrosscallServiceServer is the server API for CrosscallService service.
+// All implementations must embed UnimplementedCrosscallServiceServer
+// for forward compatibility.
+type CrosscallServiceServer interface {
+	Version(context.Context, *VersionRequest) (*Response, error)
+	UserInfo(context.Context, *UserInfoRequest) (*Response, error)
+	AssetInfo(context.Context, *AssetInfoRequest) (*Response, error)
+	AssetMint(context.Context, *AssetMintRequest) (*Response, error)
+	UnsignedMessage(context.Context, *UnsignedMessageRequest) (*Response, error)
+	UnsignedEvmEntryPoint(context.Context, *UnsignedEvmEntryPointRequest) (*Response, error)
+	UnsignedSvmEntryPoint(context.Context, *UnsignedSvmEntryPointRequest) (*Response, error)
+	UnsignedTvmEntryPoint(context.Context, *UnsignedTvmEntryPointRequest) (*Response, error)
+	SignedTvmEntryPoint(context.Context, *SignedTvmEntryPointRequest) (*Response, error)
+	UnsignedEvmEscrow(context.Context, *UnsignedEvmEscrowRequest) (*Response, error)
+	UnsignedSvmEscrow(context.Context, *UnsignedSvmEscrowRequest) (*Response, error)
+	UnsignedTvmEscrow(context.Context, *UnsignedTvmEscrowRequest) (*Response, error)
+	// streams the intent every time its state changes, ends after a terminal state
+	WatchIntent(*WatchIntentRequest, grpc.ServerStreamingServer[Intent]) error
+	mustEmbedUnimplementedCrosscallServiceServer()
+}
+
+// UnimplementedCrosscallServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCrosscallServiceServer struct{}
+
+func (UnimplementedCrosscallServiceServer) Version(context.Context, *VersionRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (UnimplementedCrosscallServiceServer) UserInfo(context.Context, *UserInfoRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserInfo not implemented")
+}
+func (UnimplementedCrosscallServiceServer) AssetInfo(context.Context, *AssetInfoRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssetInfo not implemented")
+}
+func (UnimplementedCrosscallServiceServer) AssetMint(context.Context, *AssetMintRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssetMint not implemented")
+}
+func (UnimplementedCrosscallServiceServer) UnsignedMessage(context.Context, *UnsignedMessageRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsignedMessage not implemented")
+}
+func (UnimplementedCrosscallServiceServer) UnsignedEvmEntryPoint(context.Context, *UnsignedEvmEntryPointRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsignedEvmEntryPoint not implemented")
+}
+func (UnimplementedCrosscallServiceServer) UnsignedSvmEntryPoint(context.Context, *UnsignedSvmEntryPointRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsignedSvmEntryPoint not implemented")
+}
+func (UnimplementedCrosscallServiceServer) UnsignedTvmEntryPoint(context.Context, *UnsignedTvmEntryPointRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsignedTvmEntryPoint not implemented")
+}
+func (UnimplementedCrosscallServiceServer) SignedTvmEntryPoint(context.Context, *SignedTvmEntryPointRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignedTvmEntryPoint not implemented")
+}
+func (UnimplementedCrosscallServiceServer) UnsignedEvmEscrow(context.Context, *UnsignedEvmEscrowRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsignedEvmEscrow not implemented")
+}
+func (UnimplementedCrosscallServiceServer) UnsignedSvmEscrow(context.Context, *UnsignedSvmEscrowRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsignedSvmEscrow not implemented")
+}
+func (UnimplementedCrosscallServiceServer) UnsignedTvmEscrow(context.Context, *UnsignedTvmEscrowRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsignedTvmEscrow not implemented")
+}
+func (UnimplementedCrosscallServiceServer) WatchIntent(*WatchIntentRequest, grpc.ServerStreamingServer[Intent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchIntent not implemented")
+}
+func (UnimplementedCrosscallServiceServer) mustEmbedUnimplementedCrosscallServiceServer() {}
+func (UnimplementedCrosscallServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeCrosscallServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CrosscallServiceServer will
+// result in compilation errors.
+type UnsafeCrosscallServiceServer interface {
+	mustEmbedUnimplementedCrosscallServiceServer()
+}
+
+func RegisterCrosscallServiceServer(s grpc.ServiceRegistrar, srv CrosscallServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCrosscallServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CrosscallService_ServiceDesc, srv)
+}
+
+func _CrosscallService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_Version_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_UserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).UserInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_UserInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).UserInfo(ctx, req.(*UserInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_AssetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).AssetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_AssetInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).AssetInfo(ctx, req.(*AssetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_AssetMint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssetMintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).AssetMint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_AssetMint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).AssetMint(ctx, req.(*AssetMintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_UnsignedMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsignedMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).UnsignedMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_UnsignedMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).UnsignedMessage(ctx, req.(*UnsignedMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_UnsignedEvmEntryPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsignedEvmEntryPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).UnsignedEvmEntryPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_UnsignedEvmEntryPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).UnsignedEvmEntryPoint(ctx, req.(*UnsignedEvmEntryPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_UnsignedSvmEntryPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsignedSvmEntryPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).UnsignedSvmEntryPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_UnsignedSvmEntryPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).UnsignedSvmEntryPoint(ctx, req.(*UnsignedSvmEntryPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_UnsignedTvmEntryPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsignedTvmEntryPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).UnsignedTvmEntryPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_UnsignedTvmEntryPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).UnsignedTvmEntryPoint(ctx, req.(*UnsignedTvmEntryPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_SignedTvmEntryPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedTvmEntryPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).SignedTvmEntryPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_SignedTvmEntryPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).SignedTvmEntryPoint(ctx, req.(*SignedTvmEntryPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_UnsignedEvmEscrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsignedEvmEscrowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).UnsignedEvmEscrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_UnsignedEvmEscrow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).UnsignedEvmEscrow(ctx, req.(*UnsignedEvmEscrowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_UnsignedSvmEscrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsignedSvmEscrowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).UnsignedSvmEscrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_UnsignedSvmEscrow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).UnsignedSvmEscrow(ctx, req.(*UnsignedSvmEscrowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_UnsignedTvmEscrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsignedTvmEscrowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrosscallServiceServer).UnsignedTvmEscrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrosscallService_UnsignedTvmEscrow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrosscallServiceServer).UnsignedTvmEscrow(ctx, req.(*UnsignedTvmEscrowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrosscallService_WatchIntent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchIntentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CrosscallServiceServer).WatchIntent(m, &grpc.GenericServerStream[WatchIntentRequest, Intent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CrosscallService_WatchIntentServer = grpc.ServerStreamingServer[Intent]
+
+// CrosscallService_ServiceDesc is the grpc.ServiceDesc for CrosscallService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CrosscallService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "crosscall.v1.CrosscallService",
+	HandlerType: (*CrosscallServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Version",
+			Handler:    _CrosscallService_Version_Handler,
+		},
+		{
+			MethodName: "UserInfo",
+			Handler:    _CrosscallService_UserInfo_Handler,
+		},
+		{
+			MethodName: "AssetInfo",
+			Handler:    _CrosscallService_AssetInfo_Handler,
+		},
+		{
+			MethodName: "AssetMint",
+			Handler:    _CrosscallService_AssetMint_Handler,
+		},
+		{
+			MethodName: "UnsignedMessage",
+			Handler:    _CrosscallService_UnsignedMessage_Handler,
+		},
+		{
+			MethodName: "UnsignedEvmEntryPoint",
+			Handler:    _CrosscallService_UnsignedEvmEntryPoint_Handler,
+		},
+		{
+			MethodName: "UnsignedSvmEntryPoint",
+			Handler:    _CrosscallService_UnsignedSvmEntryPoint_Handler,
+		},
+		{
+			MethodName: "UnsignedTvmEntryPoint",
+			Handler:    _CrosscallService_UnsignedTvmEntryPoint_Handler,
+		},
+		{
+			MethodName: "SignedTvmEntryPoint",
+			Handler:    _CrosscallService_SignedTvmEntryPoint_Handler,
+		},
+		{
+			MethodName: "UnsignedEvmEscrow",
+			Handler:    _CrosscallService_UnsignedEvmEscrow_Handler,
+		},
+		{
+			MethodName: "UnsignedSvmEscrow",
+			Handler:    _CrosscallService_UnsignedSvmEscrow_Handler,
+		},
+		{
+			MethodName: "UnsignedTvmEscrow",
+			Handler:    _CrosscallService_UnsignedTvmEscrow_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchIntent",
+			Handler:       _CrosscallService_WatchIntent_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "crosscall/v1/crosscall.proto",
+}
@@ -0,0 +1,118 @@
+package rpcpool
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// latency is smoothed so a single slow response doesn't flip the selection
+const latencyWeight = 0.3
+
+// every consecutive failure ranks an endpoint as if it were this much slower
+const failurePenalty = time.Second
+
+// Endpoint is a single rpc url with its circuit breaker and latency score
+type Endpoint struct {
+	URL string
+
+	mu       sync.Mutex
+	latency  time.Duration
+	failures int
+	state    breakerState
+	openedAt time.Time
+	lastErr  string
+	limiter  *tokenBucket
+}
+
+type EndpointStatus struct {
+	URL       string `json:"url"`
+	State     string `json:"state"`
+	LatencyMs int64  `json:"latency-ms"`
+	Failures  int    `json:"failures"`
+	LastError string `json:"last-error,omitempty"`
+}
+
+// available reports if the breaker lets a request through, an open breaker
+// moves to half-open after the cooldown and lets a single probe through
+func (e *Endpoint) available(now time.Time, cooldown time.Duration) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch e.state {
+	case stateOpen, stateHalfOpen:
+		// in half-open a probe is already in flight, a probe that never reported back
+		// (caller cancelled) is replaced after another cooldown
+		if now.Sub(e.openedAt) < cooldown {
+			return false
+		}
+		e.state = stateHalfOpen
+		e.openedAt = now
+		return true
+	default:
+		return true
+	}
+}
+
+func (e *Endpoint) score() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.latency + time.Duration(e.failures)*failurePenalty
+}
+
+func (e *Endpoint) recordSuccess(elapsed time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.latency == 0 {
+		e.latency = elapsed
+	} else {
+		e.latency = time.Duration(latencyWeight*float64(elapsed) + (1-latencyWeight)*float64(e.latency))
+	}
+	e.failures = 0
+	e.state = stateClosed
+	e.lastErr = ""
+}
+
+func (e *Endpoint) recordFailure(err error, threshold int, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.failures++
+	e.lastErr = err.Error()
+	if e.state == stateHalfOpen || e.failures >= threshold {
+		e.state = stateOpen
+		e.openedAt = now
+	}
+}
+
+func (e *Endpoint) Status() EndpointStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return EndpointStatus{
+		URL:       e.URL,
+		State:     e.state.String(),
+		LatencyMs: e.latency.Milliseconds(),
+		Failures:  e.failures,
+		LastError: e.lastErr,
+	}
+}
@@ -0,0 +1,115 @@
+package rpcpool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// per attempt limit, a slow endpoint is failed over instead of holding the request
+const attemptTimeout = 10 * time.Second
+
+type evmEntry struct {
+	key    string
+	pool   *Pool
+	client *ethclient.Client
+}
+
+var (
+	evmMu      sync.Mutex
+	evmClients = map[string]*evmEntry{}
+)
+
+// EvmClient returns the shared client for a chain, requests fail over between the
+// chain's rpcs, the client is rebuilt when the registry changes the rpc list
+func EvmClient(chainId string) (*ethclient.Client, error) {
+	entry, err := evmClient(chainId)
+	if err != nil {
+		return nil, err
+	}
+	return entry.client, nil
+}
+
+// EvmPool returns the endpoint pool behind EvmClient, used for status reporting
+func EvmPool(chainId string) (*Pool, error) {
+	entry, err := evmClient(chainId)
+	if err != nil {
+		return nil, err
+	}
+	return entry.pool, nil
+}
+
+func evmClient(chainId string) (*evmEntry, error) {
+	chain, err := chains.Get(chainId)
+	if err != nil {
+		return nil, err
+	}
+	if chain.VM != "evm" {
+		return nil, fmt.Errorf("chain %s is not an evm chain", chain.ID)
+	}
+	key := poolKey(chain)
+
+	evmMu.Lock()
+	defer evmMu.Unlock()
+
+	if entry, found := evmClients[chain.ID]; found && entry.key == key {
+		return entry, nil
+	}
+
+	pool, rpcClient, err := newRpcClient(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	// a replaced http client is not closed, requests still in flight on it finish normally
+	entry := &evmEntry{key: key, pool: pool, client: ethclient.NewClient(rpcClient)}
+	evmClients[chain.ID] = entry
+	return entry, nil
+}
+
+// newRpcClient returns a json-rpc client whose requests fail over between the chain's rpcs
+func newRpcClient(chain *chains.Chain) (*Pool, *rpc.Client, error) {
+	pool, err := NewPool(chain.ID, chain.Rpcs, chainOptions(chain))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpClient := &http.Client{
+		Transport: &transport{
+			pool: pool,
+			base: &http.Transport{
+				Proxy:                 http.ProxyFromEnvironment,
+				MaxIdleConnsPerHost:   16,
+				IdleConnTimeout:       90 * time.Second,
+				ResponseHeaderTimeout: attemptTimeout,
+			},
+		},
+	}
+
+	// the dial url is only a placeholder, the transport rewrites it per request
+	rpcClient, err := rpc.DialOptions(context.Background(), chain.Rpcs[0], rpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, nil, err
+	}
+	return pool, rpcClient, nil
+}
+
+func chainOptions(chain *chains.Chain) Options {
+	opts := DefaultOptions
+	opts.RateLimit = chain.RpcRateLimit
+	if opts.MaxAttempts < len(chain.Rpcs) {
+		opts.MaxAttempts = len(chain.Rpcs)
+	}
+	return opts
+}
+
+func poolKey(chain *chains.Chain) string {
+	return fmt.Sprintf("%s|%v", strings.Join(chain.Rpcs, ","), chain.RpcRateLimit)
+}
@@ -0,0 +1,174 @@
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+type Options struct {
+	MaxAttempts      int           // attempts per call across all endpoints
+	BaseBackoff      time.Duration // doubled after every failed attempt
+	MaxBackoff       time.Duration
+	FailureThreshold int           // consecutive failures before the breaker opens
+	Cooldown         time.Duration // time an open breaker waits before a probe
+	RateLimit        float64       // requests per second per endpoint, 0 is unlimited
+	Burst            int
+}
+
+var DefaultOptions = Options{
+	MaxAttempts:      3,
+	BaseBackoff:      100 * time.Millisecond,
+	MaxBackoff:       2 * time.Second,
+	FailureThreshold: 3,
+	Cooldown:         30 * time.Second,
+	RateLimit:        0,
+	Burst:            10,
+}
+
+var ErrNoEndpoint = errors.New("no healthy rpc endpoint")
+
+// permanentError stops the retry loop, the endpoint is not penalised
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error as not retryable, e.g. a revert or a malformed request
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err}
+}
+
+// Pool selects between the endpoints of a single chain
+type Pool struct {
+	ChainId   string
+	endpoints []*Endpoint
+	opts      Options
+}
+
+func NewPool(chainId string, urls []string, opts Options) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no rpc configured for chain id: %s", chainId)
+	}
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+	if opts.FailureThreshold < 1 {
+		opts.FailureThreshold = 1
+	}
+
+	pool := &Pool{ChainId: chainId, opts: opts}
+	for _, url := range urls {
+		pool.endpoints = append(pool.endpoints, &Endpoint{
+			URL:     url,
+			limiter: newTokenBucket(opts.RateLimit, opts.Burst),
+		})
+	}
+	return pool, nil
+}
+
+// pick returns the lowest latency endpoint with a closed breaker and budget left,
+// endpoints already tried in this call are only used when nothing else is left
+func (p *Pool) pick(tried map[*Endpoint]bool) (*Endpoint, error) {
+	now := time.Now()
+
+	candidates := make([]*Endpoint, len(p.endpoints))
+	copy(candidates, p.endpoints)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if tried[candidates[i]] != tried[candidates[j]] {
+			return !tried[candidates[i]]
+		}
+		return candidates[i].score() < candidates[j].score()
+	})
+
+	for _, e := range candidates {
+		if e.available(now, p.opts.Cooldown) && e.limiter.allow(now) {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("%w for chain id: %s", ErrNoEndpoint, p.ChainId)
+}
+
+// Do runs fn against the pool with failover and exponential backoff
+func (p *Pool) Do(ctx context.Context, fn func(ctx context.Context, endpoint *Endpoint) error) error {
+	tried := make(map[*Endpoint]bool, len(p.endpoints))
+	backoff := p.opts.BaseBackoff
+
+	var lastErr error
+	for attempt := 0; attempt < p.opts.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, jitter(backoff)); err != nil {
+				return err
+			}
+			backoff *= 2
+			if backoff > p.opts.MaxBackoff {
+				backoff = p.opts.MaxBackoff
+			}
+		}
+
+		endpoint, err := p.pick(tried)
+		if err != nil {
+			if lastErr != nil {
+				return fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+			lastErr = err
+			continue
+		}
+		tried[endpoint] = true
+
+		start := time.Now()
+		err = fn(ctx, endpoint)
+		if err == nil {
+			endpoint.recordSuccess(time.Since(start))
+			return nil
+		}
+
+		var permanent permanentError
+		if errors.As(err, &permanent) {
+			endpoint.recordSuccess(time.Since(start))
+			return permanent.err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+
+		endpoint.recordFailure(err, p.opts.FailureThreshold, time.Now())
+		lastErr = err
+	}
+
+	return fmt.Errorf("rpc call failed for chain id %s after %d attempts: %v", p.ChainId, p.opts.MaxAttempts, lastErr)
+}
+
+func (p *Pool) Status() []EndpointStatus {
+	status := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		status = append(status, e.Status())
+	}
+	return status
+}
+
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,299 @@
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+)
+
+var testOptions = Options{
+	MaxAttempts:      3,
+	BaseBackoff:      time.Millisecond,
+	MaxBackoff:       2 * time.Millisecond,
+	FailureThreshold: 2,
+	Cooldown:         time.Minute,
+	Burst:            1,
+}
+
+func TestBreaker(t *testing.T) {
+	e := &Endpoint{URL: "a"}
+	now := time.Now()
+	failed := errors.New("timeout")
+	const cooldown = time.Minute
+
+	e.recordFailure(failed, 2, now)
+	if !e.available(now, cooldown) || e.Status().State != "closed" {
+		t.Fatalf("opened below the threshold: %+v", e.Status())
+	}
+	e.recordFailure(failed, 2, now)
+	if e.available(now.Add(cooldown-time.Second), cooldown) || e.Status().State != "open" {
+		t.Fatalf("not open at the threshold: %+v", e.Status())
+	}
+
+	// after the cooldown a single probe goes through
+	probe := now.Add(cooldown)
+	if !e.available(probe, cooldown) || e.Status().State != "half-open" {
+		t.Fatalf("no probe after the cooldown: %+v", e.Status())
+	}
+	if e.available(probe.Add(time.Second), cooldown) {
+		t.Fatal("a second probe went through")
+	}
+
+	// a failed probe reopens at once, a successful one closes the breaker
+	e.recordFailure(failed, 100, probe)
+	if e.Status().State != "open" || e.available(probe.Add(time.Second), cooldown) {
+		t.Fatalf("failed probe did not reopen: %+v", e.Status())
+	}
+	if !e.available(probe.Add(cooldown), cooldown) {
+		t.Fatal("no probe after the second cooldown")
+	}
+	e.recordSuccess(10 * time.Millisecond)
+	if status := e.Status(); status.State != "closed" || status.Failures != 0 || status.LastError != "" {
+		t.Fatalf("probe success did not close: %+v", status)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(2, 2)
+	bucket.last = now
+	for i, want := range []bool{true, true, false} {
+		if bucket.allow(now) != want {
+			t.Fatalf("request %d: allow %v", i, !want)
+		}
+	}
+	// 2 per second refills one token every 500ms, never above the burst
+	if bucket.allow(now.Add(400*time.Millisecond)) || !bucket.allow(now.Add(900*time.Millisecond)) {
+		t.Fatal("refill")
+	}
+	later := now.Add(time.Hour)
+	if !bucket.allow(later) || !bucket.allow(later) || bucket.allow(later) {
+		t.Fatal("burst cap")
+	}
+
+	unlimited := newTokenBucket(0, 1)
+	for i := 0; i < 100; i++ {
+		if !unlimited.allow(now) {
+			t.Fatal("a zero rate limited")
+		}
+	}
+}
+
+func TestPickPrefersFastAndUntried(t *testing.T) {
+	pool, err := NewPool("1", []string{"slow", "fast", "limited"}, Options{Burst: 1, RateLimit: 0.001, Cooldown: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slow, fast, limited := pool.endpoints[0], pool.endpoints[1], pool.endpoints[2]
+	slow.recordSuccess(time.Second)
+	fast.recordSuccess(time.Millisecond)
+	limited.recordSuccess(time.Microsecond)
+
+	if e, _ := pool.pick(nil); e != limited {
+		t.Fatalf("picked %s", e.URL)
+	}
+	// the limited endpoint spent its only token
+	if e, _ := pool.pick(nil); e != fast {
+		t.Fatalf("picked %s", e.URL)
+	}
+	if e, _ := pool.pick(map[*Endpoint]bool{fast: true}); e != slow {
+		t.Fatalf("picked %s", e.URL)
+	}
+	if _, err := NewPool("1", nil, testOptions); err == nil {
+		t.Fatal("expected an error without urls")
+	}
+}
+
+func TestDo(t *testing.T) {
+	t.Run("fails over", func(t *testing.T) {
+		pool, _ := NewPool("1", []string{"a", "b"}, testOptions)
+		var urls []string
+		err := pool.Do(context.Background(), func(_ context.Context, e *Endpoint) error {
+			urls = append(urls, e.URL)
+			if len(urls) == 1 {
+				return errors.New("down")
+			}
+			return nil
+		})
+		if err != nil || len(urls) != 2 || urls[0] == urls[1] {
+			t.Fatalf("err %v urls %v", err, urls)
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		pool, _ := NewPool("1", []string{"a", "b", "c", "d"}, testOptions)
+		calls := 0
+		err := pool.Do(context.Background(), func(context.Context, *Endpoint) error {
+			calls++
+			return errors.New("down")
+		})
+		if err == nil || calls != testOptions.MaxAttempts || !strings.Contains(err.Error(), "down") {
+			t.Fatalf("err %v calls %d", err, calls)
+		}
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		pool, _ := NewPool("1", []string{"a", "b"}, testOptions)
+		reverted := errors.New("execution reverted")
+		calls := 0
+		err := pool.Do(context.Background(), func(context.Context, *Endpoint) error {
+			calls++
+			return Permanent(reverted)
+		})
+		if !errors.Is(err, reverted) || calls != 1 {
+			t.Fatalf("err %v calls %d", err, calls)
+		}
+		for _, status := range pool.Status() {
+			if status.Failures != 0 {
+				t.Fatalf("permanent error penalised %+v", status)
+			}
+		}
+	})
+
+	t.Run("open breakers", func(t *testing.T) {
+		pool, _ := NewPool("1", []string{"a"}, testOptions)
+		for i := 0; i < testOptions.FailureThreshold; i++ {
+			pool.endpoints[0].recordFailure(errors.New("down"), testOptions.FailureThreshold, time.Now())
+		}
+		err := pool.Do(context.Background(), func(context.Context, *Endpoint) error { return nil })
+		if !errors.Is(err, ErrNoEndpoint) {
+			t.Fatalf("err %v", err)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		pool, _ := NewPool("1", []string{"a", "b"}, testOptions)
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := pool.Do(ctx, func(context.Context, *Endpoint) error {
+			calls++
+			cancel()
+			return errors.New("down")
+		})
+		if err == nil || calls != 1 || pool.Status()[0].Failures+pool.Status()[1].Failures != 0 {
+			t.Fatalf("err %v calls %d", err, calls)
+		}
+	})
+}
+
+func TestTransportFailover(t *testing.T) {
+	var downCalls atomic.Int32
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downCalls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer limited.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "ok %s", body)
+	}))
+	defer up.Close()
+
+	opts := testOptions
+	opts.MaxAttempts = 3
+	pool, _ := NewPool("1", []string{down.URL, limited.URL, up.URL}, opts)
+	client := &http.Client{Transport: &transport{pool: pool, base: http.DefaultTransport}}
+
+	// every request body is replayed to the next endpoint
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post("http://placeholder", "application/json", strings.NewReader("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "ok {}" {
+			t.Fatalf("body %q", body)
+		}
+	}
+	// the second request went straight to the endpoint that answered
+	status := pool.Status()
+	if downCalls.Load() != 1 || status[0].Failures != 1 || status[1].Failures != 1 || status[2].State != "closed" {
+		t.Fatalf("status %+v", status)
+	}
+
+	// other client errors are the answer of a healthy endpoint
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	client = &http.Client{Transport: &transport{pool: mustPool(t, notFound.URL, up.URL), base: http.DefaultTransport}}
+	resp, err := client.Get("http://placeholder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+}
+
+func mustPool(t *testing.T, urls ...string) *Pool {
+	pool, err := NewPool("1", urls, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pool
+}
+
+// a liteserver config url that never answers holds only the calls of its own chain
+func TestTonClientConnectsOutsideTheLock(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+	defer close(release)
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "not a config")
+	}))
+	defer broken.Close()
+
+	config := fmt.Sprintf(`{"chains": [
+		{"id": "9001", "vm": "tvm", "name": "slowTon", "enabled": true, "rpcs": [%q]},
+		{"id": "9002", "vm": "tvm", "name": "brokenTon", "enabled": true, "rpcs": [%q]}
+	]}`, slow.URL, broken.URL)
+	path := filepath.Join(t.TempDir(), "chains.json")
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { chains.Reload() })
+	t.Setenv("CHAIN_CONFIG_SOURCE", "")
+	t.Setenv("CHAIN_CONFIG_PATH", path)
+	if _, err := chains.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	go TonClient("9001")
+	time.Sleep(50 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := TonClient("9002")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("connected to a broken config")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("a slow chain blocked the client of another chain")
+	}
+}
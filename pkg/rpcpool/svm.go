@@ -0,0 +1,47 @@
+package rpcpool
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type svmEntry struct {
+	key    string
+	pool   *Pool
+	client *rpc.Client
+}
+
+var (
+	svmMu      sync.Mutex
+	svmClients = map[string]*svmEntry{}
+)
+
+// SvmClient returns the shared json-rpc client for a solana chain, the solana rpc is plain
+// json-rpc 2.0 so it goes through the same failover transport as the evm clients
+func SvmClient(chainId string) (*rpc.Client, error) {
+	chain, err := chains.Get(chainId)
+	if err != nil {
+		return nil, err
+	}
+	if chain.VM != "svm" {
+		return nil, fmt.Errorf("chain %s is not an svm chain", chain.ID)
+	}
+	key := poolKey(chain)
+
+	svmMu.Lock()
+	defer svmMu.Unlock()
+
+	if entry, found := svmClients[chain.ID]; found && entry.key == key {
+		return entry.client, nil
+	}
+
+	pool, rpcClient, err := newRpcClient(chain)
+	if err != nil {
+		return nil, err
+	}
+	svmClients[chain.ID] = &svmEntry{key: key, pool: pool, client: rpcClient}
+	return rpcClient, nil
+}
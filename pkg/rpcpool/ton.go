@@ -0,0 +1,137 @@
+package rpcpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
+	"github.com/xssnick/tonutils-go/liteclient"
+	"github.com/xssnick/tonutils-go/ton"
+)
+
+// for tvm chains the rpcs are liteserver global config urls
+type tonEntry struct {
+	key  string
+	pool *Pool
+
+	mu   sync.Mutex // held while connecting, calls of other chains don't wait on it
+	conn *liteclient.ConnectionPool
+	api  ton.APIClientWrapped
+}
+
+var (
+	tonMu      sync.Mutex // guards tonClients only, never held while connecting
+	tonClients = map[string]*tonEntry{}
+)
+
+// TonClient returns the shared liteserver client for a chain with a fresh sticky context,
+// the first config url that connects is kept until the registry changes or Reset is called
+func TonClient(chainId string) (context.Context, ton.APIClientWrapped, error) {
+	chain, err := chains.Get(chainId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if chain.VM != "tvm" {
+		return nil, nil, fmt.Errorf("chain %s is not a tvm chain", chain.ID)
+	}
+	entry, err := tonEntryOf(chain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.api == nil {
+		err := entry.pool.Do(context.Background(), func(ctx context.Context, endpoint *Endpoint) error {
+			// a config url or liteserver that never answers is failed over like a slow evm rpc
+			ctx, cancel := context.WithTimeout(ctx, attemptTimeout)
+			defer cancel()
+
+			conn, api, err := connectLiteserver(ctx, endpoint.URL)
+			if err != nil {
+				return err
+			}
+			entry.conn, entry.api = conn, api
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return entry.conn.StickyContext(context.Background()), entry.api, nil
+}
+
+// tonEntryOf returns the entry of a chain, an entry of a replaced rpc list is stopped
+func tonEntryOf(chain *chains.Chain) (*tonEntry, error) {
+	key := poolKey(chain)
+
+	tonMu.Lock()
+	defer tonMu.Unlock()
+
+	entry, found := tonClients[chain.ID]
+	if found && entry.key == key {
+		return entry, nil
+	}
+	pool, err := NewPool(chain.ID, chain.Rpcs, chainOptions(chain))
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		// the old entry may still be connecting, it is stopped once that finishes
+		go entry.stop()
+	}
+	entry = &tonEntry{key: key, pool: pool}
+	tonClients[chain.ID] = entry
+	return entry, nil
+}
+
+func (e *tonEntry) stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil {
+		e.conn.Stop()
+		e.conn, e.api = nil, nil
+	}
+}
+
+// ResetTonClient drops the cached connection so the next call reconnects through the pool
+func ResetTonClient(chainId string) {
+	tonMu.Lock()
+	entry, found := tonClients[chainId]
+	tonMu.Unlock()
+
+	if found {
+		entry.stop()
+	}
+}
+
+// TonPool returns the config url pool for a chain, nil before the first TonClient call
+func TonPool(chainId string) *Pool {
+	tonMu.Lock()
+	defer tonMu.Unlock()
+
+	if entry, found := tonClients[chainId]; found {
+		return entry.pool
+	}
+	return nil
+}
+
+func connectLiteserver(ctx context.Context, configUrl string) (*liteclient.ConnectionPool, ton.APIClientWrapped, error) {
+	cfg, err := liteclient.GetConfigFromUrl(ctx, configUrl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn := liteclient.NewConnectionPool()
+	if err := conn.AddConnectionsFromConfig(ctx, cfg); err != nil {
+		return nil, nil, err
+	}
+
+	api := ton.NewAPIClient(conn, ton.ProofCheckPolicyFast).WithRetry()
+	api.SetTrustedBlockFromConfig(cfg)
+	return conn, api, nil
+}
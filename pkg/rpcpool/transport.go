@@ -0,0 +1,71 @@
+package rpcpool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// transport sends every http json-rpc request through the pool, the request url
+// is replaced with the selected endpoint so one client can fail over between urls
+type transport struct {
+	pool *Pool
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var response *http.Response
+
+	err := t.pool.Do(req.Context(), func(ctx context.Context, endpoint *Endpoint) error {
+		clone, err := cloneRequest(req, endpoint)
+		if err != nil {
+			return err
+		}
+
+		resp, err := t.base.RoundTrip(clone)
+		if err != nil {
+			return err
+		}
+		if retryableStatus(resp.StatusCode) {
+			drain(resp.Body)
+			return fmt.Errorf("%s returned status %d", endpoint.URL, resp.StatusCode)
+		}
+
+		response = resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func cloneRequest(req *http.Request, endpoint *Endpoint) (*http.Request, error) {
+	target, err := url.Parse(endpoint.URL)
+	if err != nil {
+		return nil, Permanent(fmt.Errorf("invalid rpc url %s: %v", endpoint.URL, err))
+	}
+
+	clone := req.Clone(req.Context())
+	clone.URL = target
+	clone.Host = target.Host
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, Permanent(err)
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+func drain(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, 4096))
+	body.Close()
+}
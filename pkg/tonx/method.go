@@ -50,8 +50,8 @@ type TonGetBlockHeader struct {
 	Workchain int    `json:"workchain"` // Workchain id
 	Shard     string `json:"shard"`     // Required
 	Seqno     int    `json:"seqno"`     // Required: Block sequence number
-	RootHash  string `json:"root_hash"`
-	FileHash  string `json:"file_hash"`
+	RootHash  string `json:"root_hash,omitempty"`
+	FileHash  string `json:"file_hash,omitempty"`
 }
 
 // method: ton_getConsensusBlock
@@ -70,11 +70,18 @@ type TonGetShardBlockProof struct {
 	Workchain int    `json:"workchain"` // Required
 	Shard     string `json:"shard"`     // Required
 	Seqno     int    `json:"seqno"`     // Required
-	FromSeqno int    `json:"from_seqno"`
+	FromSeqno int    `json:"from_seqno,omitempty"`
 }
 
 // method: ton_lookupBlock
-// not found
+// missing from the docs, same parameters as toncenter, one of seqno, lt or unixtime is required
+type TonLookupBlock struct {
+	Workchain int    `json:"workchain"` // Required
+	Shard     string `json:"shard"`     // Required
+	Seqno     int    `json:"seqno,omitempty"`
+	Lt        int    `json:"lt,omitempty"`
+	Unixtime  int    `json:"unixtime,omitempty"`
+}
 
 // method: ton_shards
 type TonShards struct {
@@ -83,8 +90,8 @@ type TonShards struct {
 
 // method: ton_getConfigParam
 type TonGetConfigParam struct {
-	ConfigId int `json:"config_id"` // Required: config id
-	Seqno    int `json:"seqno"`     // Block sequence number
+	ConfigId int `json:"config_id"`       // Required: config id
+	Seqno    int `json:"seqno,omitempty"` // Block sequence number
 }
 
 // method: ton_runGetMethod
@@ -96,8 +103,11 @@ type TonRunGetMethod struct {
 
 // method: ton_estimateFee
 type TonEstimateFee struct {
-	Address string `json:"address"` // Required: Identifier of target TON account in any form.
-	Body    string `json:"body"`    // Required: Base64 encoded message body.
+	Address      string `json:"address"`             // Required: Identifier of target TON account in any form.
+	Body         string `json:"body"`                // Required: Base64 encoded message body.
+	InitCode     string `json:"init_code,omitempty"` // Base64 encoded code of an undeployed account.
+	InitData     string `json:"init_data,omitempty"` // Base64 encoded data of an undeployed account.
+	IgnoreChksig bool   `json:"ignore_chksig"`       // Estimate without checking the signature of the body.
 }
 
 // method: ton_sendBoc
@@ -115,20 +125,21 @@ type TonGetBlockTransactions struct {
 	Workchain int    `json:"workchain"` // Required: Workchain id
 	Shard     string `json:"shard"`     // Required: shard
 	Seqno     int    `json:"seqno"`     // Required: Block sequence number
-	RootHash  string `json:"root_hash"`
-	FileHash  string `json:"file_hash"`
-	AfterLt   string `json:"after_lt"`
-	AfterHash string `json:"after_hash"`
+	RootHash  string `json:"root_hash,omitempty"`
+	FileHash  string `json:"file_hash,omitempty"`
+	AfterLt   string `json:"after_lt,omitempty"`
+	AfterHash string `json:"after_hash,omitempty"`
+	Count     int    `json:"count,omitempty"`
 }
 
 // method: ton_getTransactions
 type TonGetTransactions struct {
-	Address  string `json:"address"` // Required: Identifier of target TON account in any form.
-	Limit    int    `json:"limit"`   // Maximum number of transactions in response.
-	Lt       int    `json:"lt"`      // Logical time of transaction to start with, must be sent with hash.
-	Hash     string `json:"hash"`    // Hash of transaction to start with, in base64 or hex encoding , must be sent with lt.
-	ToLt     int    `json:"to_lt"`   // Logical time of transaction to finish with (to get tx from lt to to_lt).
-	Archival bool   `json:"archival"`
+	Address  string `json:"address"`         // Required: Identifier of target TON account in any form.
+	Limit    int    `json:"limit,omitempty"` // Maximum number of transactions in response.
+	Lt       int    `json:"lt,omitempty"`    // Logical time of transaction to start with, must be sent with hash.
+	Hash     string `json:"hash,omitempty"`  // Hash of transaction to start with, in base64 or hex encoding , must be sent with lt.
+	ToLt     int    `json:"to_lt,omitempty"` // Logical time of transaction to finish with (to get tx from lt to to_lt).
+	Archival bool   `json:"archival,omitempty"`
 }
 
 // method: ton_tryLocateResultTx
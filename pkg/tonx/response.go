@@ -1,52 +1,296 @@
 package tonx
 
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// responses are the result of the json-rpc envelope, see TonXResponse
+
+type BlockId struct {
+	Type      string `json:"@type"`
+	Workchain int    `json:"workchain"`
+	Shard     string `json:"shard"`
+	Seqno     int    `json:"seqno"`
+	RootHash  string `json:"root_hash"` // base64
+	FileHash  string `json:"file_hash"` // base64
+}
+
+type TransactionId struct {
+	Type string `json:"@type"`
+	Lt   string `json:"lt"`
+	Hash string `json:"hash"` // base64
+}
+
+type AccountAddress struct {
+	Type           string `json:"@type"`
+	AccountAddress string `json:"account_address"`
+}
+
+type RawMessage struct {
+	Type        string `json:"@type"`
+	Hash        string `json:"hash"` // base64 hash of the message cell
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Value       string `json:"value"`
+	FwdFee      string `json:"fwd_fee"`
+	IhrFee      string `json:"ihr_fee"`
+	CreatedLt   string `json:"created_lt"`
+	BodyHash    string `json:"body_hash"`
+}
+
+type RawTransaction struct {
+	Type          string         `json:"@type"`
+	Address       AccountAddress `json:"address"`
+	Utime         int64          `json:"utime"`
+	Data          string         `json:"data"`
+	TransactionId TransactionId  `json:"transaction_id"`
+	Fee           string         `json:"fee"`
+	StorageFee    string         `json:"storage_fee"`
+	OtherFee      string         `json:"other_fee"`
+	InMsg         RawMessage     `json:"in_msg"`
+	OutMsgs       []RawMessage   `json:"out_msgs"`
+}
+
+type Fees struct {
+	Type       string `json:"@type"`
+	InFwdFee   int64  `json:"in_fwd_fee"`
+	StorageFee int64  `json:"storage_fee"`
+	GasFee     int64  `json:"gas_fee"`
+	FwdFee     int64  `json:"fwd_fee"`
+}
+
+// Total is the sum of every fee in nanotons
+func (f Fees) Total() int64 {
+	return f.InFwdFee + f.StorageFee + f.GasFee + f.FwdFee
+}
+
+// StackEntry is a [type, value] pair of a get method stack, the value is a string for numbers
+// and an object for cells, slices and tuples
+type StackEntry [2]json.RawMessage
+
+func (e StackEntry) Type() string {
+	var t string
+	json.Unmarshal(e[0], &t)
+	return t
+}
+
+// Value returns the value of string entries, other entries return their raw json
+func (e StackEntry) Value() string {
+	var v string
+	if err := json.Unmarshal(e[1], &v); err != nil {
+		return string(e[1])
+	}
+	return v
+}
+
 // response: ton_detectAddress
 type TonDetectAddressResponse struct {
-	Jsonrpc string `json:"jsonrpc"`
-	Id      int    `json:"id"`
-	Result  struct {
-		RawForm    string `json:"raw_form"` // Raw address in any form.
-		Bounceable struct {
-			B64    string `json:"b64"`
-			B64URL string `json:"b64url"`
-		} `json:"bounceable"`
-		NonBounceable struct {
-			B64    string `json:"b64"`
-			B64URL string `json:"b64url"`
-		} `json:"non_bounceable"`
-		GivenType string `json:"given_type"`
-		TestOnly  bool   `json:"test_only"`
-	} `json:"result"`
+	Type       string `json:"@type"`
+	RawForm    string `json:"raw_form"` // Raw address in any form.
+	Bounceable struct {
+		B64    string `json:"b64"`
+		B64URL string `json:"b64url"`
+	} `json:"bounceable"`
+	NonBounceable struct {
+		B64    string `json:"b64"`
+		B64URL string `json:"b64url"`
+	} `json:"non_bounceable"`
+	GivenType string `json:"given_type"`
+	TestOnly  bool   `json:"test_only"`
 }
 
 // response: ton_getAddressBalance
-type TonGetAddressBalanceResponse struct {
-	Jsonrpc string `json:"jsonrpc"`
-	Id      int    `json:"id"`
-	Result  string `json:"result"` // Balance of the account in nanotokens.
+// Balance of the account in nanotokens.
+type TonGetAddressBalanceResponse string
+
+// response: ton_getAddressInformation
+type TonGetAddressInformationResponse struct {
+	Type              string        `json:"@type"`
+	Balance           string        `json:"balance"`
+	Code              string        `json:"code"` // base64 boc
+	Data              string        `json:"data"` // base64 boc
+	LastTransactionId TransactionId `json:"last_transaction_id"`
+	BlockId           BlockId       `json:"block_id"`
+	FrozenHash        string        `json:"frozen_hash"`
+	SyncUtime         int64         `json:"sync_utime"`
+	State             string        `json:"state"` // active, uninitialized or frozen
+}
+
+// response: ton_getAddressState
+// One of active, uninitialized or frozen.
+type TonGetAddressStateResponse string
+
+// response: ton_getExtendedAddressInformation
+type TonGetExtendedAddressInformationResponse struct {
+	Type              string          `json:"@type"`
+	Address           AccountAddress  `json:"address"`
+	Balance           string          `json:"balance"`
+	LastTransactionId TransactionId   `json:"last_transaction_id"`
+	BlockId           BlockId         `json:"block_id"`
+	SyncUtime         int64           `json:"sync_utime"`
+	AccountState      json.RawMessage `json:"account_state"` // depends on the wallet or contract type
+	Revision          int             `json:"revision"`
+}
+
+// response: ton_getTokenData
+// Minters fill the jetton fields, wallets fill the wallet fields.
+type TonGetTokenDataResponse struct {
+	ContractType     string          `json:"contract_type"` // jetton_master or jetton_wallet
+	TotalSupply      string          `json:"total_supply,omitempty"`
+	Mintable         bool            `json:"mintable,omitempty"`
+	AdminAddress     string          `json:"admin_address,omitempty"`
+	JettonContent    json.RawMessage `json:"jetton_content,omitempty"`
+	JettonWalletCode string          `json:"jetton_wallet_code,omitempty"`
+	Balance          string          `json:"balance,omitempty"`
+	Owner            string          `json:"owner,omitempty"`
+	Jetton           string          `json:"jetton,omitempty"`
+}
+
+// response: ton_getWalletInformation
+type TonGetWalletInformationResponse struct {
+	Wallet            bool          `json:"wallet"`
+	Balance           string        `json:"balance"`
+	AccountState      string        `json:"account_state"`
+	WalletType        string        `json:"wallet_type"`
+	Seqno             int64         `json:"seqno"`
+	WalletId          int64         `json:"wallet_id"`
+	LastTransactionId TransactionId `json:"last_transaction_id"`
+}
+
+// response: ton_packAddress
+// User-friendly form of the address.
+type TonPackAddressResponse string
+
+// response: ton_unpackAddress
+// Raw form of the address.
+type TonUnpackAddressResponse string
+
+// response: ton_getBlockHeader
+type TonGetBlockHeaderResponse struct {
+	Type                   string    `json:"@type"`
+	Id                     BlockId   `json:"id"`
+	GlobalId               int       `json:"global_id"`
+	Version                int       `json:"version"`
+	Flags                  int       `json:"flags"`
+	AfterMerge             bool      `json:"after_merge"`
+	AfterSplit             bool      `json:"after_split"`
+	BeforeSplit            bool      `json:"before_split"`
+	WantMerge              bool      `json:"want_merge"`
+	WantSplit              bool      `json:"want_split"`
+	ValidatorListHashShort int64     `json:"validator_list_hash_short"`
+	CatchainSeqno          int       `json:"catchain_seqno"`
+	MinRefMcSeqno          int       `json:"min_ref_mc_seqno"`
+	IsKeyBlock             bool      `json:"is_key_block"`
+	PrevKeyBlockSeqno      int       `json:"prev_key_block_seqno"`
+	StartLt                string    `json:"start_lt"`
+	EndLt                  string    `json:"end_lt"`
+	GenUtime               int64     `json:"gen_utime"`
+	PrevBlocks             []BlockId `json:"prev_blocks"`
+}
+
+// response: ton_getConsensusBlock
+type TonGetConsensusBlockResponse struct {
+	ConsensusBlock int     `json:"consensus_block"`
+	Timestamp      float64 `json:"timestamp"`
+}
+
+// response: ton_getMasterchainBlockSignatures
+type TonGetMasterchainBlockSignaturesResponse struct {
+	Type       string  `json:"@type"`
+	Id         BlockId `json:"id"`
+	Signatures []struct {
+		Type        string `json:"@type"`
+		NodeIdShort string `json:"node_id_short"`
+		Signature   string `json:"signature"`
+	} `json:"signatures"`
+}
+
+// response: ton_getMasterchainInfo
+type TonGetMasterchainInfoResponse struct {
+	Type          string  `json:"@type"`
+	Last          BlockId `json:"last"`
+	StateRootHash string  `json:"state_root_hash"`
+	Init          BlockId `json:"init"`
+}
+
+// response: ton_getShardBlockProof
+// Links and the masterchain proof are kept raw, they are only forwarded to provers.
+type TonGetShardBlockProofResponse struct {
+	Type    string          `json:"@type"`
+	From    BlockId         `json:"from"`
+	McId    BlockId         `json:"mc_id"`
+	Links   json.RawMessage `json:"links"`
+	McProof json.RawMessage `json:"mc_proof"`
+}
+
+// response: ton_lookupBlock
+type TonLookupBlockResponse BlockId
+
+// response: ton_shards
+type TonShardsResponse struct {
+	Type   string    `json:"@type"`
+	Shards []BlockId `json:"shards"`
 }
 
-// response: ton_getConfigParamResponse
+// response: ton_getConfigParam
 type TonGetConfigParamResponse struct {
-	Jsonrpc string `json:"jsonrpc"` // Defaults to 2.0
-	Id      int    `json:"id"`      // Defaults to 1
-	Result  struct {
-		Type   string `json:"@type"`
-		Config struct {
-			Type  string `json:"@type"` // config type
-			Bytes string `json:"bytes"` // config bytes
-		} `json:"config"`
-		Extra string `json:"@extra"` // Extra Information
-	} `json:"result"`
+	Type   string `json:"@type"`
+	Config struct {
+		Type  string `json:"@type"` // config type
+		Bytes string `json:"bytes"` // config bytes
+	} `json:"config"`
+}
+
+// response: ton_runGetMethod
+type TonRunGetMethodResponse struct {
+	Type     string       `json:"@type"`
+	GasUsed  int          `json:"gas_used"`
+	Stack    []StackEntry `json:"stack"`
+	ExitCode int          `json:"exit_code"`
+}
+
+// response: ton_estimateFee
+type TonEstimateFeeResponse struct {
+	Type            string `json:"@type"`
+	SourceFees      Fees   `json:"source_fees"`
+	DestinationFees []Fees `json:"destination_fees"`
+}
+
+// response: ton_sendBoc
+type TonSendBocResponse struct {
+	Type string `json:"@type"` // ok
 }
 
 // response: ton_sendBocReturnHash
 type TonSendBocReturnHashResponse struct {
-	Jsonrpc string `json:"jsonrpc"` // Required: Defaults to 2.0
-	Id      int    `json:"id"`      // Required: Defaults to 1
-	Result  struct {
-		Type  string `json:"@type"`  // Type of the result
-		Hash  string `json:"hash"`   // Required: Transaction hash
-		Extra string `json:"@extra"` // Extra Information
-	} `json:"result"` // Required
+	Type string `json:"@type"` // Type of the result
+	Hash string `json:"hash"`  // Required: base64 hash of the external message
+}
+
+// response: ton_getBlockTransactions
+type TonGetBlockTransactionsResponse struct {
+	Type         string  `json:"@type"`
+	Id           BlockId `json:"id"`
+	ReqCount     int     `json:"req_count"`
+	Incomplete   bool    `json:"incomplete"`
+	Transactions []struct {
+		Type    string `json:"@type"`
+		Mode    int    `json:"mode"`
+		Account string `json:"account"`
+		Lt      string `json:"lt"`
+		Hash    string `json:"hash"`
+	} `json:"transactions"`
+}
+
+// response: ton_getTransactions
+// Newest first.
+type TonGetTransactionsResponse []RawTransaction
+
+// response: ton_tryLocateResultTx, ton_tryLocateSourceTx, ton_tryLocateTx
+type TonTryLocateTxResponse RawTransaction
+
+// Lt parses the logical time of the transaction
+func (t RawTransaction) Lt() (uint64, error) {
+	return strconv.ParseUint(t.TransactionId.Lt, 10, 64)
 }
@@ -0,0 +1,219 @@
+package tonx
+
+import "context"
+
+// one method per json-rpc method, parameters are the structs of method.go
+
+func (c *Client) DetectAddress(ctx context.Context, params TonDetectAddress) (*TonDetectAddressResponse, error) {
+	var response TonDetectAddressResponse
+	if err := c.Call(ctx, "detectAddress", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetAddressBalance(ctx context.Context, params TonGetAddressBalance) (*TonGetAddressBalanceResponse, error) {
+	var response TonGetAddressBalanceResponse
+	if err := c.Call(ctx, "getAddressBalance", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetAddressInformation(ctx context.Context, params TonGetAddressInformation) (*TonGetAddressInformationResponse, error) {
+	var response TonGetAddressInformationResponse
+	if err := c.Call(ctx, "getAddressInformation", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetAddressState(ctx context.Context, params TonGetAddressState) (*TonGetAddressStateResponse, error) {
+	var response TonGetAddressStateResponse
+	if err := c.Call(ctx, "getAddressState", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetExtendedAddressInformation(ctx context.Context, params TonGetExtendedAddressInformation) (*TonGetExtendedAddressInformationResponse, error) {
+	var response TonGetExtendedAddressInformationResponse
+	if err := c.Call(ctx, "getExtendedAddressInformation", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetTokenData(ctx context.Context, params TonGetTokenData) (*TonGetTokenDataResponse, error) {
+	var response TonGetTokenDataResponse
+	if err := c.Call(ctx, "getTokenData", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetWalletInformation(ctx context.Context, params TonGetWalletInformation) (*TonGetWalletInformationResponse, error) {
+	var response TonGetWalletInformationResponse
+	if err := c.Call(ctx, "getWalletInformation", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) PackAddress(ctx context.Context, params TonPackAddress) (*TonPackAddressResponse, error) {
+	var response TonPackAddressResponse
+	if err := c.Call(ctx, "packAddress", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) UnpackAddress(ctx context.Context, params TonUnpackAddress) (*TonUnpackAddressResponse, error) {
+	var response TonUnpackAddressResponse
+	if err := c.Call(ctx, "unpackAddress", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetBlockHeader(ctx context.Context, params TonGetBlockHeader) (*TonGetBlockHeaderResponse, error) {
+	var response TonGetBlockHeaderResponse
+	if err := c.Call(ctx, "getBlockHeader", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetConsensusBlock(ctx context.Context) (*TonGetConsensusBlockResponse, error) {
+	var response TonGetConsensusBlockResponse
+	if err := c.Call(ctx, "getConsensusBlock", nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetMasterchainBlockSignatures(ctx context.Context, params TonGetMasterchainBlockSignatures) (*TonGetMasterchainBlockSignaturesResponse, error) {
+	var response TonGetMasterchainBlockSignaturesResponse
+	if err := c.Call(ctx, "getMasterchainBlockSignatures", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetMasterchainInfo(ctx context.Context) (*TonGetMasterchainInfoResponse, error) {
+	var response TonGetMasterchainInfoResponse
+	if err := c.Call(ctx, "getMasterchainInfo", nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetShardBlockProof(ctx context.Context, params TonGetShardBlockProof) (*TonGetShardBlockProofResponse, error) {
+	var response TonGetShardBlockProofResponse
+	if err := c.Call(ctx, "getShardBlockProof", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) LookupBlock(ctx context.Context, params TonLookupBlock) (*TonLookupBlockResponse, error) {
+	var response TonLookupBlockResponse
+	if err := c.Call(ctx, "lookupBlock", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) Shards(ctx context.Context, params TonShards) (*TonShardsResponse, error) {
+	var response TonShardsResponse
+	if err := c.Call(ctx, "shards", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetConfigParam(ctx context.Context, params TonGetConfigParam) (*TonGetConfigParamResponse, error) {
+	var response TonGetConfigParamResponse
+	if err := c.Call(ctx, "getConfigParam", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RunGetMethod runs a get method, stack entries are [type, value] pairs such as ["num", "0x1"]
+func (c *Client) RunGetMethod(ctx context.Context, params RunGetMethod) (*TonRunGetMethodResponse, error) {
+	var response TonRunGetMethodResponse
+	if err := c.Call(ctx, "runGetMethod", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) EstimateFee(ctx context.Context, params TonEstimateFee) (*TonEstimateFeeResponse, error) {
+	var response TonEstimateFeeResponse
+	if err := c.Call(ctx, "estimateFee", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) SendBoc(ctx context.Context, params TonSendBoc) (*TonSendBocResponse, error) {
+	var response TonSendBocResponse
+	if err := c.Call(ctx, "sendBoc", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// SendBocReturnHash submits a serialized external message and returns its hash
+func (c *Client) SendBocReturnHash(ctx context.Context, params TonSendBocReturnHash) (*TonSendBocReturnHashResponse, error) {
+	var response TonSendBocReturnHashResponse
+	if err := c.Call(ctx, "sendBocReturnHash", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetBlockTransactions(ctx context.Context, params TonGetBlockTransactions) (*TonGetBlockTransactionsResponse, error) {
+	var response TonGetBlockTransactionsResponse
+	if err := c.Call(ctx, "getBlockTransactions", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetTransactions returns the latest transactions of an account, newest first
+func (c *Client) GetTransactions(ctx context.Context, params TonGetTransactions) (*TonGetTransactionsResponse, error) {
+	var response TonGetTransactionsResponse
+	if err := c.Call(ctx, "getTransactions", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// TryLocateResultTx finds the transaction of destination that received the message sent by source at created lt
+func (c *Client) TryLocateResultTx(ctx context.Context, params TonTryLocateResultTx) (*TonTryLocateTxResponse, error) {
+	var response TonTryLocateTxResponse
+	if err := c.Call(ctx, "tryLocateResultTx", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// TryLocateSourceTx finds the transaction of source that sent the message received by destination
+func (c *Client) TryLocateSourceTx(ctx context.Context, params TonTryLocateSourceTx) (*TonTryLocateTxResponse, error) {
+	var response TonTryLocateTxResponse
+	if err := c.Call(ctx, "tryLocateSourceTx", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// TryLocateTx finds the transaction of destination that received the message sent by source at created lt
+func (c *Client) TryLocateTx(ctx context.Context, params TonTryLocateTx) (*TonTryLocateTxResponse, error) {
+	var response TonTryLocateTxResponse
+	if err := c.Call(ctx, "tryLocateTx", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
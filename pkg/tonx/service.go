@@ -2,40 +2,183 @@ package tonx
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"time"
 )
 
-// generic request method for TonX
-func SendTonXRequest(url, apiKey, jsonrpc string, id int, method string, params interface{}) (string, error) {
-	requestBody := TonXRequest{
-		Jsonrpc: jsonrpc,
-		Id:      id,
+const (
+	jsonrpcVersion    = "2.0"
+	defaultTimeout    = 10 * time.Second
+	defaultRetries    = 2
+	defaultRetryDelay = 500 * time.Millisecond
+)
+
+// Client calls the TonX json-rpc api, the api key is part of the url and never logged
+type Client struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+	retries    int
+	retryDelay time.Duration
+	id         atomic.Int64
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default client, which times out after 10 seconds
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries retries failed transports, 429 and 5xx responses, the delay doubles after every attempt
+func WithRetries(retries int, delay time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.retryDelay = delay
+	}
+}
+
+func NewClient(endpoint, apiKey string, options ...Option) *Client {
+	c := &Client{
+		url:        endpoint,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retries:    defaultRetries,
+		retryDelay: defaultRetryDelay,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// NewTestnetClient creates a client from TONX_API_BASE_TESTNET_URL and TONX_TESTNET_API_KEY_1
+func NewTestnetClient(options ...Option) (*Client, error) {
+	return newEnvClient("TONX_API_BASE_TESTNET_URL", "TONX_TESTNET_API_KEY_1", options)
+}
+
+// NewMainnetClient creates a client from TONX_API_BASE_URL and TONX_MAINNET_API_KEY_1
+func NewMainnetClient(options ...Option) (*Client, error) {
+	return newEnvClient("TONX_API_BASE_URL", "TONX_MAINNET_API_KEY_1", options)
+}
+
+func newEnvClient(urlEnv, apiKeyEnv string, options []Option) (*Client, error) {
+	endpoint, apiKey := os.Getenv(urlEnv), os.Getenv(apiKeyEnv)
+	if endpoint == "" || apiKey == "" {
+		return nil, fmt.Errorf("tonx not configured, %s and %s are required", urlEnv, apiKeyEnv)
+	}
+	return NewClient(endpoint, apiKey, options...), nil
+}
+
+// URL returns the endpoint without the api key
+func (c *Client) URL() string {
+	return c.url
+}
+
+// retryableError is a failure worth another attempt
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// Call sends a json-rpc request and decodes its result into result, json-rpc errors are returned as *TonXError
+func (c *Client) Call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	if params == nil {
+		params = struct{}{}
+	}
+	requestData, err := json.Marshal(TonXRequest{
+		Jsonrpc: jsonrpcVersion,
+		Id:      c.id.Add(1),
 		Method:  method,
 		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling %s request: %v", method, err)
 	}
 
-	requestData, err := json.Marshal(requestBody)
-	if err != nil {
-		return "", fmt.Errorf("error marshalling request data: %v", err)
+	delay := c.retryDelay
+	for attempt := 0; ; attempt++ {
+		err = c.call(ctx, method, requestData, result)
+		var retryable *retryableError
+		if !errors.As(err, &retryable) {
+			return err
+		}
+		if attempt >= c.retries {
+			return retryable.err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
 	}
-	fmt.Printf("\nrequestData: \n%v", requestData)
+}
 
-	fullURL := fmt.Sprintf("%s/%s", url, apiKey)
-	fmt.Printf("\nrequest url: %s\n\n", fullURL)
+func (c *Client) call(ctx context.Context, method string, requestData []byte, result interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s", c.url, c.apiKey), bytes.NewReader(requestData))
+	if err != nil {
+		return fmt.Errorf("error creating %s request: %v", method, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.Post(fullURL, "application/json", bytes.NewBuffer(requestData))
+	resp, err := c.httpClient.Do(request)
 	if err != nil {
-		return "", fmt.Errorf("error sending request: %v", err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// the url error repeats the api key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return &retryableError{fmt.Errorf("error sending %s request: %v", method, err)}
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("error reading response body: %v", err)
+		return &retryableError{fmt.Errorf("error reading %s response: %v", method, err)}
 	}
 
-	return string(body), nil
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	var response TonXResponse
+	if err := json.Unmarshal(body, &response); err != nil || (response.Error == nil && response.Result == nil) {
+		err = fmt.Errorf("%s returned status %d", method, resp.StatusCode)
+		if retryable {
+			return &retryableError{err}
+		}
+		return err
+	}
+	if response.Error != nil {
+		if retryable {
+			return &retryableError{response.Error}
+		}
+		return response.Error
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("error parsing %s response: %v", method, err)
+	}
+	return nil
 }
@@ -0,0 +1,130 @@
+package tonx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/tonx"
+	"github.com/crosscall-labs/crosschain-api/pkg/tonx/tonxtest"
+)
+
+func TestCall(t *testing.T) {
+	server := tonxtest.NewServer()
+	defer server.Close()
+	server.Handle("getAddressBalance", func(params json.RawMessage) (interface{}, *tonx.TonXError) {
+		var request tonx.TonGetAddressBalance
+		if err := json.Unmarshal(params, &request); err != nil || request.Address != "EQ-test" {
+			return nil, &tonx.TonXError{Code: -32602, Message: "invalid params"}
+		}
+		return "1000", nil
+	})
+
+	balance, err := server.Client().GetAddressBalance(context.Background(), tonx.TonGetAddressBalance{Address: "EQ-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *balance != "1000" {
+		t.Fatalf("balance %s, want 1000", *balance)
+	}
+	calls := server.Calls()
+	if len(calls) != 1 || calls[0].Method != "getAddressBalance" || calls[0].APIKey != tonxtest.APIKey {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+}
+
+func TestCallRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		rpcError bool
+		wantErr  bool
+		calls    int
+	}{
+		{"ok", nil, false, false, 1},
+		{"retried 503", []int{http.StatusServiceUnavailable}, false, false, 2},
+		{"retried 429 then 502", []int{http.StatusTooManyRequests, http.StatusBadGateway}, false, false, 3},
+		{"retries exhausted", []int{500, 500, 500}, false, true, 3},
+		{"400 not retried", []int{http.StatusBadRequest}, false, true, 1},
+		{"json-rpc error not retried", nil, true, true, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := tonxtest.NewServer()
+			defer server.Close()
+			if test.rpcError {
+				server.Error("getMasterchainInfo", -32000, "lite server error")
+			} else {
+				server.Result("getMasterchainInfo", tonx.TonGetMasterchainInfoResponse{})
+			}
+			server.FailNext("getMasterchainInfo", test.statuses...)
+
+			_, err := server.Client().GetMasterchainInfo(context.Background())
+			if (err != nil) != test.wantErr {
+				t.Fatalf("err %v, want error %v", err, test.wantErr)
+			}
+			if got := len(server.Calls()); got != test.calls {
+				t.Fatalf("%d calls, want %d", got, test.calls)
+			}
+			var rpcErr *tonx.TonXError
+			if test.wantErr && !errors.As(err, &rpcErr) {
+				t.Fatalf("err %v is not a TonXError", err)
+			}
+		})
+	}
+}
+
+func TestCallContext(t *testing.T) {
+	server := tonxtest.NewServer()
+	defer server.Close()
+	server.Result("getMasterchainInfo", tonx.TonGetMasterchainInfoResponse{})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := server.Client().GetMasterchainInfo(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err %v, want context.Canceled", err)
+		}
+	})
+
+	t.Run("deadline during retry delay", func(t *testing.T) {
+		server.FailNext("getMasterchainInfo", http.StatusServiceUnavailable)
+		before := len(server.Calls())
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := server.Client(tonx.WithRetries(2, time.Hour)).GetMasterchainInfo(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err %v, want context.DeadlineExceeded", err)
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("retry delay ignored the context")
+		}
+		if got := len(server.Calls()) - before; got != 1 {
+			t.Fatalf("%d calls, want 1", got)
+		}
+	})
+}
+
+func TestNewTestnetClient(t *testing.T) {
+	t.Setenv("TONX_API_BASE_TESTNET_URL", "")
+	t.Setenv("TONX_TESTNET_API_KEY_1", "")
+	if _, err := tonx.NewTestnetClient(); err == nil {
+		t.Fatal("expected an error without configuration")
+	}
+
+	t.Setenv("TONX_API_BASE_TESTNET_URL", "https://testnet-rpc.tonxapi.com/v2/json-rpc")
+	t.Setenv("TONX_TESTNET_API_KEY_1", "key")
+	client, err := tonx.NewTestnetClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.URL() != "https://testnet-rpc.tonxapi.com/v2/json-rpc" {
+		t.Fatalf("url %s", client.URL())
+	}
+}
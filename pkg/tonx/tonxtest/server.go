@@ -0,0 +1,139 @@
+// Package tonxtest fakes the TonX json-rpc api with an httptest server
+package tonxtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/tonx"
+)
+
+const APIKey = "test-api-key"
+
+// Handler returns the result of a call or its json-rpc error
+type Handler func(params json.RawMessage) (interface{}, *tonx.TonXError)
+
+type Call struct {
+	Method string
+	APIKey string
+	Params json.RawMessage
+}
+
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	status   map[string][]int // queued http statuses returned before the handler runs
+	calls    []Call
+}
+
+// NewServer starts a fake, methods without a handler fail with -32601. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		handlers: map[string]Handler{},
+		status:   map[string][]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Client returns a client of the fake without retry delays
+func (s *Server) Client(options ...tonx.Option) *tonx.Client {
+	options = append([]tonx.Option{tonx.WithRetries(2, 0)}, options...)
+	return tonx.NewClient(s.URL, APIKey, options...)
+}
+
+func (s *Server) Handle(method string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// Result answers every call of method with result
+func (s *Server) Result(method string, result interface{}) {
+	s.Handle(method, func(json.RawMessage) (interface{}, *tonx.TonXError) {
+		return result, nil
+	})
+}
+
+// Error answers every call of method with a json-rpc error
+func (s *Server) Error(method string, code int, message string) {
+	s.Handle(method, func(json.RawMessage) (interface{}, *tonx.TonXError) {
+		return nil, &tonx.TonXError{Code: code, Message: message}
+	})
+}
+
+// FailNext makes the next calls of method return the http statuses in order, before the handler answers
+func (s *Server) FailNext(method string, statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status[method] = append(s.status[method], statuses...)
+}
+
+// Calls returns the calls received so far
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call{}, s.calls...)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Jsonrpc string          `json:"jsonrpc"`
+		Id      int64           `json:"id"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, tonx.TonXResponse{
+			Jsonrpc: "2.0",
+			Error:   &tonx.TonXError{Code: -32700, Message: "parse error"},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{
+		Method: request.Method,
+		APIKey: strings.TrimPrefix(r.URL.Path, "/"),
+		Params: request.Params,
+	})
+	handler, ok := s.handlers[request.Method]
+	status := http.StatusOK
+	if queued := s.status[request.Method]; len(queued) > 0 {
+		status, s.status[request.Method] = queued[0], queued[1:]
+	}
+	s.mu.Unlock()
+
+	response := tonx.TonXResponse{Jsonrpc: "2.0", Id: request.Id}
+	switch {
+	case status != http.StatusOK:
+		response.Error = &tonx.TonXError{Code: status, Message: http.StatusText(status)}
+	case !ok:
+		response.Error = &tonx.TonXError{Code: -32601, Message: "method not found"}
+	default:
+		result, rpcErr := handler(request.Params)
+		if rpcErr != nil {
+			response.Error = rpcErr
+			break
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			status = http.StatusInternalServerError
+			response.Error = &tonx.TonXError{Code: -32603, Message: err.Error()}
+			break
+		}
+		response.Result = data
+	}
+	writeResponse(w, status, response)
+}
+
+func writeResponse(w http.ResponseWriter, status int, response tonx.TonXResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -2,8 +2,10 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -12,6 +14,9 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+	"github.com/crosscall-labs/crosschain-api/pkg/bind"
+	"github.com/crosscall-labs/crosschain-api/pkg/chains"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -25,55 +30,20 @@ func WriteJSONResponse(w http.ResponseWriter, r *http.Request, message string) {
 	}
 }
 
+// ParseAndValidateParams binds the query string, or a json body, into params with pkg/bind,
+// invalid fields come back as a malformed request listing each field
 func ParseAndValidateParams(r *http.Request, params interface{}) error {
-	val := reflect.ValueOf(params).Elem() // Dereference the pointer to access the underlying struct
-	if val.Kind() == reflect.Ptr && !val.IsNil() {
-		val = val.Elem()
-	}
-	typ := val.Type()
-
-	missingFields := []string{}
-	allowedFields := make(map[string]struct{})
-
-	fmt.Printf("\nfieldType.Type: %v", typ)
-	for i := 0; i < val.NumField(); i++ {
-		field := val.Field(i)
-		fieldType := typ.Field(i)
-		queryTag := fieldType.Tag.Get("query")
-		optionalTag := fieldType.Tag.Get("optional")
-
-		if queryTag != "" {
-			allowedFields[queryTag] = struct{}{}
-		}
-
-		// fmt.Printf("\nfieldType.Name: %v", fieldType.Name)
-		if _, exists := typ.FieldByName(fieldType.Name); exists {
-			if field.Kind() == reflect.Struct {
-				// Recursively parse nested struct fields
-				nestedParams := reflect.New(fieldType.Type).Interface()
-				if err := ParseAndValidateParams(r, nestedParams); err != nil {
-					return err
-				}
-				// After recursion, set the original struct's field value
-				field.Set(reflect.ValueOf(nestedParams).Elem())
-			} else if queryTag != "" {
-				queryValue := r.URL.Query().Get(queryTag)
-
-				// If the field is required (i.e., optional is not set to "true")
-				if queryValue == "" && optionalTag != "true" {
-					missingFields = append(missingFields, queryTag)
-				} else if queryValue != "" {
-					field.SetString(queryValue)
-				}
-			}
-		}
+	err := bind.Bind(r, params)
+	var validationErr *bind.ValidationError
+	if errors.As(err, &validationErr) {
+		e := apierr.New(apierr.InvalidParam, validationErr.Error())
+		e.Origin = GetOrigin()
+		e.Fields = validationErr.Fields
+		return e
 	}
-
-	// If there are missing fields, return an error response
-	if len(missingFields) > 0 {
-		return ErrMalformedRequest(fmt.Sprint("Missing fields: " + strings.Join(missingFields, ", ")))
+	if err != nil {
+		return ErrInternal(err.Error())
 	}
-
 	return nil
 }
 
@@ -107,10 +77,6 @@ func PrintStructFields(params interface{}) {
 	}
 }
 
-func (e Error) Error() string {
-	return fmt.Sprintf("Error (Code: %d, Message: %s)", e.Code, e.Message)
-}
-
 // func ErrMalformedRequest(w http.ResponseWriter, message string) {
 // 	w.Header().Set("Content-Type", "application/json")
 // 	w.WriteHeader(http.StatusBadRequest)
@@ -136,25 +102,22 @@ func GetOrigin() string {
 }
 
 func ErrMalformedRequest(message string) error {
-	origin := GetOrigin()
-
-	return Error{
-		Code:    400,
-		Message: "Malformed request",
-		Details: message,
-		Origin:  origin,
-	}
+	e := apierr.New(apierr.InvalidParam, message)
+	e.Origin = GetOrigin()
+	return e
 }
 
 func ErrInternal(message string) Error {
-	origin := GetOrigin()
+	e := apierr.New(apierr.Internal, message)
+	e.Origin = GetOrigin()
+	return e
+}
 
-	return Error{
-		Code:    500,
-		Message: "Internal server error",
-		Details: message,
-		Origin:  origin,
-	}
+// Err builds an error of kind, for the kinds without a helper above
+func Err(kind apierr.Kind, message string) error {
+	e := apierr.New(kind, message)
+	e.Origin = GetOrigin()
+	return e
 }
 
 func EnvKey2Ecdsa() (*ecdsa.PrivateKey, common.Address, error) {
@@ -220,7 +183,7 @@ func EnableCORS(next http.Handler) http.Handler {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Admin-Key")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		// Handle preflight requests
@@ -235,7 +198,7 @@ func EnableCORS(next http.Handler) http.Handler {
 }
 
 func CheckChainPartialType(chainId, partialType, txType string) (string, string, string, string) {
-	chainIdOut, chainType, chainName, entrypointTypes, escrowTypes, errorStr := CheckChainType(chainId)
+	chainIdOut, chainType, chainName, escrowTypes, entrypointTypes, errorStr := CheckChainType(chainId)
 	if errorStr != "" {
 		return "", "", "", errorStr
 	}
@@ -274,54 +237,14 @@ func CheckChainPartialType(chainId, partialType, txType string) (string, string,
 // case "0xBF04", "48900": // zircuit mainnet
 func CheckChainType(chainId string) (string, string, string, []int, []int, string) { // out: id, vm, name, escrowType, entrypointType, error
 	disabled := fmt.Sprintf("unsupported chain ID: %s", chainId)
-	switch chainId {
-	case "0x3106A", "200810": // bitlayer testnet
-		return "200810", "evm", "bitlayerTestnet", []int{0, 1}, []int{0, 1, 2}, ""
-	case "0x4268", "17000": // holesky
-		return "17000", "evm", "ethereumHoleskyTestnet", []int{0, 1}, []int{0, 1, 2}, ""
-	case "0xAA36A7", "11155111": // sepolia
-		return "11155111", "evm", "ethereumSepoliaTestnet", []int{0, 1}, []int{0, 1, 2}, ""
-	case "0xE34", "3636": // botanix testnet
-		return "3636", "evm", "botanixTestnet", []int{0, 1}, []int{0, 1, 2}, disabled
-	case "0xF35A", "62298": // citrea testnet
-		return "62298", "evm", "citreaTestnet", []int{0, 1}, []int{0, 1, 2}, ""
-	case "0x13881", "80001": // matic mumbai
-		return "80001", "evm", "maticMumbai", nil, nil, disabled
-	case "0x13882", "80002": // matic amoy
-		return "80002", "evm", "maticAmoy", nil, nil, disabled
-	case "0xC3", "195": // x layer testnet
-		return "195", "evm", "xLayerEvmTestnet", nil, nil, disabled
-	case "0xAEF3", "44787": // celo alfajores
-		return "44787", "evm", "celoAlforesTestnet", nil, nil, disabled
-	case "0x5E9", "1513": // story testnet
-		return "1513", "evm", "storyEvmTestnet", nil, nil, disabled
-	case "0x8274F", "534351": // scroll testnet
-		return "534351", "evm", "scrollEvmTestnet", nil, nil, disabled
-	case "0xAA37DC", "11155420": // op sepolia
-		return "11155420", "evm", "optimismSepoliaTestnet", nil, nil, disabled
-	case "0x66EEE", "421614": // arbitrum sepolia
-		return "421614", "evm", "arbitrumSepoliaTestnet", nil, nil, disabled
-	case "0x14A34", "84532": // base sepolia
-		return "84532", "evm", "baseSepoliaTestnet", nil, nil, disabled
-	case "0x4CB2F", "314159": // filecoin calibration
-		return "314159", "evm", "filecoinEvmTestnet", nil, nil, disabled
-	case "0xBF03", "48899": // zircuit testnet
-		return "48899", "evm", "zircuitTestnet", nil, nil, disabled
-	case "0x63639999", "1667471769": // tvm workchain_id == -1 ton testnet
-		return "1667471769", "tvm", "tonTvmTestnet", []int{2}, []int{0, 1, 2}, ""
-	case "0x53564D0002", "357930172418": // solana devnet
-		return "357930172418", "svm", "solanaSvmDevnet", nil, nil, disabled
-	case "0x53564D0003", "357930172419": // solana testnet
-		return "357930172419", "svm", "solanaSvmTestnet", nil, nil, disabled
-	case "0x53564D0004", "357930172420": // eclipse (solana) testnet
-		return "357930172420", "svm", "eclipseSvmTestnet", nil, nil, disabled
-	default:
+	chain, found := chains.Lookup(chainId)
+	if !found {
 		return "", "", "", nil, nil, disabled
 	}
-}
-
-var disabled = func(chainId string) error {
-	return ErrMalformedRequest(fmt.Sprintf("unsupported chain ID: %s", chainId))
+	if !chain.Enabled {
+		return chain.ID, chain.VM, chain.Name, chain.EscrowTypes, chain.EntrypointTypes, disabled
+	}
+	return chain.ID, chain.VM, chain.Name, chain.EscrowTypes, chain.EntrypointTypes, ""
 }
 
 // this will need to later be added to db
@@ -415,49 +338,6 @@ var disabled = func(chainId string) error {
 // 	return "", "", "", nil, nil, disabled
 // }
 
-var chainClientMap = map[string]ChainInfo{
-	"0x3106A":      {"200810", "evm", "bitlayerTestnet", []int{0, 1}, []int{0, 1, 2}, nil},
-	"200810":       {"200810", "evm", "bitlayerTestnet", []int{0, 1}, []int{0, 1, 2}, nil},
-	"0x4268":       {"17000", "evm", "ethereumHoleskyTestnet", []int{0, 1}, []int{0, 1, 2}, nil},
-	"17000":        {"17000", "evm", "ethereumHoleskyTestnet", []int{0, 1}, []int{0, 1, 2}, nil},
-	"0xAA36A7":     {"11155111", "evm", "ethereumSepoliaTestnet", []int{0, 1}, []int{0, 1, 2}, nil},
-	"11155111":     {"11155111", "evm", "ethereumSepoliaTestnet", []int{0, 1}, []int{0, 1, 2}, nil},
-	"0xE34":        {"3636", "evm", "botanixTestnet", []int{0, 1}, []int{0, 1, 2}, disabled("3636")},
-	"3636":         {"3636", "evm", "botanixTestnet", []int{0, 1}, []int{0, 1, 2}, disabled("3636")},
-	"0xF35A":       {"62298", "evm", "citreaTestnet", []int{0, 1}, []int{0, 1, 2}, nil},
-	"62298":        {"62298", "evm", "citreaTestnet", []int{0, 1}, []int{0, 1, 2}, nil},
-	"0x13881":      {"80001", "evm", "maticMumbai", nil, nil, disabled("80001")},
-	"80001":        {"80001", "evm", "maticMumbai", nil, nil, disabled("80001")},
-	"0x13882":      {"80002", "evm", "maticAmoy", nil, nil, disabled("80002")},
-	"80002":        {"80002", "evm", "maticAmoy", nil, nil, disabled("80002")},
-	"0xC3":         {"195", "evm", "xLayerEvmTestnet", nil, nil, disabled("195")},
-	"195":          {"195", "evm", "xLayerEvmTestnet", nil, nil, disabled("195")},
-	"0xAEF3":       {"44787", "evm", "celoAlforesTestnet", nil, nil, disabled("44787")},
-	"44787":        {"44787", "evm", "celoAlforesTestnet", nil, nil, disabled("44787")},
-	"0x5E9":        {"1513", "evm", "storyEvmTestnet", nil, nil, disabled("1513")},
-	"1513":         {"1513", "evm", "storyEvmTestnet", nil, nil, disabled("1513")},
-	"0x8274F":      {"534351", "evm", "scrollEvmTestnet", nil, nil, disabled("534351")},
-	"534351":       {"534351", "evm", "scrollEvmTestnet", nil, nil, disabled("534351")},
-	"0xAA37DC":     {"11155420", "evm", "optimismSepoliaTestnet", nil, nil, disabled("11155420")},
-	"11155420":     {"11155420", "evm", "optimismSepoliaTestnet", nil, nil, disabled("11155420")},
-	"0x66EEE":      {"421614", "evm", "arbitrumSepoliaTestnet", nil, nil, disabled("421614")},
-	"421614":       {"421614", "evm", "arbitrumSepoliaTestnet", nil, nil, disabled("421614")},
-	"0x14A34":      {"84532", "evm", "baseSepoliaTestnet", nil, nil, disabled("84532")},
-	"84532":        {"84532", "evm", "baseSepoliaTestnet", nil, nil, disabled("84532")},
-	"0x4CB2F":      {"314159", "evm", "filecoinEvmTestnet", nil, nil, disabled("314159")},
-	"314159":       {"314159", "evm", "filecoinEvmTestnet", nil, nil, disabled("314159")},
-	"0xBF03":       {"48899", "evm", "zircuitTestnet", nil, nil, disabled("48899")},
-	"48899":        {"48899", "evm", "zircuitTestnet", nil, nil, disabled("48899")},
-	"0x63639999":   {"1667471769", "tvm", "tonTvmTestnet", []int{2}, []int{0, 1, 2}, nil},
-	"1667471769":   {"1667471769", "tvm", "tonTvmTestnet", []int{2}, []int{0, 1, 2}, nil},
-	"0x53564D0002": {"357930172418", "svm", "solanaSvmDevnet", nil, nil, disabled("357930172418")},
-	"357930172418": {"357930172418", "svm", "solanaSvmDevnet", nil, nil, disabled("357930172418")},
-	"0x53564D0003": {"357930172419", "svm", "solanaSvmTestnet", nil, nil, disabled("357930172419")},
-	"357930172419": {"357930172419", "svm", "solanaSvmTestnet", nil, nil, disabled("357930172419")},
-	"0x53564D0004": {"357930172420", "svm", "eclipseSvmTestnet", nil, nil, disabled("357930172420")},
-	"357930172420": {"357930172420", "svm", "eclipseSvmTestnet", nil, nil, disabled("357930172420")},
-}
-
 // func checkChainStatus(chainId string) (*ethclient.Client, *Chain, error) {
 // 	var client *ethclient.Client
 // 	var chain *Chain
@@ -611,29 +491,13 @@ func Bytes32PadLeft(data []byte) [32]byte {
 	return result
 }
 
-var chainTypeMap = map[string]struct {
-	ChainId string
-	VM      string
-}{
-	"0x3106A":    {ChainId: "200810", VM: "evm"},
-	"200810":     {ChainId: "200810", VM: "evm"},
-	"0x4268":     {ChainId: "17000", VM: "evm"},
-	"17000":      {ChainId: "17000", VM: "evm"},
-	"0xAA36A7":   {ChainId: "11155111", VM: "evm"},
-	"11155111":   {ChainId: "11155111", VM: "evm"},
-	"0xF35A":     {ChainId: "62298", VM: "evm"},
-	"62298":      {ChainId: "62298", VM: "evm"},
-	"0x63639999": {ChainId: "1667471769", VM: "tvm"},
-	"1667471769": {ChainId: "1667471769", VM: "tvm"},
-	"998":        {ChainId: "998", VM: "evm"},
-}
-
 func GetChainType(chainId string) (string, string, error) {
-	if data, found := chainTypeMap[chainId]; found {
-		return data.ChainId, data.VM, nil
+	chain, err := chains.Get(chainId)
+	if err != nil {
+		return "", "", fmt.Errorf("unsupporting chain id: %s", chainId)
 	}
 
-	return "", "", fmt.Errorf("unsupporting chain id: %s", chainId)
+	return chain.ID, chain.VM, nil
 }
 
 func HexToUint64(hexStr string) (uint64, error) {
@@ -664,3 +528,13 @@ func BytesToUint64(bytes []byte) (uint64, error) {
 	}
 	return value, nil
 }
+
+// AdminAuthorized checks the X-Admin-Key header against ADMIN_API_KEY in constant time, admin
+// routes are closed while the key is unset
+func AdminAuthorized(r *http.Request) bool {
+	adminKey := os.Getenv("ADMIN_API_KEY")
+	if adminKey == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Key")), []byte(adminKey)) == 1
+}
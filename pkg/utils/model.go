@@ -1,15 +1,13 @@
 package utils
 
+import "github.com/crosscall-labs/crosschain-api/pkg/apierr"
+
 type VersionResponse struct {
 	Version string `json:"version"`
 }
 
-type Error struct {
-	Code    uint64 `json:"code"`
-	Message string `json:"message"`
-	Details string `json:"details"`
-	Origin  string `json:"origin"`
-}
+// Error is the error returned to clients, see pkg/apierr for the kinds and their codes
+type Error = apierr.Error
 
 type PartialHeader struct {
 	TxType      string `query:"txtype"`               // for now just type1 tx and type0 (legacy)
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/crosscall-labs/crosschain-api/pkg/apierr"
+)
+
+// WriteResponse is the response writer shared by every handler, errors are answered with the
+// http status of their kind
+func WriteResponse(w http.ResponseWriter, response interface{}, err error) {
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	// encoded before the header is written, a failed encoding can still answer 500
+	data, err := json.Marshal(response)
+	if err != nil {
+		WriteError(w, ErrInternal(fmt.Sprintf("failed to encode response: %v", err)))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(data, '\n'))
+}
+
+func WriteError(w http.ResponseWriter, err error) {
+	apiErr := apierr.From(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.Status())
+	json.NewEncoder(w).Encode(apiErr)
+}